@@ -0,0 +1,82 @@
+package librariesio
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// AlternativesOptions configures FindAlternatives
+type AlternativesOptions struct {
+	// SamePlatform only returns candidates from the platform of the
+	// original project
+	SamePlatform bool
+
+	// Limit caps the number of returned candidates, 0 means no limit
+	Limit int
+}
+
+// FindAlternatives returns projects comparable to the given project.
+// It searches for projects sharing the keywords of the original project,
+// drops candidates written in a different language as well as the original
+// itself and ranks the remaining ones by SourceRank and stars.
+func (c *Client) FindAlternatives(ctx context.Context, plat, name string, opts *AlternativesOptions) ([]*Project, error) {
+	if opts == nil {
+		opts = &AlternativesOptions{}
+	}
+
+	project, _, err := c.Project(ctx, plat, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var keywords []string
+	for _, k := range project.Keywords {
+		if k != nil && *k != "" {
+			keywords = append(keywords, *k)
+		}
+	}
+	q := strings.Join(keywords, " ")
+	if q == "" {
+		q = name
+	}
+
+	candidates, _, err := c.Search(ctx, q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var alternatives []*Project
+	for _, candidate := range candidates {
+		if isSameProject(candidate, plat, name) {
+			continue
+		}
+		if opts.SamePlatform && !strings.EqualFold(stringValue(candidate.Platform), plat) {
+			continue
+		}
+		if project.Language != nil && candidate.Language != nil &&
+			!strings.EqualFold(*project.Language, *candidate.Language) {
+			continue
+		}
+		alternatives = append(alternatives, candidate)
+	}
+
+	sort.SliceStable(alternatives, func(i, j int) bool {
+		ri, rj := intValue(alternatives[i].Rank), intValue(alternatives[j].Rank)
+		if ri != rj {
+			return ri > rj
+		}
+		return intValue(alternatives[i].Stars) > intValue(alternatives[j].Stars)
+	})
+
+	if opts.Limit > 0 && len(alternatives) > opts.Limit {
+		alternatives = alternatives[:opts.Limit]
+	}
+
+	return alternatives, nil
+}
+
+func isSameProject(p *Project, plat, name string) bool {
+	return strings.EqualFold(stringValue(p.Platform), plat) &&
+		strings.EqualFold(stringValue(p.Name), name)
+}
@@ -0,0 +1,63 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestFindAlternatives(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{
+			"name": "cookiecutter",
+			"platform": "Pypi",
+			"language": "Python",
+			"keywords": ["template", "scaffolding"]
+		}`)
+	})
+	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.Query().Get("q"), "template scaffolding"; got != want {
+			t.Errorf("search query is %q, want %q", got, want)
+		}
+		fmt.Fprintf(w, `[
+			{"name": "cookiecutter", "platform": "Pypi", "language": "Python", "rank": 20},
+			{"name": "yeoman", "platform": "NPM", "language": "JavaScript", "rank": 25},
+			{"name": "copier", "platform": "Pypi", "language": "Python", "rank": 10, "stars": 100},
+			{"name": "mr.bob", "platform": "Pypi", "language": "Python", "rank": 10, "stars": 200},
+			{"name": "cruft", "platform": "Pypi", "language": "Python", "rank": 12}
+		]`)
+	})
+
+	alternatives, err := client.FindAlternatives(context.Background(), "pypi", "cookiecutter", nil)
+	if err != nil {
+		t.Fatalf("FindAlternatives returned unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, p := range alternatives {
+		got = append(got, *p.Name)
+	}
+
+	want := []string{"cruft", "mr.bob", "copier"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, got)
+	}
+
+	alternatives, err = client.FindAlternatives(
+		context.Background(), "pypi", "cookiecutter",
+		&AlternativesOptions{Limit: 1},
+	)
+	if err != nil {
+		t.Fatalf("FindAlternatives returned unexpected error: %v", err)
+	}
+	if len(alternatives) != 1 || *alternatives[0].Name != "cruft" {
+		t.Errorf("expected only cruft to be returned, got %v", alternatives)
+	}
+}
@@ -0,0 +1,116 @@
+package librariesio
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// AuditEntry records a single request sent to the API
+type AuditEntry struct {
+	Time   time.Time `json:"time"`
+	Method string    `json:"method"`
+
+	// URL is the request URL with the API key redacted
+	URL string `json:"url"`
+
+	// Status is the HTTP status of the response,
+	// it is zero if no response was received
+	Status int `json:"status,omitempty"`
+
+	// Bytes is the size of the response body that was read
+	Bytes int64 `json:"bytes"`
+
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// AuditSink stores audit entries, implementations must be safe for
+// concurrent use. A sink backed by a database can be plugged in by
+// implementing Record.
+type AuditSink interface {
+	Record(entry *AuditEntry) error
+}
+
+// WithAuditSink records every request sent to the API to sink, including
+// failed requests. Responses served from the cache set with WithCache and
+// requests skipped by WithDryRun are not sent and therefore not recorded.
+// Recording is best effort, errors of the sink do not fail requests.
+func WithAuditSink(sink AuditSink) ClientOption {
+	return func(c *Client) {
+		c.auditSink = sink
+	}
+}
+
+// FileAuditSink is an AuditSink that appends entries as JSON lines
+// to a file. It is safe for concurrent use within a single process.
+type FileAuditSink struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileAuditSink returns a sink writing to path,
+// the file is created when the first entry is recorded
+func NewFileAuditSink(path string) *FileAuditSink {
+	return &FileAuditSink{path: path}
+}
+
+// Record appends the entry to the file
+func (s *FileAuditSink) Record(entry *AuditEntry) error {
+	line, err := marshalLine(entry)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(line); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// audit records req to the audit sink, resp is nil if no response was received
+func (c *Client) audit(req *http.Request, resp *http.Response, err error, start time.Time) {
+	if c.auditSink == nil {
+		return
+	}
+
+	entry := &AuditEntry{
+		Time:     start,
+		Method:   req.Method,
+		URL:      redactAPIKey(req.URL).String(),
+		Duration: time.Since(start),
+	}
+	if resp != nil {
+		entry.Status = resp.StatusCode
+		if body, ok := resp.Body.(*countingBody); ok {
+			entry.Bytes = body.n
+		}
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	c.auditSink.Record(entry)
+}
+
+// countingBody counts the bytes read from a response body
+type countingBody struct {
+	io.ReadCloser
+	n int64
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
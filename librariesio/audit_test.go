@@ -0,0 +1,111 @@
+package librariesio
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWithAuditSink(t *testing.T) {
+	server, mux, url := startNewServer()
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	client := NewClient(APIKey, WithAuditSink(NewFileAuditSink(path)))
+	client.BaseURL = url
+
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"cookiecutter"}`)
+	})
+	mux.HandleFunc("/pypi/nope", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Not Found"}`, http.StatusNotFound)
+	})
+
+	ctx := context.Background()
+	if _, _, err := client.Project(ctx, "pypi", "cookiecutter"); err != nil {
+		t.Fatalf("Project returned unexpected error: %v", err)
+	}
+	if _, _, err := client.Project(ctx, "pypi", "nope"); err == nil {
+		t.Fatal("Expected error for missing project")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("could not open audit file: %v", err)
+	}
+	defer f.Close()
+
+	var entries []*AuditEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		entry := new(AuditEntry)
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			t.Fatalf("invalid audit entry %s: %v", scanner.Bytes(), err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+
+	ok, failed := entries[0], entries[1]
+	if ok.Method != "GET" || ok.Status != http.StatusOK || ok.Bytes != int64(len(`{"name":"cookiecutter"}`)) || ok.Error != "" {
+		t.Errorf("unexpected entry %+v", ok)
+	}
+	if failed.Status != http.StatusNotFound || failed.Bytes == 0 || failed.Error == "" {
+		t.Errorf("unexpected entry %+v", failed)
+	}
+	for _, entry := range entries {
+		if strings.Contains(entry.URL, "api_key="+APIKey) || entry.Time.IsZero() {
+			t.Errorf("expected redacted URL and timestamp, got %+v", entry)
+		}
+	}
+}
+
+// recordingAuditSink keeps the recorded entries in memory
+type recordingAuditSink struct {
+	mu      sync.Mutex
+	entries []*AuditEntry
+}
+
+func (s *recordingAuditSink) Record(entry *AuditEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+// recorded returns a copy of the entries recorded so far
+func (s *recordingAuditSink) recorded() []*AuditEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*AuditEntry(nil), s.entries...)
+}
+
+func TestWithAuditSink_maxResponseBytes(t *testing.T) {
+	server, mux, url := startNewServer()
+	defer server.Close()
+
+	sink := new(recordingAuditSink)
+	client := NewClient(APIKey, WithAuditSink(sink), WithMaxResponseBytes(1024))
+	client.BaseURL = url
+
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"cookiecutter"}`)
+	})
+
+	if _, _, err := client.Project(context.Background(), "pypi", "cookiecutter"); err != nil {
+		t.Fatalf("Project returned unexpected error: %v", err)
+	}
+	if len(sink.entries) != 1 || sink.entries[0].Bytes != int64(len(`{"name":"cookiecutter"}`)) {
+		t.Errorf("unexpected entries %+v", sink.entries)
+	}
+}
@@ -0,0 +1,67 @@
+package librariesio
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BatchError collects the failures of an operation run for several
+// projects. errors.Is and errors.As match the error of any failed project.
+type BatchError struct {
+	// Refs are all projects the operation was run for
+	Refs []ProjectRef
+
+	// Errors holds the error for every failed project
+	Errors map[ProjectRef]error
+}
+
+// batchError returns a BatchError for the given projects,
+// or nil if none of them failed
+func batchError(refs []ProjectRef, errs map[ProjectRef]error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return &BatchError{Refs: refs, Errors: errs}
+}
+
+// Error returns the number of failed projects and their errors
+func (e *BatchError) Error() string {
+	failed := e.Failed()
+
+	var msgs []string
+	for _, ref := range failed {
+		msgs = append(msgs, fmt.Sprintf("%v: %v", ref, e.Errors[ref]))
+	}
+	return fmt.Sprintf("%d of %d projects failed: %v", len(failed), len(e.Refs), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of the failed projects
+func (e *BatchError) Unwrap() []error {
+	var errs []error
+	for _, ref := range e.Failed() {
+		errs = append(errs, e.Errors[ref])
+	}
+	return errs
+}
+
+// Failed returns the projects that failed, in the order of Refs
+func (e *BatchError) Failed() []ProjectRef {
+	var failed []ProjectRef
+	for _, ref := range e.Refs {
+		if _, ok := e.Errors[ref]; ok {
+			failed = append(failed, ref)
+		}
+	}
+	return failed
+}
+
+// Succeeded returns the projects that did not fail, in the order of Refs
+func (e *BatchError) Succeeded() []ProjectRef {
+	var succeeded []ProjectRef
+	for _, ref := range e.Refs {
+		if _, ok := e.Errors[ref]; !ok {
+			succeeded = append(succeeded, ref)
+		}
+	}
+	return succeeded
+}
@@ -0,0 +1,46 @@
+package librariesio
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBatchError(t *testing.T) {
+	a := ProjectRef{Platform: "npm", Name: "a"}
+	b := ProjectRef{Platform: "npm", Name: "b"}
+	c := ProjectRef{Platform: "npm", Name: "c"}
+
+	notFound := &ProjectNotFoundError{Platform: "npm", Name: "c", Err: errors.New("404")}
+	err := batchError([]ProjectRef{a, b, c}, map[ProjectRef]error{
+		a: errors.New("timeout"),
+		c: notFound,
+	})
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected *BatchError, got %v", err)
+	}
+	if want := []ProjectRef{a, c}; !reflect.DeepEqual(batchErr.Failed(), want) {
+		t.Errorf("\nExpected %v\nGot %v", want, batchErr.Failed())
+	}
+	if want := []ProjectRef{b}; !reflect.DeepEqual(batchErr.Succeeded(), want) {
+		t.Errorf("\nExpected %v\nGot %v", want, batchErr.Succeeded())
+	}
+
+	if !errors.Is(err, ErrProjectNotFound) {
+		t.Error("expected errors.Is to match the error of a failed project")
+	}
+	var notFoundErr *ProjectNotFoundError
+	if !errors.As(err, &notFoundErr) || notFoundErr != notFound {
+		t.Errorf("expected errors.As to find the ProjectNotFoundError, got %v", notFoundErr)
+	}
+
+	if want := "2 of 3 projects failed: npm/a: timeout; npm/c: " + notFound.Error(); err.Error() != want {
+		t.Errorf("Error() returned %q, want %q", err.Error(), want)
+	}
+
+	if err := batchError([]ProjectRef{a}, nil); err != nil {
+		t.Errorf("expected nil without failures, got %v", err)
+	}
+}
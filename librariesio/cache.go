@@ -0,0 +1,264 @@
+package librariesio
+
+import (
+	"container/list"
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Cache stores response bodies of GET requests,
+// implementations must be safe for concurrent use
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte)
+	Delete(key string)
+}
+
+// WithCache serves GET requests from cache when possible and stores
+// the bodies of successful GET responses in it. Keys are request URLs
+// with the API key redacted. POST, PUT and DELETE requests remove the
+// entry of their URL. The subscriptions of the authenticated user are
+// never cached, see WithNoCache.
+func WithCache(cache Cache) ClientOption {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// LRUCache is a Cache holding up to a maximum number of bytes,
+// evicting the least recently used entries first
+type LRUCache struct {
+	mu       sync.Mutex
+	maxBytes int64
+	size     int64
+	order    *list.List
+	entries  map[string]*list.Element
+	metrics  Metrics
+}
+
+type lruEntry struct {
+	key   string
+	value []byte
+}
+
+// NewLRUCache returns a cache bounded to maxBytes of keys and values.
+// Hits, misses and evictions are counted in metrics if it is not nil.
+func NewLRUCache(maxBytes int64, metrics Metrics) *LRUCache {
+	return &LRUCache{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+		metrics:  metrics,
+	}
+}
+
+// Get returns the value stored for key and marks it as recently used
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		c.count(MetricCacheMisses, 1)
+		return nil, false
+	}
+	c.count(MetricCacheHits, 1)
+	c.order.MoveToFront(e)
+	return e.Value.(*lruEntry).value, true
+}
+
+// Set stores value for key, evicting least recently used entries until
+// the cache fits. Values larger than the cache are not stored.
+func (c *LRUCache) Set(key string, value []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		c.remove(e)
+	}
+
+	size := entrySize(key, value)
+	if size > c.maxBytes {
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value})
+	c.size += size
+
+	var evicted int64
+	for c.size > c.maxBytes {
+		c.remove(c.order.Back())
+		evicted++
+	}
+	c.count(MetricCacheEvictions, evicted)
+}
+
+// Delete removes the value stored for key
+func (c *LRUCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		c.remove(e)
+	}
+}
+
+// Len returns the number of cached entries
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// Size returns the number of bytes held by the cache
+func (c *LRUCache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.size
+}
+
+func (c *LRUCache) remove(e *list.Element) {
+	entry := c.order.Remove(e).(*lruEntry)
+	delete(c.entries, entry.key)
+	c.size -= entrySize(entry.key, entry.value)
+}
+
+func (c *LRUCache) count(name string, delta int64) {
+	if c.metrics != nil && delta > 0 {
+		c.metrics.Add(name, delta)
+	}
+}
+
+func entrySize(key string, value []byte) int64 {
+	return int64(len(key) + len(value))
+}
+
+// WithStaleWhileRevalidate limits how long cached responses are served,
+// it only has an effect together with WithCache. Entries younger than
+// maxAge are served as is. Older entries are served for up to maxStale
+// more while a background request with PriorityBackground refreshes
+// them, so it waits for the limiters like any other request. Failed
+// refreshes are retried with exponential backoff, starting at one second
+// and capped at maxStale. Entries older than maxAge plus maxStale, and
+// entries stored by another client, are fetched again before returning.
+func WithStaleWhileRevalidate(maxAge, maxStale time.Duration) ClientOption {
+	return func(c *Client) {
+		c.revalidation = &revalidation{
+			maxAge:   maxAge,
+			maxStale: maxStale,
+			entries:  make(map[string]*revalidationState),
+		}
+	}
+}
+
+// revalidation tracks the age of cache entries
+type revalidation struct {
+	maxAge   time.Duration
+	maxStale time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*revalidationState
+}
+
+type revalidationState struct {
+	storedAt   time.Time
+	refreshing bool
+	failures   int
+	retryAt    time.Time
+}
+
+// lookup reports whether the entry for key may be served at the given
+// time, whether it is stale and whether the caller has to refresh it
+func (r *revalidation) lookup(key string, at time.Time) (ok, stale, refresh bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, ok := r.entries[key]
+	if !ok {
+		return false, false, false
+	}
+
+	age := at.Sub(state.storedAt)
+	switch {
+	case age <= r.maxAge:
+		return true, false, false
+	case age > r.maxAge+r.maxStale:
+		return false, true, false
+	}
+
+	if state.refreshing || at.Before(state.retryAt) {
+		return true, true, false
+	}
+	state.refreshing = true
+	return true, true, true
+}
+
+// stored records that the entry for key was stored at the given time,
+// entries that expired since are forgotten
+func (r *revalidation) stored(key string, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for k, state := range r.entries {
+		if at.Sub(state.storedAt) > r.maxAge+r.maxStale && !state.refreshing {
+			delete(r.entries, k)
+		}
+	}
+	r.entries[key] = &revalidationState{storedAt: at}
+}
+
+// refreshed records the result of a background refresh of key
+func (r *revalidation) refreshed(key string, at time.Time, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, ok := r.entries[key]
+	if !ok {
+		return
+	}
+	state.refreshing = false
+	if err == nil {
+		return
+	}
+
+	state.failures++
+	backoff := r.maxStale
+	if state.failures < 32 && time.Second<<(state.failures-1) < backoff {
+		backoff = time.Second << (state.failures - 1)
+	}
+	state.retryAt = at.Add(backoff)
+}
+
+// revalidate refreshes the cache entry for req in the background,
+// the request keeps the values but not the cancellation of ctx. Nothing
+// is refreshed after Client.Shutdown.
+func (c *Client) revalidate(ctx context.Context, req *http.Request, key string) {
+	started := c.background.run(ctx, func(ctx context.Context) {
+		_, err := c.DoLazy(ctx, req.Clone(ctx), WithPriority(PriorityBackground), revalidating())
+		c.revalidation.refreshed(key, now(), err)
+	})
+	if !started {
+		c.revalidation.refreshed(key, now(), ErrShutdown)
+	}
+}
+
+// WithNoCache sends the request even if the cache set with WithCache
+// holds a response for it, and does not store the response. A Scheduler
+// does not share the response with identical pending requests either,
+// so the result reflects the state after the request was made.
+func WithNoCache() RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.noCache = true
+	}
+}
+
+// revalidating makes background refreshes skip the cached entry
+func revalidating() RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.revalidating = true
+	}
+}
@@ -0,0 +1,259 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLRUCache(t *testing.T) {
+	metrics := new(Counters)
+	cache := NewLRUCache(20, metrics)
+
+	cache.Set("a", []byte("123456789")) // 10 bytes
+	cache.Set("b", []byte("123456789"))
+
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("expected a to be cached")
+	}
+
+	// a was used more recently, so b is evicted
+	cache.Set("c", []byte("1234"))
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	if got, ok := cache.Get("a"); !ok || string(got) != "123456789" {
+		t.Errorf("expected a to be cached, got %q", got)
+	}
+	if cache.Len() != 2 || cache.Size() != 15 {
+		t.Errorf("unexpected cache len %d and size %d", cache.Len(), cache.Size())
+	}
+
+	cache.Delete("c")
+	if cache.Len() != 1 || cache.Size() != 10 {
+		t.Errorf("unexpected cache len %d and size %d after delete", cache.Len(), cache.Size())
+	}
+
+	// Values that never fit are not stored
+	cache.Set("d", []byte(strings.Repeat("x", 20)))
+	if _, ok := cache.Get("d"); ok {
+		t.Error("expected oversized value not to be cached")
+	}
+
+	for name, want := range map[string]int64{
+		MetricCacheHits:      2,
+		MetricCacheMisses:    2,
+		MetricCacheEvictions: 1,
+	} {
+		if got := metrics.Get(name); got != want {
+			t.Errorf("expected %v to be %d, got %d", name, want, got)
+		}
+	}
+}
+
+func TestWithCache(t *testing.T) {
+	server, mux, url := startNewServer()
+	cache := NewLRUCache(1<<20, nil)
+	client := NewClient(APIKey, WithCache(cache))
+	client.BaseURL = url
+	defer server.Close()
+
+	var calls int
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"name":"cookiecutter"}`)
+	})
+
+	for i := 0; i < 2; i++ {
+		project, _, err := client.Project(context.Background(), "pypi", "cookiecutter")
+		if err != nil {
+			t.Fatalf("Project returned unexpected error: %v", err)
+		}
+		if got := stringValue(project.Name); got != "cookiecutter" {
+			t.Errorf("unexpected project name %q", got)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("expected 1 request, got %d", calls)
+	}
+	for key := range cache.entries {
+		if strings.Contains(key, "api_key="+APIKey) {
+			t.Errorf("expected API key to be redacted from cache key %q", key)
+		}
+	}
+}
+
+func TestWithCache_subscriptions(t *testing.T) {
+	server, mux, url := startNewServer()
+	cache := NewLRUCache(1<<20, nil)
+	client := NewClient(APIKey, WithCache(cache))
+	client.BaseURL = url
+	defer server.Close()
+
+	var subscribed bool
+	mux.HandleFunc("/subscriptions/npm/ava", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			subscribed = true
+		case "DELETE":
+			subscribed = false
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if !subscribed {
+			http.Error(w, `{"error":"Not Found"}`, http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"include_prerelease": true, "project": {"name": "ava"}}`)
+	})
+
+	ctx := context.Background()
+	if _, _, err := client.Subscribe(ctx, "npm", "ava", true); err != nil {
+		t.Fatalf("Subscribe returned unexpected error: %v", err)
+	}
+	if _, _, err := client.Subscription(ctx, "npm", "ava"); err != nil {
+		t.Fatalf("Subscription returned unexpected error: %v", err)
+	}
+	if _, err := client.Unsubscribe(ctx, "npm", "ava"); err != nil {
+		t.Fatalf("Unsubscribe returned unexpected error: %v", err)
+	}
+	if _, _, err := client.Subscription(ctx, "npm", "ava"); !isStatus(err, http.StatusNotFound) {
+		t.Errorf("expected removed subscription not to be served from cache, got %v", err)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("expected subscriptions not to be cached, got %d entries", cache.Len())
+	}
+}
+
+func TestWithCache_writeInvalidates(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey, WithCache(NewLRUCache(1<<20, nil)))
+	client.BaseURL = url
+	defer server.Close()
+
+	var calls int
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			calls++
+		}
+		fmt.Fprint(w, `{"name":"cookiecutter"}`)
+	})
+
+	get := func() {
+		t.Helper()
+		request, _ := client.NewRequest("GET", "pypi/cookiecutter", nil)
+		if _, err := client.Do(context.Background(), request, nil); err != nil {
+			t.Fatalf("Do returned unexpected error: %v", err)
+		}
+	}
+
+	get()
+	get()
+	request, _ := client.NewRequest("PUT", "pypi/cookiecutter", nil)
+	if _, err := client.Do(context.Background(), request, nil); err != nil {
+		t.Fatalf("Do returned unexpected error: %v", err)
+	}
+	get()
+
+	if calls != 2 {
+		t.Errorf("expected the PUT to invalidate the cached response, got %d requests", calls)
+	}
+}
+
+func TestWithStaleWhileRevalidate(t *testing.T) {
+	defer func(f func() time.Time) { now = f }(now)
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var mu sync.Mutex
+	now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return at
+	}
+	advance := func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		at = at.Add(d)
+	}
+
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey, WithCache(NewLRUCache(1<<20, nil)), WithStaleWhileRevalidate(time.Minute, time.Hour))
+	client.BaseURL = url
+	defer server.Close()
+
+	var calls int32
+	var failing atomic.Bool
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintf(w, `{"name":"cookiecutter","stars":%d}`, n)
+	})
+
+	project := func(wantStars int, wantStale bool) {
+		t.Helper()
+		p, resp, err := client.Project(context.Background(), "pypi", "cookiecutter")
+		if err != nil {
+			t.Fatalf("Project returned unexpected error: %v", err)
+		}
+		if intValue(p.Stars) != wantStars || resp.Stale != wantStale {
+			t.Errorf("expected %d stars (stale %v), got %d (stale %v)", wantStars, wantStale, intValue(p.Stars), resp.Stale)
+		}
+	}
+	refreshDone := func() {
+		t.Helper()
+		for i := 0; i < 100; i++ {
+			client.revalidation.mu.Lock()
+			refreshing := false
+			for _, state := range client.revalidation.entries {
+				refreshing = refreshing || state.refreshing
+			}
+			client.revalidation.mu.Unlock()
+			if !refreshing {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatal("background refresh did not finish")
+	}
+
+	project(1, false)
+	project(1, false)
+
+	// Stale entries are served while refreshed in the background
+	advance(2 * time.Minute)
+	project(1, true)
+	refreshDone()
+	project(2, false)
+
+	// Failed refreshes back off before trying again
+	failing.Store(true)
+	advance(2 * time.Minute)
+	project(2, true)
+	refreshDone()
+	project(2, true)
+	refreshDone()
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("expected 3 requests during backoff, got %d", got)
+	}
+
+	advance(2 * time.Second)
+	project(2, true)
+	refreshDone()
+	if got := atomic.LoadInt32(&calls); got != 4 {
+		t.Errorf("expected a retry after backoff, got %d requests", got)
+	}
+
+	// Expired entries are fetched before returning
+	failing.Store(false)
+	advance(2 * time.Hour)
+	project(5, false)
+}
@@ -0,0 +1,107 @@
+package librariesio
+
+import (
+	"sort"
+	"time"
+)
+
+// ReleaseCadence describes how often a project publishes releases
+type ReleaseCadence struct {
+	// Releases is the number of versions with a known publish date
+	Releases int
+
+	FirstReleaseAt  time.Time
+	LatestReleaseAt time.Time
+
+	// AverageGap is the average time between two releases,
+	// it is zero for less than two releases
+	AverageGap time.Duration
+
+	// ReleasesPerYear is the release frequency over the lifetime
+	// of the project, it is zero for less than two releases
+	ReleasesPerYear float64
+
+	// SinceLatestRelease is the time since the latest release was
+	// published, it is zero if there are no releases
+	SinceLatestRelease time.Duration
+}
+
+const year = 365 * 24 * time.Hour
+
+// ReleaseCadence computes the release cadence from the publish
+// dates of the project's Versions
+func (p *Project) ReleaseCadence() ReleaseCadence {
+	return releaseCadence(p.Versions, now())
+}
+
+func releaseCadence(versions []*Release, at time.Time) ReleaseCadence {
+	published := publishDates(versions)
+
+	var cadence ReleaseCadence
+	if cadence.Releases = len(published); cadence.Releases == 0 {
+		return cadence
+	}
+
+	cadence.FirstReleaseAt = published[0]
+	cadence.LatestReleaseAt = published[len(published)-1]
+	cadence.SinceLatestRelease = at.Sub(cadence.LatestReleaseAt)
+
+	if lifetime := cadence.LatestReleaseAt.Sub(cadence.FirstReleaseAt); lifetime > 0 {
+		gaps := len(published) - 1
+		cadence.AverageGap = lifetime / time.Duration(gaps)
+		cadence.ReleasesPerYear = float64(gaps) / (float64(lifetime) / float64(year))
+	}
+
+	return cadence
+}
+
+// publishDates returns the sorted publish dates of the given releases
+func publishDates(versions []*Release) []time.Time {
+	var published []time.Time
+	for _, v := range versions {
+		if v != nil && v.PublishedAt != nil {
+			published = append(published, *v.PublishedAt)
+		}
+	}
+
+	sort.Slice(published, func(i, j int) bool {
+		return published[i].Before(published[j])
+	})
+	return published
+}
+
+// HasReleaseWithin reports whether any version of the project was
+// published within d before now, e.g. HasReleaseWithin(p, 365*24*time.Hour)
+func HasReleaseWithin(p *Project, d time.Duration) bool {
+	return hasReleaseSince(p, now().Add(-d), false)
+}
+
+// HasStableReleaseWithin reports whether a version of the project that is
+// not a prerelease was published within d before now
+func HasStableReleaseWithin(p *Project, d time.Duration) bool {
+	return hasReleaseSince(p, now().Add(-d), true)
+}
+
+func hasReleaseSince(p *Project, since time.Time, stable bool) bool {
+	for _, v := range p.Versions {
+		if v == nil || v.PublishedAt == nil || v.PublishedAt.Before(since) {
+			continue
+		}
+		if !stable || !isPrerelease(stringValue(v.Number)) {
+			return true
+		}
+	}
+	return false
+}
+
+// StableReleaseCount returns the number of versions of the
+// project that are not prereleases
+func StableReleaseCount(p *Project) int {
+	var n int
+	for _, v := range p.Versions {
+		if v != nil && v.Number != nil && !isPrerelease(*v.Number) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,85 @@
+package librariesio
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hackebrot/go-repr/repr"
+)
+
+func TestProjectReleaseCadence(t *testing.T) {
+	defer func(f func() time.Time) { now = f }(now)
+	now = func() time.Time {
+		return time.Date(2017, time.March, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	project := &Project{
+		Versions: []*Release{
+			{Number: String("1.1.0"), PublishedAt: Time(time.Date(2016, time.July, 1, 0, 0, 0, 0, time.UTC))},
+			{Number: String("1.0.0"), PublishedAt: Time(time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC))},
+			{Number: String("0.1.0")},
+			{Number: String("1.2.0"), PublishedAt: Time(time.Date(2016, time.December, 31, 0, 0, 0, 0, time.UTC))},
+		},
+	}
+
+	got := project.ReleaseCadence()
+	want := ReleaseCadence{
+		Releases:           3,
+		FirstReleaseAt:     time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC),
+		LatestReleaseAt:    time.Date(2016, time.December, 31, 0, 0, 0, 0, time.UTC),
+		AverageGap:         365 * 24 * time.Hour / 2,
+		ReleasesPerYear:    2,
+		SinceLatestRelease: 60 * 24 * time.Hour,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(got))
+	}
+}
+
+func TestProjectReleaseCadence_noReleases(t *testing.T) {
+	project := &Project{Versions: []*Release{{Number: String("0.1.0")}}}
+
+	if got := project.ReleaseCadence(); !reflect.DeepEqual(got, ReleaseCadence{}) {
+		t.Errorf("expected zero ReleaseCadence, got %v", repr.Repr(got))
+	}
+}
+
+func TestReleaseRecency(t *testing.T) {
+	defer func(f func() time.Time) { now = f }(now)
+	now = func() time.Time {
+		return time.Date(2017, time.March, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	project := &Project{
+		Versions: []*Release{
+			{Number: String("1.0.0"), PublishedAt: Time(time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC))},
+			{Number: String("1.1.0"), PublishedAt: Time(time.Date(2016, time.June, 1, 0, 0, 0, 0, time.UTC))},
+			{Number: String("2.0.0-rc1"), PublishedAt: Time(time.Date(2017, time.February, 1, 0, 0, 0, 0, time.UTC))},
+			{Number: String("0.9.0")},
+		},
+	}
+
+	testCases := []struct {
+		within      time.Duration
+		any, stable bool
+	}{
+		{7 * 24 * time.Hour, false, false},
+		{60 * 24 * time.Hour, true, false},
+		{year, true, true},
+	}
+
+	for _, testCase := range testCases {
+		if got := HasReleaseWithin(project, testCase.within); got != testCase.any {
+			t.Errorf("HasReleaseWithin(%v) returned %v, want %v", testCase.within, got, testCase.any)
+		}
+		if got := HasStableReleaseWithin(project, testCase.within); got != testCase.stable {
+			t.Errorf("HasStableReleaseWithin(%v) returned %v, want %v", testCase.within, got, testCase.stable)
+		}
+	}
+
+	if got := StableReleaseCount(project); got != 3 {
+		t.Errorf("expected 3 stable releases, got %d", got)
+	}
+}
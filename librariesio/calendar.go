@@ -0,0 +1,127 @@
+package librariesio
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Calendar spreads a recurring workload, such as refreshing a list of
+// projects once a day, evenly over a period so it stays within the API
+// rate limit. Every item gets a slot in each period and is handed out by
+// Next once its slot has passed. A Calendar can be saved and loaded to
+// keep its place across restarts. It is not safe for concurrent use.
+type Calendar struct {
+	Items  []string      `json:"items"`
+	Period time.Duration `json:"period"`
+
+	// PeriodStart is the start of the current period
+	PeriodStart time.Time `json:"period_start"`
+
+	// Done is the number of items handed out in the current period
+	Done int `json:"done"`
+}
+
+// NewCalendar returns a calendar handing out every item once per period,
+// starting now. requestsPerItem is the number of requests the work for an
+// item takes, an error is returned if the workload exceeds APIRateLimit.
+// The period must be at least a minute, the window of APIRateLimit.
+func NewCalendar(items []string, period time.Duration, requestsPerItem int) (*Calendar, error) {
+	if period < time.Minute {
+		return nil, fmt.Errorf("calendar period must be at least a minute, got %v", period)
+	}
+	if requestsPerItem < 1 {
+		requestsPerItem = 1
+	}
+
+	capacity := int(period.Minutes() * APIRateLimit)
+	if requests := len(items) * requestsPerItem; requests > capacity {
+		return nil, fmt.Errorf("%d requests exceed the rate limit of %d requests per %v", requests, capacity, period)
+	}
+
+	return &Calendar{
+		Items:       append([]string(nil), items...),
+		Period:      period,
+		PeriodStart: now(),
+	}, nil
+}
+
+// Next returns the items whose slots passed by at and were not handed out
+// before, and how long to wait until the next slot. Items left over from
+// a past period, e.g. because the process was down, are due right away
+// and are not handed out again for slots of the current period that
+// passed as well.
+func (c *Calendar) Next(at time.Time) (due []string, wait time.Duration) {
+	if len(c.Items) == 0 {
+		return nil, c.Period
+	}
+
+	// Items from index leftover on were just handed out as left over
+	leftover := len(c.Items)
+	if !at.Before(c.PeriodStart.Add(c.Period)) {
+		due = append(due, c.Items[c.Done:]...)
+		leftover = c.Done
+		periods := at.Sub(c.PeriodStart) / c.Period
+		c.PeriodStart = c.PeriodStart.Add(periods * c.Period)
+		c.Done = 0
+	}
+
+	for c.Done < len(c.Items) && !c.slot(c.Done).After(at) {
+		if c.Done < leftover {
+			due = append(due, c.Items[c.Done])
+		}
+		c.Done++
+	}
+
+	if c.Done < len(c.Items) {
+		return due, c.slot(c.Done).Sub(at)
+	}
+	return due, c.PeriodStart.Add(c.Period).Sub(at)
+}
+
+// slot returns when item i is due in the current period
+func (c *Calendar) slot(i int) time.Time {
+	return c.PeriodStart.Add(time.Duration(float64(c.Period) * float64(i) / float64(len(c.Items))))
+}
+
+// SaveFile writes the calendar to path, replacing the file atomically
+func (c *Calendar) SaveFile(path string) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// LoadCalendarFile reads a calendar written by SaveFile
+func LoadCalendarFile(path string) (*Calendar, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c := new(Calendar)
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	if c.Period <= 0 {
+		return nil, fmt.Errorf("calendar %v has no period", path)
+	}
+	return c, nil
+}
@@ -0,0 +1,110 @@
+package librariesio
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCalendar(t *testing.T) {
+	defer func(f func() time.Time) { now = f }(now)
+	start := time.Date(2017, time.April, 1, 0, 0, 0, 0, time.UTC)
+	now = func() time.Time { return start }
+
+	cal, err := NewCalendar([]string{"a", "b", "c", "d"}, 24*time.Hour, 1)
+	if err != nil {
+		t.Fatalf("NewCalendar returned unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		at   time.Duration
+		due  []string
+		wait time.Duration
+	}{
+		{0, []string{"a"}, 6 * time.Hour},
+		{time.Hour, nil, 5 * time.Hour},
+		{13 * time.Hour, []string{"b", "c"}, 5 * time.Hour},
+		{18 * time.Hour, []string{"d"}, 6 * time.Hour},
+		// The next period starts with the first item again
+		{24 * time.Hour, []string{"a"}, 6 * time.Hour},
+		// Items left over from a missed period are due right away
+		{3 * 24 * time.Hour, []string{"b", "c", "d", "a"}, 6 * time.Hour},
+	}
+
+	for _, testCase := range testCases {
+		due, wait := cal.Next(start.Add(testCase.at))
+		if !reflect.DeepEqual(due, testCase.due) || wait != testCase.wait {
+			t.Errorf("Next(+%v) returned %v and %v, want %v and %v", testCase.at, due, wait, testCase.due, testCase.wait)
+		}
+	}
+}
+
+func TestCalendar_leftoverOnce(t *testing.T) {
+	defer func(f func() time.Time) { now = f }(now)
+	start := time.Date(2017, time.April, 1, 0, 0, 0, 0, time.UTC)
+	now = func() time.Time { return start }
+
+	cal, err := NewCalendar([]string{"a", "b"}, 2*time.Hour, 1)
+	if err != nil {
+		t.Fatalf("NewCalendar returned unexpected error: %v", err)
+	}
+
+	// The slots of the current period passed too, but left over
+	// items are only handed out once
+	due, wait := cal.Next(start.Add(3 * time.Hour))
+	if want := []string{"a", "b"}; !reflect.DeepEqual(due, want) || wait != time.Hour {
+		t.Errorf("Next(+3h) returned %v and %v, want %v and 1h", due, wait, want)
+	}
+
+	due, _ = cal.Next(start.Add(4 * time.Hour))
+	if want := []string{"a"}; !reflect.DeepEqual(due, want) {
+		t.Errorf("Next(+4h) returned %v, want %v", due, want)
+	}
+}
+
+func TestNewCalendar_overbooked(t *testing.T) {
+	items := make([]string, 61)
+	if _, err := NewCalendar(items, time.Minute, 1); err == nil {
+		t.Error("Expected error for more requests than the rate limit allows")
+	}
+	if _, err := NewCalendar(items[:30], time.Minute, 2); err != nil {
+		t.Errorf("NewCalendar returned unexpected error: %v", err)
+	}
+	if _, err := NewCalendar(items, 0, 1); err == nil {
+		t.Error("Expected error for a period of 0")
+	}
+	if _, err := NewCalendar(items[:1], 30*time.Second, 1); err == nil {
+		t.Error("Expected error for a period shorter than a minute")
+	}
+	if _, err := NewCalendar(make([]string, 90), 90*time.Second, 1); err != nil {
+		t.Errorf("NewCalendar returned unexpected error for a period of 90s: %v", err)
+	}
+}
+
+func TestCalendarFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calendar.json")
+
+	cal, err := NewCalendar([]string{"npm/react", "pypi/requests"}, time.Hour, 3)
+	if err != nil {
+		t.Fatalf("NewCalendar returned unexpected error: %v", err)
+	}
+	cal.Next(cal.PeriodStart)
+
+	if err := cal.SaveFile(path); err != nil {
+		t.Fatalf("SaveFile returned unexpected error: %v", err)
+	}
+	loaded, err := LoadCalendarFile(path)
+	if err != nil {
+		t.Fatalf("LoadCalendarFile returned unexpected error: %v", err)
+	}
+
+	if !loaded.PeriodStart.Equal(cal.PeriodStart) || loaded.Done != 1 || loaded.Period != time.Hour ||
+		!reflect.DeepEqual(loaded.Items, cal.Items) {
+		t.Errorf("\nExpected %+v\nGot %+v", cal, loaded)
+	}
+
+	if _, err := LoadCalendarFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected error for a missing file")
+	}
+}
@@ -33,12 +33,31 @@ func main() {
 		os.Exit(1)
 	}
 
-	c := librariesio.NewClient(strings.TrimSpace(env["LIBRARIESIO_API_KEY"]))
+	// LIBRARIESIO_HOST points the client at a mirror or mock server
+	host := os.Getenv("LIBRARIESIO_HOST")
+	if host == "" {
+		host = librariesio.DefaultHost
+	}
+
+	c, err := librariesio.NewClientForHost(strings.TrimSpace(env["LIBRARIESIO_API_KEY"]), host)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
 
-	project, _, err := c.Project(ctx, "pypi", "cookiecutter")
+	// An optional argument selects the project, e.g. npm:react
+	ref := librariesio.ProjectRef{Platform: "pypi", Name: "cookiecutter"}
+	if len(os.Args) > 1 {
+		if ref, err = librariesio.ParseProjectRef(os.Args[1]); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	project, _, err := c.Project(ctx, ref.Platform, ref.Name)
 
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
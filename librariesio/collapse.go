@@ -0,0 +1,57 @@
+package librariesio
+
+import "strings"
+
+// collapseProjects merges projects sharing the same repository URL into
+// the first of them, projects without a repository URL are kept as is
+func collapseProjects(projects []*Project) []*Project {
+	var collapsed []*Project
+	byRepo := make(map[string]*Project)
+
+	for _, p := range projects {
+		if p == nil {
+			continue
+		}
+
+		key := repositoryKey(stringValue(p.RepositoryURL))
+		if key == "" {
+			collapsed = append(collapsed, p)
+			continue
+		}
+
+		first, ok := byRepo[key]
+		if !ok {
+			p.CollapsedPlatforms = appendPlatform(nil, stringValue(p.Platform))
+			byRepo[key] = p
+			collapsed = append(collapsed, p)
+			continue
+		}
+		first.CollapsedPlatforms = appendPlatform(first.CollapsedPlatforms, stringValue(p.Platform))
+	}
+
+	return collapsed
+}
+
+func appendPlatform(platforms []string, plat string) []string {
+	if plat == "" {
+		return platforms
+	}
+	for _, p := range platforms {
+		if strings.EqualFold(p, plat) {
+			return platforms
+		}
+	}
+	return append(platforms, plat)
+}
+
+// repositoryKey normalizes a repository URL so different spellings
+// of the same repository compare equal
+func repositoryKey(repoURL string) string {
+	key := strings.ToLower(strings.TrimSpace(repoURL))
+	for _, prefix := range []string{"git+", "https://", "http://", "git://", "ssh://", "git@", "www."} {
+		key = strings.TrimPrefix(key, prefix)
+	}
+	key = strings.Replace(key, ":", "/", 1)
+	key = strings.TrimSuffix(strings.TrimSuffix(key, "/"), ".git")
+	return key
+}
@@ -0,0 +1,115 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestSearch_collapse(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("collapse"); got != "" {
+			t.Errorf("unexpected collapse query param %q", got)
+		}
+		fmt.Fprint(w, `[
+			{"name": "protobuf", "platform": "Pypi", "repository_url": "https://github.com/protocolbuffers/protobuf"},
+			{"name": "left-pad", "platform": "NPM"},
+			{"name": "google-protobuf", "platform": "NPM", "repository_url": "https://github.com/protocolbuffers/protobuf.git"},
+			{"name": "google-protobuf", "platform": "Rubygems", "repository_url": "git@github.com:protocolbuffers/protobuf"}
+		]`)
+	})
+
+	projects, _, err := client.Search(context.Background(), "protobuf", &SearchOptions{Collapse: true})
+	if err != nil {
+		t.Fatalf("Search returned unexpected error: %v", err)
+	}
+
+	if len(projects) != 2 {
+		t.Fatalf("expected 2 projects, got %d", len(projects))
+	}
+	if got := *projects[0].Name; got != "protobuf" {
+		t.Errorf("expected first result to be kept, got %v", got)
+	}
+	if want := []string{"Pypi", "NPM", "Rubygems"}; !reflect.DeepEqual(projects[0].CollapsedPlatforms, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, projects[0].CollapsedPlatforms)
+	}
+	if projects[1].CollapsedPlatforms != nil {
+		t.Errorf("expected project without repository to be kept as is")
+	}
+}
+
+func TestSearchAll_collapse(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(w, `[{"name": "a", "platform": "NPM", "repository_url": "https://github.com/x/a"}]`)
+		case "2":
+			fmt.Fprint(w, `[{"name": "a", "platform": "Bower", "repository_url": "https://github.com/x/a"}]`)
+		default:
+			fmt.Fprint(w, `[]`)
+		}
+	})
+
+	projects, err := client.SearchAll(context.Background(), "a", &SearchOptions{Collapse: true, ListOptions: ListOptions{PerPage: 1}})
+	if err != nil {
+		t.Fatalf("SearchAll returned unexpected error: %v", err)
+	}
+
+	if len(projects) != 1 {
+		t.Fatalf("expected results of all pages to be collapsed, got %d projects", len(projects))
+	}
+	if want := []string{"NPM", "Bower"}; !reflect.DeepEqual(projects[0].CollapsedPlatforms, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, projects[0].CollapsedPlatforms)
+	}
+}
+
+func TestSearchUntil_collapse(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(w, `[
+				{"name": "a", "platform": "NPM", "repository_url": "https://github.com/x/a"},
+				{"name": "a", "platform": "Bower", "repository_url": "https://github.com/x/a"}
+			]`)
+		case "2":
+			fmt.Fprint(w, `[{"name": "b", "platform": "NPM"}]`)
+		default:
+			fmt.Fprint(w, `[]`)
+		}
+	})
+
+	opts := &SearchOptions{Collapse: true, ListOptions: ListOptions{PerPage: 2}}
+	projects, found, err := client.SearchUntil(context.Background(), "a", opts, func(p *Project) bool {
+		return *p.Name == "b"
+	})
+	if err != nil {
+		t.Fatalf("SearchUntil returned unexpected error: %v", err)
+	}
+
+	if !found {
+		t.Fatal("expected a collapsed page not to stop paging")
+	}
+	if len(projects) != 2 {
+		t.Fatalf("expected results of all pages to be collapsed, got %d projects", len(projects))
+	}
+	if want := []string{"NPM", "Bower"}; !reflect.DeepEqual(projects[0].CollapsedPlatforms, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, projects[0].CollapsedPlatforms)
+	}
+}
@@ -0,0 +1,81 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// now is swapped out in tests
+var now = time.Now
+
+// ProjectComparison holds the metrics used to compare a project
+// against others in CompareProjects
+type ProjectComparison struct {
+	Ref     ProjectRef
+	Project *Project
+
+	Stars      int
+	Rank       int
+	Dependents int
+	License    string
+
+	// LatestReleaseAge is the time since the latest release was published,
+	// it is zero if the project has no published releases
+	LatestReleaseAge time.Duration
+
+	// ReleaseCadence is the average time between two releases,
+	// it is zero if the project has less than two published releases
+	ReleaseCadence time.Duration
+}
+
+// CompareProjects fetches the given projects and returns a comparison
+// row for each of them, in the same order as the given refs. If ctx is
+// cancelled, the rows fetched so far are returned with a PartialResultError.
+func (c *Client) CompareProjects(ctx context.Context, refs ...ProjectRef) ([]*ProjectComparison, error) {
+	comparisons := make([]*ProjectComparison, 0, len(refs))
+
+	for _, ref := range refs {
+		project, _, err := c.Project(ctx, ref.Platform, ref.Name)
+		if err != nil {
+			err = fmt.Errorf("comparing %v: %w", ref, err)
+			if err, ok := partialResult(ctx, err); ok {
+				return comparisons, err
+			}
+			return nil, err
+		}
+		comparisons = append(comparisons, compareProject(ref, project, now()))
+	}
+
+	return comparisons, nil
+}
+
+func compareProject(ref ProjectRef, project *Project, at time.Time) *ProjectComparison {
+	cmp := &ProjectComparison{
+		Ref:        ref,
+		Project:    project,
+		Stars:      intValue(project.Stars),
+		Rank:       intValue(project.Rank),
+		Dependents: intValue(project.DependentsCount),
+	}
+
+	var licenses []string
+	for _, l := range project.NormalizedLicenses {
+		if l != nil {
+			licenses = append(licenses, *l)
+		}
+	}
+	if len(licenses) > 0 {
+		cmp.License = strings.Join(licenses, ", ")
+	} else if project.Licenses != nil {
+		cmp.License = *project.Licenses
+	}
+
+	if project.LatestReleasePublishedAt != nil {
+		cmp.LatestReleaseAge = at.Sub(*project.LatestReleasePublishedAt)
+	}
+	cmp.ReleaseCadence = releaseCadence(project.Versions, at).AverageGap
+
+	return cmp
+}
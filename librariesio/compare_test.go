@@ -0,0 +1,128 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hackebrot/go-repr/repr"
+)
+
+func TestCompareProjects(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	defer func(f func() time.Time) { now = f }(now)
+	now = func() time.Time {
+		return time.Date(2017, time.April, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{
+			"name": "cookiecutter",
+			"stars": 5000,
+			"rank": 24,
+			"dependents_count": 120,
+			"normalized_licenses": ["BSD-3-Clause"],
+			"latest_release_published_at": "2017-03-30T00:00:00.000Z",
+			"versions": [
+				{"number": "1.5.0", "published_at": "2017-03-20T00:00:00.000Z"},
+				{"number": "1.4.0", "published_at": "2017-03-10T00:00:00.000Z"},
+				{"number": "1.5.1", "published_at": "2017-03-30T00:00:00.000Z"}
+			]
+		}`)
+	})
+	mux.HandleFunc("/pypi/poyo", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name": "poyo", "stars": 50, "licenses": "MIT"}`)
+	})
+
+	refs := []ProjectRef{
+		{Platform: "pypi", Name: "cookiecutter"},
+		{Platform: "pypi", Name: "poyo"},
+	}
+
+	got, err := client.CompareProjects(context.Background(), refs...)
+	if err != nil {
+		t.Fatalf("CompareProjects returned unexpected error: %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 comparisons, got %d", len(got))
+	}
+
+	want := &ProjectComparison{
+		Ref:              refs[0],
+		Project:          got[0].Project,
+		Stars:            5000,
+		Rank:             24,
+		Dependents:       120,
+		License:          "BSD-3-Clause",
+		LatestReleaseAge: 48 * time.Hour,
+		ReleaseCadence:   10 * 24 * time.Hour,
+	}
+	if !reflect.DeepEqual(got[0], want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(got[0]))
+	}
+
+	want = &ProjectComparison{
+		Ref:     refs[1],
+		Project: got[1].Project,
+		Stars:   50,
+		License: "MIT",
+	}
+	if !reflect.DeepEqual(got[1], want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(got[1]))
+	}
+}
+
+func TestCompareProjects_error(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Not Found"}`, http.StatusNotFound)
+	})
+
+	_, err := client.CompareProjects(context.Background(), ProjectRef{Platform: "npm", Name: "nope"})
+	if err == nil {
+		t.Fatal("Expected error to be returned")
+	}
+}
+
+func TestCompareProjects_cancelled(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mux.HandleFunc("/npm/a", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"a"}`)
+	})
+	mux.HandleFunc("/npm/b", func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		<-r.Context().Done()
+	})
+
+	comparisons, err := client.CompareProjects(ctx,
+		ProjectRef{Platform: "npm", Name: "a"},
+		ProjectRef{Platform: "npm", Name: "b"},
+		ProjectRef{Platform: "npm", Name: "c"},
+	)
+	if !errors.Is(err, ErrPartialResult) {
+		t.Fatalf("expected partial result error, got %v", err)
+	}
+	if len(comparisons) != 1 || comparisons[0].Ref.Name != "a" {
+		t.Errorf("expected comparison of a, got %v", repr.Repr(comparisons))
+	}
+}
@@ -0,0 +1,137 @@
+package librariesio
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ProjectFlavor is the endpoint a Project was returned by,
+// each endpoint populates a different set of fields
+type ProjectFlavor int
+
+const (
+	// FlavorProject is a project returned by Project
+	FlavorProject ProjectFlavor = iota
+
+	// FlavorProjectDeps is a project returned by ProjectDeps
+	FlavorProjectDeps
+
+	// FlavorUserProjects is a project returned by Search or UserProjects
+	FlavorUserProjects
+)
+
+// String returns the name of the endpoint
+func (f ProjectFlavor) String() string {
+	switch f {
+	case FlavorProjectDeps:
+		return "ProjectDeps"
+	case FlavorUserProjects:
+		return "UserProjects"
+	}
+	return "Project"
+}
+
+// expectedFields lists the JSON fields every flavor is expected to populate
+var expectedFields = map[ProjectFlavor][]string{
+	FlavorProject: {
+		"name", "platform", "versions", "latest_release_number", "latest_release_published_at",
+		"rank", "stars", "forks", "dependents_count", "dependent_repos_count", "normalized_licenses",
+		"package_manager_url",
+	},
+	FlavorProjectDeps: {
+		"name", "platform", "dependencies", "dependencies_for_version",
+	},
+	FlavorUserProjects: {
+		"name", "platform", "repository_url", "rank", "stars",
+	},
+}
+
+// Completeness reports which fields expected for an endpoint
+// are populated, see Project.Completeness
+type Completeness struct {
+	Flavor ProjectFlavor
+
+	// Present and Missing hold the JSON names of the expected fields
+	Present []string
+	Missing []string
+}
+
+// Complete reports whether no expected field is missing
+func (c Completeness) Complete() bool {
+	return len(c.Missing) == 0
+}
+
+// Ratio returns the share of expected fields that are present
+func (c Completeness) Ratio() float64 {
+	total := len(c.Present) + len(c.Missing)
+	if total == 0 {
+		return 1
+	}
+	return float64(len(c.Present)) / float64(total)
+}
+
+// Completeness reports which of the fields that the endpoint of the
+// given flavor is expected to return are missing from the project.
+// Fields are missing if they are nil, empty lists count as present.
+func (p *Project) Completeness(f ProjectFlavor) Completeness {
+	c := Completeness{Flavor: f}
+
+	v := reflect.ValueOf(p).Elem()
+	fields := structFields(v.Type())
+	for _, name := range expectedFields[f] {
+		field, ok := findField(fields, name)
+		if ok && !v.FieldByIndex(field.index).IsNil() {
+			c.Present = append(c.Present, name)
+		} else {
+			c.Missing = append(c.Missing, name)
+		}
+	}
+	return c
+}
+
+// IncompleteProjectError is returned by Project.Validate
+type IncompleteProjectError struct {
+	Completeness
+	Platform string
+	Name     string
+
+	// Likely is a flavor whose fields are all populated, if it
+	// differs from Flavor the project was probably returned by
+	// another endpoint than expected
+	Likely ProjectFlavor
+}
+
+// Error lists the missing fields and the likely endpoint of the project
+func (e *IncompleteProjectError) Error() string {
+	msg := fmt.Sprintf("project %v/%v is missing fields expected from %v: %v",
+		e.Platform, e.Name, e.Flavor, strings.Join(e.Missing, ", "))
+	if e.Likely != e.Flavor {
+		msg += fmt.Sprintf(" (it looks like it was returned by %v)", e.Likely)
+	}
+	return msg
+}
+
+// Validate returns an *IncompleteProjectError if fields expected from
+// the endpoint of the given flavor are missing from the project
+func (p *Project) Validate(f ProjectFlavor) error {
+	c := p.Completeness(f)
+	if c.Complete() {
+		return nil
+	}
+
+	likely := f
+	for _, other := range []ProjectFlavor{FlavorProject, FlavorProjectDeps, FlavorUserProjects} {
+		if p.Completeness(other).Complete() {
+			likely = other
+			break
+		}
+	}
+
+	return &IncompleteProjectError{
+		Completeness: c,
+		Platform:     stringValue(p.Platform),
+		Name:         stringValue(p.Name),
+		Likely:       likely,
+	}
+}
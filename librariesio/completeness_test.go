@@ -0,0 +1,81 @@
+package librariesio
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestProjectCompleteness(t *testing.T) {
+	project := &Project{
+		Name:                     String("cookiecutter"),
+		Platform:                 String("Pypi"),
+		Versions:                 []*Release{},
+		LatestReleaseNumber:      String("1.5.1"),
+		LatestReleasePublishedAt: Time(time.Date(2017, time.March, 1, 0, 0, 0, 0, time.UTC)),
+		Rank:                     Int(20),
+		Stars:                    Int(5000),
+		Forks:                    Int(600),
+		DependentsCount:          Int(10),
+		DependentReposCount:      Int(100),
+		NormalizedLicenses:       []*string{String("BSD-3-Clause")},
+	}
+
+	c := project.Completeness(FlavorProject)
+	if want := []string{"package_manager_url"}; !reflect.DeepEqual(c.Missing, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, c.Missing)
+	}
+	if c.Complete() || len(c.Present) != 11 {
+		t.Errorf("unexpected completeness %+v", c)
+	}
+
+	project.PackageManagerURL = String("https://pypi.org/project/cookiecutter/")
+	if err := project.Validate(FlavorProject); err != nil {
+		t.Errorf("Validate returned unexpected error: %v", err)
+	}
+}
+
+func TestProjectValidate(t *testing.T) {
+	project := &Project{Name: String("cookiecutter"), Platform: String("Pypi")}
+
+	err := project.Validate(FlavorProjectDeps)
+
+	var incomplete *IncompleteProjectError
+	if !errors.As(err, &incomplete) {
+		t.Fatalf("expected *IncompleteProjectError, got %v", err)
+	}
+	if want := []string{"dependencies", "dependencies_for_version"}; !reflect.DeepEqual(incomplete.Missing, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, incomplete.Missing)
+	}
+	if got := incomplete.Ratio(); got != 0.5 {
+		t.Errorf("expected ratio 0.5, got %v", got)
+	}
+
+	err = project.Validate(FlavorUserProjects)
+	want := "project Pypi/cookiecutter is missing fields expected from UserProjects: repository_url, rank, stars"
+	if err == nil || err.Error() != want {
+		t.Errorf("Validate returned %q, want %q", err, want)
+	}
+
+	err = (&Project{Name: String("a")}).Validate(FlavorProject)
+	if err == nil || !reflect.DeepEqual(err.(*IncompleteProjectError).Missing[:2], []string{"platform", "versions"}) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestIncompleteProjectError_likely(t *testing.T) {
+	project := &Project{
+		Name:          String("cookiecutter"),
+		Platform:      String("Pypi"),
+		RepositoryURL: String("https://github.com/audreyr/cookiecutter"),
+		Rank:          Int(20),
+		Stars:         Int(5000),
+	}
+
+	want := "project Pypi/cookiecutter is missing fields expected from ProjectDeps: dependencies, dependencies_for_version" +
+		" (it looks like it was returned by UserProjects)"
+	if err := project.Validate(FlavorProjectDeps); err == nil || err.Error() != want {
+		t.Errorf("Validate returned %q, want %q", err, want)
+	}
+}
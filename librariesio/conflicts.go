@@ -0,0 +1,82 @@
+package librariesio
+
+import (
+	"sort"
+	"strings"
+)
+
+// DependencyConflict reports a package that is required with different
+// version requirements in different branches of a dependency tree
+type DependencyConflict struct {
+	Platform string
+	Name     string
+
+	// Paths holds every occurrence of the package in the tree
+	Paths []*RequirementPath
+}
+
+// Code returns CodeVersionConflict
+func (c *DependencyConflict) Code() FindingCode {
+	return CodeVersionConflict
+}
+
+// Severity returns the severity of CodeVersionConflict
+func (c *DependencyConflict) Severity() Severity {
+	return CodeVersionConflict.Severity()
+}
+
+// RequirementPath is an occurrence of a package in a dependency tree
+type RequirementPath struct {
+	Requirements string
+
+	// Path lists the nodes from the root of the tree
+	// down to the parent that declared the requirement
+	Path []ProjectRef
+}
+
+// FindConflicts returns a conflict for every package in the tree that is
+// required with different requirement strings. Requirements are compared
+// verbatim, so differing ranges such as "^1.0.0" and "^1.2.0" are reported
+// even though a single version may satisfy both of them.
+func FindConflicts(tree *DependencyNode) []*DependencyConflict {
+	occurrences := make(map[string]*DependencyConflict)
+	requirements := make(map[string]map[string]bool)
+	var keys []string
+
+	tree.Walk(func(node *DependencyNode, path []*DependencyNode) bool {
+		if len(path) == 0 {
+			return true
+		}
+
+		key := strings.ToLower(node.Platform + "/" + node.Name)
+		conflict, ok := occurrences[key]
+		if !ok {
+			conflict = &DependencyConflict{Platform: node.Platform, Name: node.Name}
+			occurrences[key] = conflict
+			requirements[key] = make(map[string]bool)
+			keys = append(keys, key)
+		}
+
+		refs := make([]ProjectRef, len(path))
+		for i, n := range path {
+			refs[i] = n.Ref()
+		}
+
+		conflict.Paths = append(conflict.Paths, &RequirementPath{
+			Requirements: node.Requirements,
+			Path:         refs,
+		})
+		requirements[key][node.Requirements] = true
+		return true
+	})
+
+	sort.Strings(keys)
+
+	var conflicts []*DependencyConflict
+	for _, key := range keys {
+		if len(requirements[key]) > 1 {
+			conflicts = append(conflicts, occurrences[key])
+		}
+	}
+	return conflicts
+}
@@ -0,0 +1,61 @@
+package librariesio
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hackebrot/go-repr/repr"
+)
+
+func TestFindConflicts(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	handleDeps(mux, testTreeDeps)
+
+	tree, err := client.ResolveTree(context.Background(), "npm", "app", "1.0.0", nil)
+	if err != nil {
+		t.Fatalf("ResolveTree returned unexpected error: %v", err)
+	}
+
+	conflicts := FindConflicts(tree)
+
+	app := ProjectRef{Platform: "npm", Name: "app", Version: "1.0.0"}
+	a := ProjectRef{Platform: "npm", Name: "a", Version: "1.2.0"}
+	b := ProjectRef{Platform: "npm", Name: "b", Version: "2.0.0"}
+
+	want := []*DependencyConflict{
+		{
+			Platform: "npm",
+			Name:     "c",
+			Paths: []*RequirementPath{
+				{Requirements: "~1.0.0", Path: []ProjectRef{app, a}},
+				{Requirements: "^1.0.0", Path: []ProjectRef{app, b}},
+				{Requirements: "~1.0.0", Path: []ProjectRef{app, b, a}},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(conflicts, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(conflicts))
+	}
+}
+
+func TestFindConflicts_none(t *testing.T) {
+	tree := &DependencyNode{
+		Name: "app",
+		Dependencies: []*DependencyNode{
+			{Name: "a", Requirements: "^1.0.0", Dependencies: []*DependencyNode{
+				{Name: "c", Requirements: "^1.0.0"},
+			}},
+			{Name: "c", Requirements: "^1.0.0"},
+		},
+	}
+
+	if conflicts := FindConflicts(tree); len(conflicts) != 0 {
+		t.Errorf("expected no conflicts, got %v", repr.Repr(conflicts))
+	}
+}
@@ -0,0 +1,72 @@
+package librariesio
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+type requestOptionsKey struct{}
+
+// NewContext returns a copy of ctx carrying the given request options.
+// They apply to every request sent with the returned context, including
+// requests sent by methods that do not accept options, so middleware can
+// influence downstream calls. Options passed to Do or DoLazy directly
+// take precedence, options of nested contexts are added to those of
+// their parent.
+func NewContext(ctx context.Context, opts ...RequestOption) context.Context {
+	parent := contextOptions(ctx)
+	merged := make([]RequestOption, 0, len(parent)+len(opts))
+	merged = append(merged, parent...)
+	merged = append(merged, opts...)
+	return context.WithValue(ctx, requestOptionsKey{}, merged)
+}
+
+// contextOptions returns the request options attached with NewContext
+func contextOptions(ctx context.Context) []RequestOption {
+	opts, _ := ctx.Value(requestOptionsKey{}).([]RequestOption)
+	return opts
+}
+
+// WithHeader sets a header on the request, replacing any value
+// set by the client
+func WithHeader(key, value string) RequestOption {
+	return func(cfg *requestConfig) {
+		if cfg.header == nil {
+			cfg.header = make(http.Header)
+		}
+		cfg.header.Set(key, value)
+	}
+}
+
+// WithTimeout bounds the time of the request to d, replacing the
+// timeout set with WithDefaultCallTimeout
+func WithTimeout(d time.Duration) RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.timeout = d
+	}
+}
+
+// WithAPIKey sends the request with the given API key instead of the key
+// of the client. Cached responses are only shared between requests sent
+// with the same key. The rate limit status of the client, see
+// RateRemaining, is shared by all keys.
+func WithAPIKey(key string) RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.apiKey = key
+	}
+}
+
+// NewAPIKeyContext returns a copy of ctx whose requests are sent with the
+// given API key, so one client can serve several tenants of a proxy
+func NewAPIKeyContext(ctx context.Context, key string) context.Context {
+	return NewContext(ctx, WithAPIKey(key))
+}
+
+// tenantKey identifies an API key in cache keys without revealing it
+func tenantKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:8])
+}
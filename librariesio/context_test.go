@@ -0,0 +1,114 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewContext(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("X-Request-ID"), "abc"; got != want {
+			t.Errorf("X-Request-ID is %q, want %q", got, want)
+		}
+		if got, want := r.Header.Get("X-Tenant"), "acme"; got != want {
+			t.Errorf("X-Tenant is %q, want %q", got, want)
+		}
+		if got, want := r.Header.Get("User-Agent"), "proxy"; got != want {
+			t.Errorf("User-Agent is %q, want %q", got, want)
+		}
+		fmt.Fprint(w, `{"name":"cookiecutter"}`)
+	})
+
+	ctx := NewContext(context.Background(), WithHeader("X-Request-ID", "abc"), WithHeader("User-Agent", "proxy"))
+	ctx = NewContext(ctx, WithHeader("X-Tenant", "acme"))
+
+	if _, _, err := client.Project(ctx, "pypi", "cookiecutter"); err != nil {
+		t.Fatalf("Project returned unexpected error: %v", err)
+	}
+}
+
+func TestNewContext_timeout(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey, WithDefaultCallTimeout(time.Minute))
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	ctx := NewContext(context.Background(), WithTimeout(10*time.Millisecond))
+
+	_, _, err := client.Project(ctx, "pypi", "cookiecutter")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestNewContext_precedence(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("X-Request-ID"), "call"; got != want {
+			t.Errorf("X-Request-ID is %q, want %q", got, want)
+		}
+		fmt.Fprint(w, `{}`)
+	})
+
+	ctx := NewContext(context.Background(), WithHeader("X-Request-ID", "context"))
+
+	req, err := client.NewRequest("GET", "pypi/cookiecutter", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned unexpected error: %v", err)
+	}
+	if _, err := client.Do(ctx, req, nil, WithHeader("X-Request-ID", "call")); err != nil {
+		t.Fatalf("Do returned unexpected error: %v", err)
+	}
+}
+
+func TestNewAPIKeyContext(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey, WithCache(NewLRUCache(1<<20, nil)))
+	client.BaseURL = url
+	defer server.Close()
+
+	var calls int
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprintf(w, `{"name":%q}`, r.URL.Query().Get("api_key"))
+	})
+
+	for _, key := range []string{"tenant-a", "tenant-b", "tenant-a", ""} {
+		ctx := context.Background()
+		want := APIKey
+		if key != "" {
+			ctx = NewAPIKeyContext(ctx, key)
+			want = key
+		}
+
+		project, _, err := client.Project(ctx, "pypi", "cookiecutter")
+		if err != nil {
+			t.Fatalf("Project returned unexpected error: %v", err)
+		}
+		if got := stringValue(project.Name); got != want {
+			t.Errorf("expected request with API key %q, got %q", want, got)
+		}
+	}
+
+	// The second request of tenant-a is served from cache
+	if calls != 3 {
+		t.Errorf("expected 3 requests, got %d", calls)
+	}
+}
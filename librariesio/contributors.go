@@ -0,0 +1,178 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ProjectContributors returns all users who contributed to the source
+// repository of the given project. A *ProjectNotFoundError is returned if
+// the project does not exist. If ctx is cancelled while paging, the users
+// fetched so far are returned with a PartialResultError.
+//
+// GET https://libraries.io/api/:platform/:name/contributors
+//
+// plat is the platform/package manager of the project
+// name is the name of the project on the platform
+func (c *Client) ProjectContributors(ctx context.Context, plat, name string) ([]*User, *Response, error) {
+	var users []*User
+
+	for page := 1; ; page++ {
+		if page > 1 {
+			if err := c.waitForNextPage(ctx); err != nil {
+				return users, nil, &PartialResultError{Err: err}
+			}
+		}
+
+		urlStr, err := addOptions(fmt.Sprintf("%v/%v/contributors", plat, url.PathEscape(name)), ListOptions{Page: page, PerPage: MaxPerPage})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		request, err := c.NewRequest("GET", urlStr, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var u []*User
+
+		response, err := c.Do(ctx, request, &u)
+		if err != nil {
+			err = projectError(err, plat, name)
+			if err, ok := partialResult(ctx, err); ok {
+				return users, response, err
+			}
+			return nil, response, err
+		}
+
+		users = append(users, u...)
+
+		if len(u) < MaxPerPage {
+			return users, response, nil
+		}
+	}
+}
+
+// TransitiveContributor is a person contributing to projects in a
+// dependency tree
+type TransitiveContributor struct {
+	User *User
+
+	// Projects are the projects of the tree the user contributed to,
+	// without versions
+	Projects []ProjectRef
+}
+
+// ContributorReport lists the people an application transitively
+// depends on, as returned by TreeContributors
+type ContributorReport struct {
+	// Contributors are sorted by the number of projects they
+	// contributed to in descending order
+	Contributors []*TransitiveContributor
+
+	// Projects is the number of distinct projects analyzed
+	Projects int
+
+	// Failed holds the error for every project whose
+	// contributors could not be fetched
+	Failed map[ProjectRef]error
+
+	refs []ProjectRef
+}
+
+// Err returns a BatchError for the projects whose contributors
+// could not be fetched, or nil if all of them were fetched
+func (r *ContributorReport) Err() error {
+	return batchError(r.refs, r.Failed)
+}
+
+// TreeContributors fetches the contributors of every distinct project in
+// the dependency tree, excluding the root, and deduplicates them by UUID
+// or login. Projects whose contributors cannot be fetched are reported in
+// Failed. If ctx is cancelled, the report so far is returned with a
+// PartialResultError.
+func (c *Client) TreeContributors(ctx context.Context, tree *DependencyNode) (*ContributorReport, error) {
+	var refs []ProjectRef
+	seen := make(map[string]bool)
+
+	tree.Walk(func(node *DependencyNode, path []*DependencyNode) bool {
+		if len(path) == 0 {
+			return true
+		}
+		ref := ProjectRef{Platform: node.Platform, Name: node.Name}
+		if key := strings.ToLower(ref.String()); !seen[key] {
+			seen[key] = true
+			refs = append(refs, ref)
+		}
+		return true
+	})
+
+	report := &ContributorReport{Failed: make(map[ProjectRef]error), refs: refs}
+	byKey := make(map[string]*TransitiveContributor)
+
+	for _, ref := range refs {
+		users, _, err := c.ProjectContributors(ctx, ref.Platform, ref.Name)
+		if err != nil {
+			if err, ok := partialResult(ctx, err); ok {
+				report.Contributors = sortContributors(byKey)
+				return report, err
+			}
+			report.Failed[ref] = err
+			continue
+		}
+		report.Projects++
+
+		for _, u := range users {
+			key := contributorKey(u)
+			if key == "" {
+				continue
+			}
+
+			contributor, ok := byKey[key]
+			if !ok {
+				contributor = &TransitiveContributor{User: u}
+				byKey[key] = contributor
+			}
+			if n := len(contributor.Projects); n == 0 || contributor.Projects[n-1] != ref {
+				contributor.Projects = append(contributor.Projects, ref)
+			}
+		}
+	}
+
+	report.Contributors = sortContributors(byKey)
+	return report, nil
+}
+
+// contributorKey identifies a user by UUID, falling back to the login
+func contributorKey(u *User) string {
+	if u == nil {
+		return ""
+	}
+	if u.UUID != nil {
+		return "uuid:" + strconv.Itoa(*u.UUID)
+	}
+	if login := stringValue(u.Login); login != "" {
+		return "login:" + strings.ToLower(login)
+	}
+	return ""
+}
+
+func sortContributors(byKey map[string]*TransitiveContributor) []*TransitiveContributor {
+	contributors := make([]*TransitiveContributor, 0, len(byKey))
+	for _, contributor := range byKey {
+		contributors = append(contributors, contributor)
+	}
+
+	sort.Slice(contributors, func(i, j int) bool {
+		a, b := contributors[i], contributors[j]
+		if len(a.Projects) != len(b.Projects) {
+			return len(a.Projects) > len(b.Projects)
+		}
+		return strings.ToLower(stringValue(a.User.Login)) < strings.ToLower(stringValue(b.User.Login))
+	})
+	return contributors
+}
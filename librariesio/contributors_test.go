@@ -0,0 +1,130 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hackebrot/go-repr/repr"
+)
+
+func TestTreeContributors(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/npm/a/contributors", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("per_page"); got != "100" {
+			t.Errorf("per_page is %q, want 100", got)
+		}
+		fmt.Fprint(w, `[{"login": "alice", "uuid": 1}, {"login": "bob"}]`)
+	})
+	mux.HandleFunc("/npm/b/contributors", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"login": "Alice", "uuid": 1}, {"login": "BOB"}, {"login": "carol"}]`)
+	})
+	mux.HandleFunc("/npm/c/contributors", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Not Found"}`, http.StatusNotFound)
+	})
+	mux.HandleFunc("/npm/app/contributors", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("contributors of the root should not be fetched")
+	})
+
+	tree := &DependencyNode{Platform: "npm", Name: "app", Dependencies: []*DependencyNode{
+		{Platform: "npm", Name: "a", Version: "1.0.0", Dependencies: []*DependencyNode{
+			{Platform: "npm", Name: "b", Version: "2.0.0"},
+		}},
+		{Platform: "npm", Name: "b", Version: "2.1.0"},
+		{Platform: "npm", Name: "c", Version: "1.0.0"},
+	}}
+
+	report, err := client.TreeContributors(context.Background(), tree)
+	if err != nil {
+		t.Fatalf("TreeContributors returned unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, c := range report.Contributors {
+		got = append(got, fmt.Sprintf("%v:%d", *c.User.Login, len(c.Projects)))
+	}
+	if want := []string{"alice:2", "bob:2", "carol:1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, got)
+	}
+
+	if report.Projects != 2 {
+		t.Errorf("expected 2 analyzed projects, got %d", report.Projects)
+	}
+	if err := report.Failed[ProjectRef{Platform: "npm", Name: "c"}]; !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("expected failure of c to be reported, got %v", report.Failed)
+	}
+	if err := report.Err(); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("expected batch error to match ErrProjectNotFound, got %v", err)
+	}
+}
+
+func TestProjectContributors_pagination(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/npm/a/contributors", func(w http.ResponseWriter, r *http.Request) {
+		n := 1
+		if r.URL.Query().Get("page") == "1" {
+			n = MaxPerPage
+		}
+		fmt.Fprint(w, "[")
+		for i := 0; i < n; i++ {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"login": "u%d"}`, i)
+		}
+		fmt.Fprint(w, "]")
+	})
+
+	users, _, err := client.ProjectContributors(context.Background(), "npm", "a")
+	if err != nil {
+		t.Fatalf("ProjectContributors returned unexpected error: %v", err)
+	}
+	if len(users) != MaxPerPage+1 {
+		t.Errorf("expected %d users, got %d", MaxPerPage+1, len(users))
+	}
+}
+
+func TestProjectContributors(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/pypi/cookiecutter/contributors", func(w http.ResponseWriter, r *http.Request) {
+		if method := "GET"; method != r.Method {
+			t.Errorf("expected HTTP %v request, got %v", method, r.Method)
+		}
+		fmt.Fprint(w, `[{"login":"audreyr","uuid":1,"name":"Audrey Roy Greenfeld","host_type":"GitHub"}]`)
+	})
+
+	users, _, err := client.ProjectContributors(context.Background(), "pypi", "cookiecutter")
+	if err != nil {
+		t.Fatalf("ProjectContributors returned unexpected error: %v", err)
+	}
+
+	want := []*User{{
+		Login:    String("audreyr"),
+		UUID:     Int(1),
+		Name:     String("Audrey Roy Greenfeld"),
+		HostType: String("GitHub"),
+	}}
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(users))
+	}
+
+	_, _, err = client.ProjectContributors(context.Background(), "pypi", "nope")
+	if !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("expected ErrProjectNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package librariesio
+
+import (
+	"time"
+)
+
+// APIRateLimit is the number of requests per minute the libraries.io
+// API allows for an API key
+const APIRateLimit = 60
+
+// Plan describes the requests an operation is expected to send,
+// see Client.EstimateCost
+type Plan struct {
+	// Requests is the number of single lookups, e.g. of a batch of projects
+	Requests int
+
+	// ListResults is the number of results fetched by methods that fetch
+	// all pages of a list, such as SearchAll or MirrorDependents
+	ListResults int
+
+	// TreeNodes is the number of project versions of a dependency tree,
+	// ResolveTree looks up the dependencies of each of them once
+	TreeNodes int
+}
+
+// requests returns the number of requests of the plan
+func (p Plan) requests() int {
+	n := p.Requests + p.TreeNodes
+	if p.ListResults > 0 {
+		n += (p.ListResults + MaxPerPage - 1) / MaxPerPage
+	}
+	return n
+}
+
+// Cost is the estimated cost of a Plan
+type Cost struct {
+	// Requests is the number of requests the plan sends
+	Requests int
+
+	// Duration is the time the requests take at least because of rate
+	// limits, response times are not included
+	Duration time.Duration
+
+	// RateLimited is set if the remaining API rate limit does not
+	// cover the requests, so the operation waits for it to reset
+	RateLimited bool
+}
+
+// EstimateCost predicts the number of requests of plan and the time they
+// take under the current rate limits: the client side limits set with
+// WithRateLimit and WithSharedLimiter, and the remaining API rate limit
+// reported by the most recent response. Until a response reported it,
+// the API rate limit is assumed to be fully available. Responses served
+// from a cache are not taken into account, so the estimate is an upper
+// bound for requests and a lower bound for time.
+func (c *Client) EstimateCost(plan Plan) *Cost {
+	cost := &Cost{Requests: plan.requests()}
+	if cost.Requests == 0 {
+		return cost
+	}
+
+	if c.limiter != nil {
+		cost.Duration = maxDuration(cost.Duration, c.limiter.duration(cost.Requests))
+	}
+	if c.shared != nil {
+		cost.Duration = maxDuration(cost.Duration, c.shared.l.duration(cost.Requests))
+	}
+
+	remaining, resetsAt := APIRateLimit, time.Time{}
+	c.rate.mu.Lock()
+	if c.rate.known {
+		remaining, resetsAt = c.rate.remaining, c.rate.resetsAt
+	}
+	c.rate.mu.Unlock()
+
+	if cost.Requests > remaining {
+		cost.RateLimited = true
+
+		untilReset := time.Minute
+		if !resetsAt.IsZero() {
+			untilReset = resetsAt.Sub(now())
+			if untilReset < 0 {
+				untilReset = 0
+			}
+		}
+
+		// Every further window after the reset allows APIRateLimit requests
+		windows := (cost.Requests - remaining + APIRateLimit - 1) / APIRateLimit
+		cost.Duration = maxDuration(cost.Duration, untilReset+time.Duration(windows-1)*time.Minute)
+	}
+
+	return cost
+}
+
+func maxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
+}
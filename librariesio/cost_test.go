@@ -0,0 +1,57 @@
+package librariesio
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEstimateCost(t *testing.T) {
+	defer func(f func() time.Time) { now = f }(now)
+	at := time.Date(2017, time.April, 1, 0, 0, 0, 0, time.UTC)
+	now = func() time.Time { return at }
+
+	plan := Plan{Requests: 3, ListResults: 250, TreeNodes: 10}
+
+	client := NewClient(APIKey)
+	if got, want := client.EstimateCost(plan), (&Cost{Requests: 16}); *got != *want {
+		t.Errorf("\nExpected %+v\nGot %+v", want, got)
+	}
+	if got := client.EstimateCost(Plan{}); *got != (Cost{}) {
+		t.Errorf("expected no cost for an empty plan, got %+v", got)
+	}
+
+	client.rate.known = true
+	client.rate.remaining = 10
+	client.rate.resetsAt = at.Add(30 * time.Second)
+
+	testCases := []struct {
+		plan Plan
+		want Cost
+	}{
+		{Plan{Requests: 10}, Cost{Requests: 10}},
+		{plan, Cost{Requests: 16, Duration: 30 * time.Second, RateLimited: true}},
+		{Plan{Requests: 130}, Cost{Requests: 130, Duration: 90 * time.Second, RateLimited: true}},
+	}
+
+	for _, testCase := range testCases {
+		if got := client.EstimateCost(testCase.plan); *got != testCase.want {
+			t.Errorf("\nExpected %+v\nGot %+v", testCase.want, *got)
+		}
+	}
+}
+
+func TestEstimateCost_clientRateLimit(t *testing.T) {
+	client := NewClient(APIKey, WithRateLimit(60, 10))
+
+	// 10 requests are covered by the burst, the other 6 take a second each
+	cost := client.EstimateCost(Plan{Requests: 16})
+	if cost.Duration != 6*time.Second || cost.RateLimited {
+		t.Errorf("unexpected cost %+v", cost)
+	}
+
+	shared := NewSharedLimiter(30, 1)
+	client = NewClient(APIKey, WithRateLimit(60, 10), WithSharedLimiter(shared))
+	if cost := client.EstimateCost(Plan{Requests: 16}); cost.Duration != 30*time.Second {
+		t.Errorf("expected the slower shared limiter to dominate, got %+v", cost)
+	}
+}
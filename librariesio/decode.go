@@ -0,0 +1,363 @@
+package librariesio
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// DecodeDiagnostic describes a field that was skipped while decoding a
+// response with WithTolerantDecoding
+type DecodeDiagnostic struct {
+	// Path is the location of the field in the response,
+	// e.g. versions[3].published_at
+	Path string
+
+	// Value is the skipped JSON value
+	Value json.RawMessage
+
+	// Err is the reason the value could not be decoded,
+	// it is nil for fields unknown to the model
+	Err error
+}
+
+// String returns the path and the reason the field was skipped
+func (d DecodeDiagnostic) String() string {
+	if d.Err == nil {
+		return d.Path + ": unknown field"
+	}
+	return d.Path + ": " + d.Err.Error()
+}
+
+// WithTolerantDecoding makes responses skip fields that cannot be decoded
+// into the model instead of failing the whole call. Skipped and unknown
+// fields are reported in Response.Diagnostics. Responses that are not
+// valid JSON still fail.
+func WithTolerantDecoding() ClientOption {
+	return func(c *Client) {
+		c.tolerant = true
+	}
+}
+
+// WithUseNumber makes responses decode numbers stored in interface{}
+// values, e.g. when decoding into a map[string]interface{}, as json.Number
+// instead of float64. This keeps large counts exact when responses are
+// re-encoded for storage.
+func WithUseNumber() ClientOption {
+	return func(c *Client) {
+		c.useNumber = true
+	}
+}
+
+// unmarshal decodes data into obj like json.Unmarshal, decoding
+// numbers in interface{} values as json.Number if useNumber is set
+func unmarshal(data []byte, obj interface{}, useNumber bool) error {
+	if !useNumber {
+		return json.Unmarshal(data, obj)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(obj); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("invalid character after top-level value")
+	}
+	return nil
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// decodeTolerant decodes data into obj like unmarshal, but skips
+// values that do not match the type of obj and reports them instead
+func decodeTolerant(data []byte, obj interface{}, useNumber bool) ([]DecodeDiagnostic, error) {
+	if !json.Valid(data) {
+		// Let encoding/json describe the syntax error
+		return nil, json.Unmarshal(data, obj)
+	}
+
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil, &json.InvalidUnmarshalError{Type: reflect.TypeOf(obj)}
+	}
+
+	d := &tolerantDecoder{useNumber: useNumber}
+	d.decode("", bytes.TrimSpace(data), v.Elem())
+	return d.diagnostics, nil
+}
+
+type tolerantDecoder struct {
+	diagnostics []DecodeDiagnostic
+	useNumber   bool
+}
+
+func (d *tolerantDecoder) skip(path string, raw json.RawMessage, err error) {
+	if path == "" {
+		path = "."
+	}
+	d.diagnostics = append(d.diagnostics, DecodeDiagnostic{Path: path, Value: raw, Err: err})
+}
+
+// decode stores raw in v and reports whether it was decoded at least partially
+func (d *tolerantDecoder) decode(path string, raw json.RawMessage, v reflect.Value) bool {
+	t := v.Type()
+
+	if string(raw) == "null" {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+			v.Set(reflect.Zero(t))
+		}
+		return true
+	}
+
+	if isLeafType(t) {
+		return d.decodeLeaf(path, raw, v)
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		elem := v
+		if v.IsNil() {
+			elem = reflect.New(t.Elem())
+		}
+		if !d.decode(path, raw, elem.Elem()) {
+			return false
+		}
+		v.Set(elem)
+		return true
+
+	case reflect.Struct:
+		return d.decodeStruct(path, raw, v)
+
+	case reflect.Slice:
+		return d.decodeSlice(path, raw, v)
+
+	case reflect.Map:
+		if t.Key().Kind() == reflect.String {
+			return d.decodeMap(path, raw, v)
+		}
+	}
+
+	return d.decodeLeaf(path, raw, v)
+}
+
+// isLeafType reports whether values of t are decoded as a whole
+func isLeafType(t reflect.Type) bool {
+	if t.Implements(jsonUnmarshalerType) || reflect.PtrTo(t).Implements(jsonUnmarshalerType) ||
+		t.Implements(textUnmarshalerType) || reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Struct, reflect.Map:
+		return false
+	case reflect.Slice:
+		// []byte is decoded from a base64 string
+		return t.Elem().Kind() == reflect.Uint8
+	}
+	return true
+}
+
+func (d *tolerantDecoder) decodeLeaf(path string, raw json.RawMessage, v reflect.Value) bool {
+	ptr := reflect.New(v.Type())
+	if v.Kind() != reflect.Ptr {
+		// Decode into a copy so a failed value leaves v untouched
+		ptr.Elem().Set(v)
+	}
+	if err := unmarshal(raw, ptr.Interface(), d.useNumber); err != nil {
+		d.skip(path, raw, unwrapTypeError(err))
+		return false
+	}
+	v.Set(ptr.Elem())
+	return true
+}
+
+// unwrapTypeError drops the Go struct details from type errors,
+// the path of the diagnostic already locates the value
+func unwrapTypeError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return fmt.Errorf("cannot decode %v into %v", typeErr.Value, typeErr.Type)
+	}
+	return err
+}
+
+type objectEntry struct {
+	key   string
+	value json.RawMessage
+}
+
+// objectEntries returns the entries of a JSON object in order,
+// ok is false if raw is not an object
+func objectEntries(raw json.RawMessage) (entries []objectEntry, ok bool) {
+	if len(raw) == 0 || raw[0] != '{' {
+		return nil, false
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if _, err := dec.Token(); err != nil {
+		return nil, false
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, false
+		}
+		key, _ := tok.(string)
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, false
+		}
+		entries = append(entries, objectEntry{key: key, value: value})
+	}
+	return entries, true
+}
+
+type structField struct {
+	name  string
+	index []int
+}
+
+// structFields returns the JSON fields of t, including
+// the fields of embedded structs
+func structFields(t reflect.Type) []structField {
+	var fields []structField
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && name == "" {
+			for _, embedded := range structFields(f.Type) {
+				embedded.index = append([]int{i}, embedded.index...)
+				fields = append(fields, embedded)
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, structField{name: name, index: f.Index})
+	}
+	return fields
+}
+
+// findField matches key to a field like encoding/json,
+// preferring an exact match over a case insensitive one
+func findField(fields []structField, key string) (structField, bool) {
+	for _, f := range fields {
+		if f.name == key {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.name, key) {
+			return f, true
+		}
+	}
+	return structField{}, false
+}
+
+func (d *tolerantDecoder) decodeStruct(path string, raw json.RawMessage, v reflect.Value) bool {
+	entries, ok := objectEntries(raw)
+	if !ok {
+		d.skip(path, raw, fmt.Errorf("cannot decode %v into %v", jsonKind(raw), v.Type()))
+		return false
+	}
+
+	fields := structFields(v.Type())
+	for _, e := range entries {
+		fieldPath := e.key
+		if path != "" {
+			fieldPath = path + "." + e.key
+		}
+
+		f, ok := findField(fields, e.key)
+		if !ok {
+			d.skip(fieldPath, e.value, nil)
+			continue
+		}
+		d.decode(fieldPath, e.value, v.FieldByIndex(f.index))
+	}
+	return true
+}
+
+func (d *tolerantDecoder) decodeSlice(path string, raw json.RawMessage, v reflect.Value) bool {
+	var items []json.RawMessage
+	if len(raw) == 0 || raw[0] != '[' || json.Unmarshal(raw, &items) != nil {
+		d.skip(path, raw, fmt.Errorf("cannot decode %v into %v", jsonKind(raw), v.Type()))
+		return false
+	}
+
+	slice := reflect.MakeSlice(v.Type(), 0, len(items))
+	for i, item := range items {
+		// Existing elements are reused like encoding/json does
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if i < v.Len() {
+			elem.Set(v.Index(i))
+		}
+		if d.decode(path+"["+strconv.Itoa(i)+"]", item, elem) {
+			slice = reflect.Append(slice, elem)
+		}
+	}
+	v.Set(slice)
+	return true
+}
+
+func (d *tolerantDecoder) decodeMap(path string, raw json.RawMessage, v reflect.Value) bool {
+	entries, ok := objectEntries(raw)
+	if !ok {
+		d.skip(path, raw, fmt.Errorf("cannot decode %v into %v", jsonKind(raw), v.Type()))
+		return false
+	}
+
+	if v.IsNil() {
+		v.Set(reflect.MakeMap(v.Type()))
+	}
+	for _, e := range entries {
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if d.decode(path+"["+strconv.Quote(e.key)+"]", e.value, elem) {
+			v.SetMapIndex(reflect.ValueOf(e.key).Convert(v.Type().Key()), elem)
+		}
+	}
+	return true
+}
+
+// jsonKind describes the kind of a JSON value for error messages
+func jsonKind(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return "empty value"
+	}
+	switch raw[0] {
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case '"':
+		return "string"
+	case 't', 'f':
+		return "bool"
+	case 'n':
+		return "null"
+	}
+	return "number"
+}
@@ -0,0 +1,153 @@
+package librariesio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hackebrot/go-repr/repr"
+)
+
+func TestDecodeTolerant(t *testing.T) {
+	data := `{
+		"name": "cookiecutter",
+		"stars": "many",
+		"keywords": ["templates", 42, "cli"],
+		"latest_stable_release": {"number": "1.5.1", "published_at": "yesterday"},
+		"versions": [{"number": "0.1.0"}, "1.0.0"],
+		"mystery": true
+	}`
+
+	var project Project
+	diagnostics, err := decodeTolerant([]byte(data), &project, false)
+	if err != nil {
+		t.Fatalf("decodeTolerant returned unexpected error: %v", err)
+	}
+
+	want := Project{
+		Name:                String("cookiecutter"),
+		Keywords:            []*string{String("templates"), String("cli")},
+		LatestStableRelease: &Release{Number: String("1.5.1")},
+		Versions:            []*Release{{Number: String("0.1.0")}},
+	}
+	if !reflect.DeepEqual(project, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(project))
+	}
+
+	var paths []string
+	for _, d := range diagnostics {
+		paths = append(paths, d.Path)
+	}
+	wantPaths := []string{"stars", "keywords[1]", "latest_stable_release.published_at", "versions[1]", "mystery"}
+	if !reflect.DeepEqual(paths, wantPaths) {
+		t.Errorf("\nExpected %v\nGot %v", wantPaths, paths)
+	}
+
+	if got, want := diagnostics[0].String(), "stars: cannot decode string into int"; got != want {
+		t.Errorf("\nExpected %q\nGot %q", want, got)
+	}
+	if got, want := diagnostics[4].String(), "mystery: unknown field"; got != want {
+		t.Errorf("\nExpected %q\nGot %q", want, got)
+	}
+}
+
+func TestDecodeTolerant_syntaxError(t *testing.T) {
+	var project Project
+	if _, err := decodeTolerant([]byte(`{"name": `), &project, false); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
+
+func TestWithTolerantDecoding(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey, WithTolerantDecoding())
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "cookiecutter", "rank": "12"}`)
+	})
+
+	project, response, err := client.Project(context.Background(), "pypi", "cookiecutter")
+	if err != nil {
+		t.Fatalf("Project returned unexpected error: %v", err)
+	}
+	if want := (&Project{Name: String("cookiecutter")}); !reflect.DeepEqual(project, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(project))
+	}
+	if len(response.Diagnostics) != 1 || response.Diagnostics[0].Path != "rank" {
+		t.Errorf("unexpected diagnostics %v", response.Diagnostics)
+	}
+}
+
+func FuzzDecodeTolerant(f *testing.F) {
+	f.Add(`{"name": "cookiecutter", "stars": 10, "keywords": ["cli"], "versions": [{"number": "1.0.0", "published_at": "2017-04-01T00:00:00Z"}]}`)
+	f.Add(`{"name": 1, "stars": "10", "versions": {"number": "1.0.0"}}`)
+	f.Add(`{"latest_stable_release": {"published_at": "2017"}, "Name": "a", "name": "b"}`)
+	f.Add(`[{"name": "a"}, null, 3]`)
+	f.Add(`null`)
+
+	f.Fuzz(func(t *testing.T, data string) {
+		var tolerant Project
+		diagnostics, err := decodeTolerant([]byte(data), &tolerant, false)
+
+		var strict Project
+		strictErr := json.Unmarshal([]byte(data), &strict)
+
+		if !json.Valid([]byte(data)) {
+			if err == nil {
+				t.Fatalf("expected error for invalid JSON %q", data)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("unexpected error for valid JSON %q: %v", data, err)
+		}
+
+		// Valid responses decode exactly like encoding/json
+		if strictErr == nil {
+			if !reflect.DeepEqual(tolerant, strict) {
+				t.Fatalf("decoded %q differently\nstrict   %v\ntolerant %v", data, repr.Repr(strict), repr.Repr(tolerant))
+			}
+			for _, d := range diagnostics {
+				if d.Err != nil {
+					t.Fatalf("unexpected diagnostic for %q: %v", data, d)
+				}
+			}
+		}
+	})
+}
+
+func TestWithUseNumber(t *testing.T) {
+	server, mux, url := startNewServer()
+	defer server.Close()
+
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"cookiecutter","dependents_count":9007199254740993}`)
+	})
+
+	for _, opts := range [][]ClientOption{{WithUseNumber()}, {WithUseNumber(), WithTolerantDecoding()}} {
+		client := NewClient(APIKey, opts...)
+		client.BaseURL = url
+
+		req, err := client.NewRequest(http.MethodGet, "pypi/cookiecutter", nil)
+		if err != nil {
+			t.Fatalf("NewRequest returned unexpected error: %v", err)
+		}
+
+		var got map[string]interface{}
+		if _, err := client.Do(context.Background(), req, &got); err != nil {
+			t.Fatalf("Do returned unexpected error: %v", err)
+		}
+		if n, ok := got["dependents_count"].(json.Number); !ok || n.String() != "9007199254740993" {
+			t.Errorf("expected exact json.Number, got %#v", got["dependents_count"])
+		}
+	}
+
+	if err := unmarshal([]byte(`{} {}`), new(map[string]interface{}), true); err == nil {
+		t.Error("expected error for trailing data")
+	}
+}
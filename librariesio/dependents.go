@@ -0,0 +1,146 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ProjectDependents returns a page of the projects depending on the
+// given project. A *ProjectNotFoundError is returned if the project
+// does not exist.
+//
+// GET https://libraries.io/api/:platform/:name/dependents
+//
+// plat is the platform/package manager of the project
+// name is the name of the project on the platform
+// opts selects the page, it may be nil for the first page
+func (c *Client) ProjectDependents(ctx context.Context, plat, name string, opts *ListOptions) ([]*Project, *Response, error) {
+	request, err := c.newListRequest(fmt.Sprintf("%v/%v/dependents", plat, url.PathEscape(name)), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var projects []*Project
+
+	response, err := c.Do(ctx, request, &projects)
+	if err != nil {
+		return nil, response, projectError(err, plat, name)
+	}
+
+	return projects, response, nil
+}
+
+// ProjectDependentRepositories returns a page of the repositories
+// depending on the given project. A *ProjectNotFoundError is returned
+// if the project does not exist.
+//
+// GET https://libraries.io/api/:platform/:name/dependent_repositories
+//
+// plat is the platform/package manager of the project
+// name is the name of the project on the platform
+// opts selects the page, it may be nil for the first page
+func (c *Client) ProjectDependentRepositories(ctx context.Context, plat, name string, opts *ListOptions) ([]*Repository, *Response, error) {
+	request, err := c.newListRequest(fmt.Sprintf("%v/%v/dependent_repositories", plat, url.PathEscape(name)), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var repos []*Repository
+
+	response, err := c.Do(ctx, request, &repos)
+	if err != nil {
+		return nil, response, projectError(err, plat, name)
+	}
+
+	return repos, response, nil
+}
+
+// DependentRepositoriesOptions specifies the optional parameters of
+// ProjectDependentRepositoriesAll
+type DependentRepositoriesOptions struct {
+	// Filter drops repositories from every page as it is fetched,
+	// all repositories are kept if it is nil
+	Filter *RepositoryFilter
+
+	// Limit stops paging once as many repositories passed the filter,
+	// 0 fetches all pages
+	Limit int
+
+	ListOptions
+}
+
+// ProjectDependentRepositoriesAll returns the repositories depending on
+// the given project that pass opts.Filter, fetching pages until opts.Limit
+// of them are found or all pages are fetched. If ctx is cancelled while
+// paging, the repositories found so far are returned with a
+// PartialResultError.
+//
+// plat is the platform/package manager of the project
+// name is the name of the project on the platform
+// opts may be nil to fetch all repositories
+func (c *Client) ProjectDependentRepositoriesAll(ctx context.Context, plat, name string, opts *DependentRepositoriesOptions) ([]*Repository, error) {
+	var o DependentRepositoriesOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	var err error
+	if o.ListOptions, err = o.ListOptions.normalizeAll(); err != nil {
+		return nil, err
+	}
+	if o.Page == 0 {
+		o.Page = 1
+	}
+
+	var all []*Repository
+
+	for {
+		repos, _, err := c.ProjectDependentRepositories(ctx, plat, name, &o.ListOptions)
+		if err != nil {
+			if err, ok := partialResult(ctx, err); ok {
+				return all, err
+			}
+			return nil, err
+		}
+
+		for _, r := range repos {
+			if o.Filter != nil && !o.Filter.Match(r) {
+				continue
+			}
+			all = append(all, r)
+			if o.Limit > 0 && len(all) == o.Limit {
+				return all, nil
+			}
+		}
+
+		if len(repos) < o.PerPage {
+			return all, nil
+		}
+		o.Page++
+
+		if err := c.waitForNextPage(ctx); err != nil {
+			return all, &PartialResultError{Err: err}
+		}
+	}
+}
+
+// newListRequest returns a GET request for a page of urlStr
+func (c *Client) newListRequest(urlStr string, opts *ListOptions) (*http.Request, error) {
+	var o ListOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	o, err := o.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	if urlStr, err = addOptions(urlStr, o); err != nil {
+		return nil, err
+	}
+
+	return c.NewRequest("GET", urlStr, nil)
+}
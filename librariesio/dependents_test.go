@@ -0,0 +1,146 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hackebrot/go-repr/repr"
+)
+
+func TestProjectDependents(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/npm/left-pad/dependents", func(w http.ResponseWriter, r *http.Request) {
+		if method := "GET"; method != r.Method {
+			t.Errorf("expected HTTP %v request, got %v", method, r.Method)
+		}
+		q := r.URL.Query()
+		if got := q.Get("page") + "/" + q.Get("per_page"); got != "2/50" {
+			t.Errorf("requested page %v, want 2/50", got)
+		}
+		fmt.Fprint(w, `[{"name":"line-numbers","platform":"NPM","stars":3}]`)
+	})
+
+	projects, _, err := client.ProjectDependents(context.Background(), "npm", "left-pad", &ListOptions{Page: 2, PerPage: 50})
+	if err != nil {
+		t.Fatalf("ProjectDependents returned unexpected error: %v", err)
+	}
+
+	want := []*Project{{Name: String("line-numbers"), Platform: String("NPM"), Stars: Int(3)}}
+	if !reflect.DeepEqual(projects, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(projects))
+	}
+}
+
+func TestProjectDependents_errors(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/npm/nope/dependents", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Not Found"}`, http.StatusNotFound)
+	})
+
+	if _, _, err := client.ProjectDependents(context.Background(), "npm", "nope", nil); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("expected ErrProjectNotFound, got %v", err)
+	}
+
+	_, _, err := client.ProjectDependents(context.Background(), "npm", "left-pad", &ListOptions{PerPage: MaxPerPage + 1})
+	if _, ok := err.(*PerPageError); !ok {
+		t.Errorf("expected *PerPageError, got %v", err)
+	}
+}
+
+func TestProjectDependentRepositories(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/npm/left-pad/dependent_repositories", func(w http.ResponseWriter, r *http.Request) {
+		if method := "GET"; method != r.Method {
+			t.Errorf("expected HTTP %v request, got %v", method, r.Method)
+		}
+		if got := r.URL.Query().Get("per_page"); got != "30" {
+			t.Errorf("per_page is %q, want 30", got)
+		}
+		fmt.Fprint(w, `[{"full_name":"babel/babel","host_type":"GitHub","stargazers_count":40000}]`)
+	})
+
+	repos, _, err := client.ProjectDependentRepositories(context.Background(), "npm", "left-pad", nil)
+	if err != nil {
+		t.Fatalf("ProjectDependentRepositories returned unexpected error: %v", err)
+	}
+
+	want := []*Repository{{FullName: String("babel/babel"), HostType: String("GitHub"), StargazersCount: Int(40000)}}
+	if !reflect.DeepEqual(repos, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(repos))
+	}
+
+	mux.HandleFunc("/npm/nope/dependent_repositories", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Not Found"}`, http.StatusNotFound)
+	})
+	if _, _, err := client.ProjectDependentRepositories(context.Background(), "npm", "nope", nil); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("expected ErrProjectNotFound, got %v", err)
+	}
+}
+
+func TestProjectDependentRepositoriesAll(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	var pages []string
+	mux.HandleFunc("/npm/left-pad/dependent_repositories", func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page+"/"+r.URL.Query().Get("per_page"))
+		switch page {
+		case "1":
+			fmt.Fprint(w, `[{"full_name":"a","stargazers_count":50},{"full_name":"b","stargazers_count":1}]`)
+		case "2":
+			fmt.Fprint(w, `[{"full_name":"c","stargazers_count":1},{"full_name":"d","stargazers_count":20}]`)
+		default:
+			fmt.Fprint(w, `[{"full_name":"e","stargazers_count":30}]`)
+		}
+	})
+
+	opts := &DependentRepositoriesOptions{
+		Filter:      &RepositoryFilter{MinStars: 10},
+		Limit:       2,
+		ListOptions: ListOptions{PerPage: 2},
+	}
+	repos, err := client.ProjectDependentRepositoriesAll(context.Background(), "npm", "left-pad", opts)
+	if err != nil {
+		t.Fatalf("ProjectDependentRepositoriesAll returned unexpected error: %v", err)
+	}
+
+	var names []string
+	for _, r := range repos {
+		names = append(names, stringValue(r.FullName))
+	}
+	if want := []string{"a", "d"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("expected repositories %v, got %v", want, names)
+	}
+	if want := []string{"1/2", "2/2"}; !reflect.DeepEqual(pages, want) {
+		t.Errorf("expected pages %v, got %v", want, pages)
+	}
+
+	// Without a limit all pages are fetched
+	pages = nil
+	opts.Limit = 0
+	if repos, err = client.ProjectDependentRepositoriesAll(context.Background(), "npm", "left-pad", opts); err != nil {
+		t.Fatalf("ProjectDependentRepositoriesAll returned unexpected error: %v", err)
+	}
+	if len(repos) != 3 || len(pages) != 3 {
+		t.Errorf("expected 3 repositories from 3 pages, got %d from %v", len(repos), pages)
+	}
+}
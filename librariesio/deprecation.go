@@ -0,0 +1,124 @@
+package librariesio
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DeprecationNotice describes the deprecation of an endpoint as announced
+// by the Deprecation, Sunset and Warning headers of a response
+type DeprecationNotice struct {
+	// Method and Path identify the request that received the notice
+	Method string
+	Path   string
+
+	// DeprecatedAt is when the endpoint was or will be deprecated,
+	// it is zero if the Deprecation header carries no date
+	Deprecated   bool
+	DeprecatedAt time.Time
+
+	// Sunset is when the endpoint will stop responding, it is zero
+	// if the response has no Sunset header
+	Sunset time.Time
+
+	// Link points to documentation of the deprecation or sunset
+	Link string
+
+	// Warnings holds the texts of Warning headers with the
+	// miscellaneous persistent warning code 299
+	Warnings []string
+}
+
+// WithDeprecationHook calls fn for every response that announces the
+// deprecation of its endpoint. Independent of the hook, the first notice
+// received by the client is logged as a warning to the logger set with
+// WithLogger, or slog.Default if none is set.
+func WithDeprecationHook(fn func(*DeprecationNotice)) ClientOption {
+	return func(c *Client) {
+		c.deprecationHook = fn
+	}
+}
+
+// checkDeprecation reports a deprecation notice in the headers of resp
+func (c *Client) checkDeprecation(ctx context.Context, req *http.Request, resp *http.Response) {
+	notice := parseDeprecation(resp.Header)
+	if notice == nil {
+		return
+	}
+	notice.Method = req.Method
+	notice.Path = req.URL.Path
+
+	if c.deprecationHook != nil {
+		c.deprecationHook(notice)
+	}
+
+	if !c.deprecationLogged.CompareAndSwap(false, true) {
+		return
+	}
+	logger := c.logger.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	attrs := []slog.Attr{
+		slog.String("method", notice.Method),
+		slog.String("path", notice.Path),
+	}
+	if !notice.Sunset.IsZero() {
+		attrs = append(attrs, slog.Time("sunset", notice.Sunset))
+	}
+	if notice.Link != "" {
+		attrs = append(attrs, slog.String("link", notice.Link))
+	}
+	if len(notice.Warnings) > 0 {
+		attrs = append(attrs, slog.String("warning", strings.Join(notice.Warnings, "; ")))
+	}
+	logger.LogAttrs(ctx, slog.LevelWarn, "libraries.io endpoint is deprecated", attrs...)
+}
+
+var (
+	warningPattern = regexp.MustCompile(`^\s*299\s+\S+\s+"((?:[^"\\]|\\.)*)"`)
+	linkPattern    = regexp.MustCompile(`<([^>]*)>\s*;[^,]*rel="?(?:deprecation|sunset)"?`)
+)
+
+// parseDeprecation returns the notice announced by the headers,
+// or nil if they do not announce a deprecation
+func parseDeprecation(h http.Header) *DeprecationNotice {
+	notice := new(DeprecationNotice)
+
+	if value := strings.TrimSpace(h.Get("Deprecation")); value != "" && value != "false" {
+		notice.Deprecated = true
+		// RFC 9745 uses @unix-seconds, earlier drafts an HTTP date
+		if seconds, err := strconv.ParseInt(strings.TrimPrefix(value, "@"), 10, 64); err == nil && strings.HasPrefix(value, "@") {
+			notice.DeprecatedAt = time.Unix(seconds, 0).UTC()
+		} else if at, err := http.ParseTime(value); err == nil {
+			notice.DeprecatedAt = at
+		}
+	}
+
+	if at, err := http.ParseTime(strings.TrimSpace(h.Get("Sunset"))); err == nil {
+		notice.Sunset = at
+	}
+
+	for _, value := range h.Values("Warning") {
+		if m := warningPattern.FindStringSubmatch(value); m != nil {
+			notice.Warnings = append(notice.Warnings, strings.ReplaceAll(m[1], `\"`, `"`))
+		}
+	}
+
+	if !notice.Deprecated && notice.Sunset.IsZero() && len(notice.Warnings) == 0 {
+		return nil
+	}
+
+	for _, value := range h.Values("Link") {
+		if m := linkPattern.FindStringSubmatch(value); m != nil {
+			notice.Link = m[1]
+			break
+		}
+	}
+	return notice
+}
@@ -0,0 +1,90 @@
+package librariesio
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hackebrot/go-repr/repr"
+)
+
+func TestWithDeprecationHook(t *testing.T) {
+	server, mux, url := startNewServer()
+	defer server.Close()
+
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "@1688169599")
+		w.Header().Set("Sunset", "Wed, 11 Nov 2026 23:59:59 GMT")
+		w.Header().Set("Link", `<https://libraries.io/api>; rel="alternate", <https://libraries.io/deprecations>; rel="deprecation"`)
+		w.Header().Add("Warning", `110 - "Response is Stale"`)
+		w.Header().Add("Warning", `299 - "Use /api/v2 instead"`)
+		fmt.Fprint(w, `{"name":"cookiecutter"}`)
+	})
+	mux.HandleFunc("/pypi/current", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"current"}`)
+	})
+
+	var buf bytes.Buffer
+	var notices []*DeprecationNotice
+	client := NewClient(APIKey, WithLogger(slog.New(slog.NewTextHandler(&buf, nil))), WithDeprecationHook(func(n *DeprecationNotice) {
+		notices = append(notices, n)
+	}))
+	client.BaseURL = url
+
+	for _, name := range []string{"cookiecutter", "current", "cookiecutter"} {
+		if _, _, err := client.Project(context.Background(), "pypi", name); err != nil {
+			t.Fatalf("Project returned unexpected error: %v", err)
+		}
+	}
+
+	want := &DeprecationNotice{
+		Method:       http.MethodGet,
+		Path:         "/pypi/cookiecutter",
+		Deprecated:   true,
+		DeprecatedAt: time.Date(2023, time.June, 30, 23, 59, 59, 0, time.UTC),
+		Sunset:       time.Date(2026, time.November, 11, 23, 59, 59, 0, time.UTC),
+		Link:         "https://libraries.io/deprecations",
+		Warnings:     []string{"Use /api/v2 instead"},
+	}
+	if len(notices) != 2 || !reflect.DeepEqual(notices[0], want) {
+		t.Errorf("\nExpected 2 times %v\nGot %v", repr.Repr(want), repr.Repr(notices))
+	}
+
+	if n := strings.Count(buf.String(), "libraries.io endpoint is deprecated"); n != 1 {
+		t.Errorf("expected the deprecation to be logged once, got:\n%v", buf.String())
+	}
+}
+
+func TestParseDeprecation(t *testing.T) {
+	testCases := []struct {
+		name   string
+		header http.Header
+		want   *DeprecationNotice
+	}{
+		{"none", http.Header{}, nil},
+		{"unrelated warning", http.Header{"Warning": {`110 - "Response is Stale"`}}, nil},
+		{"draft boolean", http.Header{"Deprecation": {"true"}}, &DeprecationNotice{Deprecated: true}},
+		{
+			"draft date",
+			http.Header{"Deprecation": {"Sun, 11 Nov 2018 23:59:59 GMT"}},
+			&DeprecationNotice{Deprecated: true, DeprecatedAt: time.Date(2018, time.November, 11, 23, 59, 59, 0, time.UTC)},
+		},
+		{
+			"sunset only",
+			http.Header{"Sunset": {"Sun, 11 Nov 2018 23:59:59 GMT"}},
+			&DeprecationNotice{Sunset: time.Date(2018, time.November, 11, 23, 59, 59, 0, time.UTC)},
+		},
+	}
+
+	for _, testCase := range testCases {
+		if got := parseDeprecation(testCase.header); !reflect.DeepEqual(got, testCase.want) {
+			t.Errorf("%v:\nExpected %v\nGot %v", testCase.name, repr.Repr(testCase.want), repr.Repr(got))
+		}
+	}
+}
@@ -2,3 +2,5 @@
 Package librariesio is a client library for accessing the libraries.io API.
 */
 package librariesio
+
+//go:generate go run gen_endpoints.go
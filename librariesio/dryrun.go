@@ -0,0 +1,63 @@
+package librariesio
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+)
+
+// WithDryRun keeps the client from sending requests that modify data,
+// such as Subscribe or Unsubscribe. They are logged to the logger set
+// with WithLogger, or slog.Default if none is set, and answered with a
+// synthesized response echoing the request body. GET requests are sent
+// as usual.
+func WithDryRun() ClientOption {
+	return func(c *Client) {
+		c.dryRun = true
+	}
+}
+
+// dryRunResponse logs req and returns the response it is answered with
+func (c *Client) dryRunResponse(ctx context.Context, req *http.Request, cfg *requestConfig) (*Response, error) {
+	var body []byte
+	if req.GetBody != nil {
+		r, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		body, err = io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.TrimSpace(body)
+	}
+
+	logger := c.logger.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger.LogAttrs(ctx, slog.LevelInfo, "libraries.io dry run",
+		slog.String("method", req.Method),
+		slog.String("url", redactAPIKey(req.URL).String()),
+		slog.String("body", string(body)),
+	)
+
+	resp := &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       http.NoBody,
+		Request:    req,
+	}
+	if len(body) == 0 {
+		resp.Status = "204 No Content"
+		resp.StatusCode = http.StatusNoContent
+	}
+
+	response := c.newResponse(resp, body, cfg)
+	response.DryRun = true
+	return response, nil
+}
@@ -0,0 +1,72 @@
+package librariesio
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hackebrot/go-repr/repr"
+)
+
+func TestWithDryRun(t *testing.T) {
+	server, mux, url := startNewServer()
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client := NewClient(APIKey, WithDryRun(), WithLogger(slog.New(slog.NewTextHandler(&buf, nil))))
+	client.BaseURL = url
+
+	mux.HandleFunc("/subscriptions/npm/ava", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("expected %v request not to be sent", r.Method)
+		}
+		fmt.Fprint(w, `{"include_prerelease": false, "project": {"name": "ava"}}`)
+	})
+
+	ctx := context.Background()
+
+	subscription, resp, err := client.Subscribe(ctx, "npm", "ava", true)
+	if err != nil {
+		t.Fatalf("Subscribe returned unexpected error: %v", err)
+	}
+	if !resp.DryRun {
+		t.Error("expected dry run response")
+	}
+
+	want := &Subscription{
+		IncludePrerelease: Bool(true),
+		Project:           &Project{Name: String("ava"), Platform: String("npm")},
+	}
+	if !reflect.DeepEqual(subscription, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(subscription))
+	}
+
+	resp, err = client.Unsubscribe(ctx, "npm", "ava")
+	if err != nil {
+		t.Fatalf("Unsubscribe returned unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("expected synthesized 204 No Content, got %v", resp.Status)
+	}
+
+	// Reads are still sent
+	subscription, resp, err = client.Subscription(ctx, "npm", "ava")
+	if err != nil {
+		t.Fatalf("Subscription returned unexpected error: %v", err)
+	}
+	if resp.DryRun || *subscription.IncludePrerelease {
+		t.Errorf("expected subscription from the API, got %v", repr.Repr(subscription))
+	}
+
+	log := buf.String()
+	for _, s := range []string{"method=POST", "method=DELETE", `body="{\"include_prerelease\":true}"`, "api_key=REDACTED"} {
+		if !strings.Contains(log, s) {
+			t.Errorf("expected log to contain %v, got %v", s, log)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package librariesio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	ecosystemsPackagesURL = "https://packages.ecosyste.ms/api/v1/"
+	ecosystemsReposURL    = "https://repos.ecosyste.ms/api/v1/"
+
+	// ecosystemsPerPage is the page size used to list versions
+	ecosystemsPerPage = 100
+)
+
+// ecosystemsRegistries maps libraries.io platforms to ecosyste.ms registries
+var ecosystemsRegistries = map[string]string{
+	"bower":     "bower.io",
+	"cargo":     "crates.io",
+	"clojars":   "clojars.org",
+	"cocoapods": "cocoapods.org",
+	"cpan":      "metacpan.org",
+	"cran":      "cran.r-project.org",
+	"go":        "proxy.golang.org",
+	"hackage":   "hackage.haskell.org",
+	"hex":       "hex.pm",
+	"maven":     "repo1.maven.org",
+	"npm":       "npmjs.org",
+	"nuget":     "nuget.org",
+	"packagist": "packagist.org",
+	"pub":       "pub.dev",
+	"pypi":      "pypi.org",
+	"rubygems":  "rubygems.org",
+	"swiftpm":   "swiftpackageindex.com",
+}
+
+// ErrNotSupported is returned by providers for calls their backend
+// does not offer
+var ErrNotSupported = errors.New("not supported by provider")
+
+// EcosystemsProvider is a Provider backed by the ecosyste.ms packages
+// and repos APIs, mapping their responses into the libraries.io models
+type EcosystemsProvider struct {
+	PackagesURL *url.URL
+	ReposURL    *url.URL
+	UserAgent   string
+
+	client *http.Client
+}
+
+// NewEcosystemsProvider returns a provider for the public ecosyste.ms
+// APIs, http.DefaultClient is used if httpClient is nil
+func NewEcosystemsProvider(httpClient *http.Client) *EcosystemsProvider {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	packagesURL, _ := url.Parse(ecosystemsPackagesURL)
+	reposURL, _ := url.Parse(ecosystemsReposURL)
+
+	return &EcosystemsProvider{
+		PackagesURL: packagesURL,
+		ReposURL:    reposURL,
+		UserAgent:   userAgent,
+		client:      httpClient,
+	}
+}
+
+type ecosystemsPackage struct {
+	Name                      *string    `json:"name"`
+	Description               *string    `json:"description"`
+	Homepage                  *string    `json:"homepage"`
+	Keywords                  []*string  `json:"keywords"`
+	Licenses                  *string    `json:"licenses"`
+	NormalizedLicenses        []*string  `json:"normalized_licenses"`
+	RepositoryURL             *string    `json:"repository_url"`
+	RegistryURL               *string    `json:"registry_url"`
+	Status                    *string    `json:"status"`
+	LatestReleaseNumber       *string    `json:"latest_release_number"`
+	LatestReleasePublishedAt  *time.Time `json:"latest_release_published_at"`
+	LatestStableReleaseNumber *string    `json:"latest_stable_release_number"`
+	LatestStablePublishedAt   *time.Time `json:"latest_stable_release_published_at"`
+	DependentPackagesCount    *int       `json:"dependent_packages_count"`
+	DependentReposCount       *int       `json:"dependent_repos_count"`
+	RepoMetadata              *struct {
+		Language        *string `json:"language"`
+		StargazersCount *int    `json:"stargazers_count"`
+		ForksCount      *int    `json:"forks_count"`
+	} `json:"repo_metadata"`
+}
+
+type ecosystemsVersion struct {
+	Number       *string    `json:"number"`
+	PublishedAt  *time.Time `json:"published_at"`
+	Dependencies []*struct {
+		PackageName  *string `json:"package_name"`
+		Requirements *string `json:"requirements"`
+		Kind         *string `json:"kind"`
+		Optional     *bool   `json:"optional"`
+	} `json:"dependencies"`
+}
+
+type ecosystemsRepository struct {
+	FullName        *string    `json:"full_name"`
+	Description     *string    `json:"description"`
+	Homepage        *string    `json:"homepage"`
+	Language        *string    `json:"language"`
+	License         *string    `json:"license"`
+	DefaultBranch   *string    `json:"default_branch"`
+	Fork            *bool      `json:"fork"`
+	Topics          []*string  `json:"topics"`
+	StargazersCount *int       `json:"stargazers_count"`
+	ForksCount      *int       `json:"forks_count"`
+	OpenIssuesCount *int       `json:"open_issues_count"`
+	Size            *int       `json:"size"`
+	CreatedAt       *time.Time `json:"created_at"`
+	UpdatedAt       *time.Time `json:"updated_at"`
+	PushedAt        *time.Time `json:"pushed_at"`
+}
+
+// GetProject returns the package and its versions
+func (p *EcosystemsProvider) GetProject(ctx context.Context, plat, name string) (*Project, error) {
+	pkg, err := p.getPackage(ctx, plat, name)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := p.versions(ctx, plat, name)
+	if err != nil {
+		return nil, err
+	}
+
+	project := pkg.project(plat)
+	for _, v := range versions {
+		project.Versions = append(project.Versions, &Release{Number: v.Number, PublishedAt: v.PublishedAt})
+	}
+	return project, nil
+}
+
+// GetDependencies returns the package and the dependencies of the given version
+func (p *EcosystemsProvider) GetDependencies(ctx context.Context, plat, name, ver string) (*Project, error) {
+	pkg, err := p.getPackage(ctx, plat, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if ver == "latest" {
+		ver = stringValue(pkg.LatestReleaseNumber)
+	}
+
+	version := new(ecosystemsVersion)
+	if err := p.get(ctx, p.PackagesURL, p.packagePath(plat, name)+"/versions/"+url.PathEscape(ver), version); err != nil {
+		return nil, err
+	}
+
+	project := pkg.project(plat)
+	project.DependenciesForVersion = version.Number
+	for _, d := range version.Dependencies {
+		project.Dependencies = append(project.Dependencies, &ProjectDependency{
+			Name:         d.PackageName,
+			ProjectName:  d.PackageName,
+			Platform:     String(plat),
+			Requirements: d.Requirements,
+			Kind:         d.Kind,
+			Optional:     d.Optional,
+		})
+	}
+	return project, nil
+}
+
+// Search is not supported by ecosyste.ms and always returns ErrNotSupported
+func (p *EcosystemsProvider) Search(ctx context.Context, q string, opts *SearchOptions) ([]*Project, error) {
+	return nil, fmt.Errorf("ecosyste.ms search: %w", ErrNotSupported)
+}
+
+// Repository returns a repository from the ecosyste.ms repos API,
+// host is the repository host such as GitHub or GitLab
+func (p *EcosystemsProvider) Repository(ctx context.Context, host, owner, name string) (*Repository, error) {
+	repo := new(ecosystemsRepository)
+	path := fmt.Sprintf("hosts/%v/repositories/%v", url.PathEscape(host), url.PathEscape(owner+"/"+name))
+	if err := p.get(ctx, p.ReposURL, path, repo); err != nil {
+		return nil, err
+	}
+
+	return &Repository{
+		FullName:        repo.FullName,
+		Description:     repo.Description,
+		Homepage:        repo.Homepage,
+		Language:        repo.Language,
+		License:         repo.License,
+		DefaultBranch:   repo.DefaultBranch,
+		Fork:            repo.Fork,
+		Keywords:        repo.Topics,
+		StargazersCount: repo.StargazersCount,
+		ForksCount:      repo.ForksCount,
+		OpenIssuesCount: repo.OpenIssuesCount,
+		Size:            repo.Size,
+		CreatedAt:       repo.CreatedAt,
+		UpdatedAt:       repo.UpdatedAt,
+		PushedAt:        repo.PushedAt,
+		HostType:        String(host),
+	}, nil
+}
+
+func (p *EcosystemsProvider) getPackage(ctx context.Context, plat, name string) (*ecosystemsPackage, error) {
+	if _, ok := ecosystemsRegistries[strings.ToLower(plat)]; !ok {
+		return nil, fmt.Errorf("ecosyste.ms platform %q: %w", plat, ErrNotSupported)
+	}
+
+	pkg := new(ecosystemsPackage)
+	if err := p.get(ctx, p.PackagesURL, p.packagePath(plat, name), pkg); err != nil {
+		return nil, projectError(err, plat, name)
+	}
+	return pkg, nil
+}
+
+// versions pages through all versions of the package
+func (p *EcosystemsProvider) versions(ctx context.Context, plat, name string) ([]*ecosystemsVersion, error) {
+	var versions []*ecosystemsVersion
+	for page := 1; ; page++ {
+		var versionsPage []*ecosystemsVersion
+		path := fmt.Sprintf("%v/versions?page=%d&per_page=%d", p.packagePath(plat, name), page, ecosystemsPerPage)
+		if err := p.get(ctx, p.PackagesURL, path, &versionsPage); err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, versionsPage...)
+		if len(versionsPage) < ecosystemsPerPage {
+			return versions, nil
+		}
+	}
+}
+
+func (p *EcosystemsProvider) packagePath(plat, name string) string {
+	registry := ecosystemsRegistries[strings.ToLower(plat)]
+	return fmt.Sprintf("registries/%v/packages/%v", registry, url.PathEscape(name))
+}
+
+// get fetches the given path relative to base and decodes the JSON response
+func (p *EcosystemsProvider) get(ctx context.Context, base *url.URL, path string, obj interface{}) error {
+	rel, err := url.Parse(path)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("GET", base.ResolveReference(rel).String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", mediaType)
+	req.Header.Set("User-Agent", p.UserAgent)
+
+	resp, err := p.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckResponse(resp); err != nil {
+		return err
+	}
+	return json.NewDecoder(resp.Body).Decode(obj)
+}
+
+func (pkg *ecosystemsPackage) project(plat string) *Project {
+	project := &Project{
+		Name:                     pkg.Name,
+		Platform:                 String(plat),
+		Description:              pkg.Description,
+		Homepage:                 pkg.Homepage,
+		Keywords:                 pkg.Keywords,
+		Licenses:                 pkg.Licenses,
+		NormalizedLicenses:       pkg.NormalizedLicenses,
+		RepositoryURL:            pkg.RepositoryURL,
+		PackageManagerURL:        pkg.RegistryURL,
+		Status:                   pkg.Status,
+		LatestReleaseNumber:      pkg.LatestReleaseNumber,
+		LatestReleasePublishedAt: pkg.LatestReleasePublishedAt,
+		DependentsCount:          pkg.DependentPackagesCount,
+		DependentReposCount:      pkg.DependentReposCount,
+	}
+
+	if pkg.LatestStableReleaseNumber != nil {
+		project.LatestStableRelease = &Release{
+			Number:      pkg.LatestStableReleaseNumber,
+			PublishedAt: pkg.LatestStablePublishedAt,
+		}
+	}
+
+	if repo := pkg.RepoMetadata; repo != nil {
+		project.Language = repo.Language
+		project.Stars = repo.StargazersCount
+		project.Forks = repo.ForksCount
+	}
+
+	return project
+}
@@ -0,0 +1,202 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hackebrot/go-repr/repr"
+)
+
+func startEcosystemsServer() (*httptest.Server, *http.ServeMux, *EcosystemsProvider) {
+	server, mux, serverURL := startNewServer()
+	p := NewEcosystemsProvider(nil)
+	p.PackagesURL = serverURL
+	p.ReposURL, _ = url.Parse(serverURL.String() + "/repos/")
+	return server, mux, p
+}
+
+func TestEcosystemsProvider_GetProject(t *testing.T) {
+	server, mux, p := startEcosystemsServer()
+	defer server.Close()
+
+	mux.HandleFunc("/registries/pypi.org/packages/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"name": "cookiecutter",
+			"description": "Project templates",
+			"licenses": "BSD",
+			"normalized_licenses": ["BSD-3-Clause"],
+			"latest_release_number": "2.5.0",
+			"latest_stable_release_number": "2.5.0",
+			"latest_stable_release_published_at": "2023-11-22T00:00:00Z",
+			"dependent_packages_count": 120,
+			"repo_metadata": {"language": "Python", "stargazers_count": 21000, "forks_count": 2000}
+		}`)
+	})
+	mux.HandleFunc("/registries/pypi.org/packages/cookiecutter/versions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"number": "2.5.0", "published_at": "2023-11-22T00:00:00Z"}]`)
+	})
+
+	project, err := p.GetProject(context.Background(), "Pypi", "cookiecutter")
+	if err != nil {
+		t.Fatalf("GetProject returned unexpected error: %v", err)
+	}
+
+	published := time.Date(2023, 11, 22, 0, 0, 0, 0, time.UTC)
+	want := &Project{
+		Name:                String("cookiecutter"),
+		Platform:            String("Pypi"),
+		Description:         String("Project templates"),
+		Licenses:            String("BSD"),
+		NormalizedLicenses:  []*string{String("BSD-3-Clause")},
+		LatestReleaseNumber: String("2.5.0"),
+		LatestStableRelease: &Release{Number: String("2.5.0"), PublishedAt: &published},
+		DependentsCount:     Int(120),
+		Language:            String("Python"),
+		Stars:               Int(21000),
+		Forks:               Int(2000),
+		Versions:            []*Release{{Number: String("2.5.0"), PublishedAt: &published}},
+	}
+
+	if !reflect.DeepEqual(project, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(project))
+	}
+}
+
+func TestEcosystemsProvider_GetProject_paged(t *testing.T) {
+	server, mux, p := startEcosystemsServer()
+	defer server.Close()
+
+	mux.HandleFunc("/registries/npmjs.org/packages/debug", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "debug"}`)
+	})
+	mux.HandleFunc("/registries/npmjs.org/packages/debug/versions", func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		if page == 1 {
+			versions := make([]string, perPage)
+			for i := range versions {
+				versions[i] = fmt.Sprintf(`{"number": "1.0.%d"}`, i)
+			}
+			fmt.Fprintf(w, "[%v]", strings.Join(versions, ","))
+			return
+		}
+		fmt.Fprintf(w, `[{"number": "0.%d.0"}]`, page)
+	})
+
+	project, err := p.GetProject(context.Background(), "npm", "debug")
+	if err != nil {
+		t.Fatalf("GetProject returned unexpected error: %v", err)
+	}
+	if got, want := len(project.Versions), ecosystemsPerPage+1; got != want {
+		t.Fatalf("expected %d versions, got %d", want, got)
+	}
+	if got := stringValue(project.Versions[ecosystemsPerPage].Number); got != "0.2.0" {
+		t.Errorf("expected the versions of the second page, got %v", got)
+	}
+}
+
+func TestEcosystemsProvider_GetDependencies(t *testing.T) {
+	server, mux, p := startEcosystemsServer()
+	defer server.Close()
+
+	mux.HandleFunc("/registries/npmjs.org/packages/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/registries/npmjs.org/packages/@babel%2Fcore":
+			fmt.Fprint(w, `{"name": "@babel/core", "latest_release_number": "7.23.0"}`)
+		case "/registries/npmjs.org/packages/@babel%2Fcore/versions/7.23.0":
+			fmt.Fprint(w, `{"number": "7.23.0", "dependencies": [{"package_name": "debug", "requirements": "^4.1.0"}]}`)
+		default:
+			t.Errorf("unexpected path %v", r.URL.EscapedPath())
+			http.NotFound(w, r)
+		}
+	})
+
+	project, err := p.GetDependencies(context.Background(), "npm", "@babel/core", "latest")
+	if err != nil {
+		t.Fatalf("GetDependencies returned unexpected error: %v", err)
+	}
+
+	want := &Project{
+		Name:                   String("@babel/core"),
+		Platform:               String("npm"),
+		LatestReleaseNumber:    String("7.23.0"),
+		DependenciesForVersion: String("7.23.0"),
+		Dependencies: []*ProjectDependency{{
+			Name:         String("debug"),
+			ProjectName:  String("debug"),
+			Platform:     String("npm"),
+			Requirements: String("^4.1.0"),
+		}},
+	}
+
+	if !reflect.DeepEqual(project, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(project))
+	}
+}
+
+func TestEcosystemsProvider_errors(t *testing.T) {
+	server, mux, p := startEcosystemsServer()
+	defer server.Close()
+
+	mux.HandleFunc("/registries/npmjs.org/packages/nope", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": "not found"}`, http.StatusNotFound)
+	})
+
+	ctx := context.Background()
+
+	if _, err := p.GetProject(ctx, "npm", "nope"); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("expected ErrProjectNotFound, got %v", err)
+	}
+	if _, err := p.GetProject(ctx, "Nope", "x"); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("expected ErrNotSupported for unknown platform, got %v", err)
+	}
+	if _, err := p.Search(ctx, "babel", nil); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("expected ErrNotSupported for search, got %v", err)
+	}
+}
+
+func TestEcosystemsProvider_Repository(t *testing.T) {
+	server, mux, p := startEcosystemsServer()
+	defer server.Close()
+
+	mux.HandleFunc("/repos/hosts/GitHub/repositories/", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.EscapedPath(), "/repos/hosts/GitHub/repositories/gruntjs%2Fgrunt"; got != want {
+			t.Errorf("unexpected path %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{"full_name": "gruntjs/grunt", "stargazers_count": 12000, "topics": ["build"]}`)
+	})
+
+	repo, err := p.Repository(context.Background(), "GitHub", "gruntjs", "grunt")
+	if err != nil {
+		t.Fatalf("Repository returned unexpected error: %v", err)
+	}
+
+	want := &Repository{
+		FullName:        String("gruntjs/grunt"),
+		StargazersCount: Int(12000),
+		Keywords:        []*string{String("build")},
+		HostType:        String("GitHub"),
+	}
+
+	if !reflect.DeepEqual(repo, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(repo))
+	}
+}
+
+func TestWithProvider(t *testing.T) {
+	p := NewEcosystemsProvider(nil)
+	client := NewClient(APIKey, WithProvider(p))
+
+	if got := client.Provider(); got != p {
+		t.Errorf("expected configured provider, got %v", got)
+	}
+}
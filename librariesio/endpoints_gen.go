@@ -0,0 +1,79 @@
+// Code generated by gen_endpoints.go from openapi.json; DO NOT EDIT.
+
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// BowerSearch returns a page of the Bower packages matching the given
+// search string
+//
+// GET https://libraries.io/api/bower-search
+//
+// q is the search string
+// opts selects the page, it may be nil for the first page
+func (c *Client) BowerSearch(ctx context.Context, q string, opts *ListOptions) ([]*Project, *Response, error) {
+	request, err := c.newListRequest("bower-search?q="+url.QueryEscape(q), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var projects []*Project
+
+	response, err := c.Do(ctx, request, &projects)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return projects, response, nil
+}
+
+// UserDependenciesOptions specifies the optional parameters of
+// UserDependencies
+type UserDependenciesOptions struct {
+	// Platform limits the dependencies to the given platform
+	Platform string `url:"platform,omitempty"`
+
+	ListOptions
+}
+
+// UserDependencies returns a page of the projects the repositories of
+// the given GitHub user depend on
+//
+// GET https://libraries.io/api/github/:login/dependencies
+//
+// login is a user or organization on GitHub
+// opts holds the optional parameters, it may be nil
+func (c *Client) UserDependencies(ctx context.Context, login string, opts *UserDependenciesOptions) ([]*Project, *Response, error) {
+	var o UserDependenciesOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	var err error
+	if o.ListOptions, err = o.ListOptions.normalize(); err != nil {
+		return nil, nil, err
+	}
+
+	urlStr, err := addOptions(fmt.Sprintf("github/%v/dependencies", url.PathEscape(login)), o)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request, err := c.NewRequest("GET", urlStr, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var projects []*Project
+
+	response, err := c.Do(ctx, request, &projects)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return projects, response, nil
+}
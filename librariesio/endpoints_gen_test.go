@@ -0,0 +1,86 @@
+package librariesio
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hackebrot/go-librariesio/librariesio/internal/openapi"
+)
+
+func TestEndpointsGenerated(t *testing.T) {
+	spec, err := os.ReadFile("openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := openapi.Generate(spec)
+	if err != nil {
+		t.Fatalf("Generate returned unexpected error: %v", err)
+	}
+
+	got, err := os.ReadFile("endpoints_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("endpoints_gen.go is out of date, run go generate")
+	}
+}
+
+func TestBowerSearch(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/bower-search", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("q"); got != "jquery ui" {
+			t.Errorf("q is %q, want %q", got, "jquery ui")
+		}
+		if got := r.URL.Query().Get("page"); got != "2" {
+			t.Errorf("page is %q, want 2", got)
+		}
+		fmt.Fprint(w, `[{"name": "jquery-ui", "platform": "Bower"}]`)
+	})
+
+	projects, _, err := client.BowerSearch(context.Background(), "jquery ui", &ListOptions{Page: 2})
+	if err != nil {
+		t.Fatalf("BowerSearch returned unexpected error: %v", err)
+	}
+	if len(projects) != 1 || stringValue(projects[0].Name) != "jquery-ui" {
+		t.Errorf("unexpected projects %+v", projects)
+	}
+}
+
+func TestUserDependencies(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/github/hackebrot/dependencies", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("platform"); got != "Pypi" {
+			t.Errorf("platform is %q, want Pypi", got)
+		}
+		if got := r.URL.Query().Get("per_page"); got != "5" {
+			t.Errorf("per_page is %q, want 5", got)
+		}
+		fmt.Fprint(w, `[{"name": "poyo", "platform": "Pypi"}]`)
+	})
+
+	opts := &UserDependenciesOptions{Platform: "Pypi", ListOptions: ListOptions{PerPage: 5}}
+	projects, _, err := client.UserDependencies(context.Background(), "hackebrot", opts)
+	if err != nil {
+		t.Fatalf("UserDependencies returned unexpected error: %v", err)
+	}
+	if len(projects) != 1 || stringValue(projects[0].Name) != "poyo" {
+		t.Errorf("unexpected projects %+v", projects)
+	}
+
+	if _, _, err := client.UserDependencies(context.Background(), "hackebrot", &UserDependenciesOptions{ListOptions: ListOptions{PerPage: MaxPerPage + 1}}); err == nil {
+		t.Error("expected an error for an invalid per_page")
+	}
+}
@@ -0,0 +1,122 @@
+package librariesio
+
+import (
+	"context"
+	"sync"
+)
+
+// RepositoryInfo is data about the source repository of a project
+// that libraries.io does not provide
+type RepositoryInfo struct {
+	HasReadme bool
+	Readme    string
+	Topics    []string
+
+	// Extra holds any other data of the fetcher
+	Extra map[string]interface{}
+}
+
+// RepositoryFetcher fetches data about a source repository, e.g. from the
+// API of the code host or a local clone. host is GitHub, GitLab or
+// Bitbucket as returned by ParseRepoURL.
+type RepositoryFetcher interface {
+	FetchRepository(ctx context.Context, host, owner, name string) (*RepositoryInfo, error)
+}
+
+// RepositoryFetcherFunc is an adapter to allow the use of ordinary
+// functions as RepositoryFetcher
+type RepositoryFetcherFunc func(ctx context.Context, host, owner, name string) (*RepositoryInfo, error)
+
+// FetchRepository calls f(ctx, host, owner, name)
+func (f RepositoryFetcherFunc) FetchRepository(ctx context.Context, host, owner, name string) (*RepositoryInfo, error) {
+	return f(ctx, host, owner, name)
+}
+
+// WithRepositoryFetcher sets the fetcher used by EnrichProjects
+func WithRepositoryFetcher(f RepositoryFetcher) ClientOption {
+	return func(c *Client) {
+		c.repoFetcher = f
+	}
+}
+
+// enrichWorkers is the number of repositories EnrichProjects fetches at once
+const enrichWorkers = 4
+
+// EnrichProjects sets the Enrichment of the given projects to the data
+// the fetcher set with WithRepositoryFetcher returns for their source
+// repository, found like RepositoryForProject does. Projects without a
+// known repository URL are left unchanged, as are all projects if no
+// fetcher is set. Repositories are fetched once even if several projects
+// share them, nil projects are skipped. Projects whose repository could
+// not be fetched are reported in a *BatchError. If ctx is cancelled, the
+// fetches already started are awaited and the error of ctx is returned.
+func (c *Client) EnrichProjects(ctx context.Context, projects ...*Project) error {
+	if c.repoFetcher == nil {
+		return nil
+	}
+
+	type repoKey struct{ host, owner, name string }
+
+	repos := make(map[repoKey][]*Project)
+	var keys []repoKey
+	for _, p := range projects {
+		if p == nil {
+			continue
+		}
+		host, owner, name, ok := projectRepository(p)
+		if !ok {
+			continue
+		}
+		key := repoKey{host, owner, name}
+		if _, ok := repos[key]; !ok {
+			keys = append(keys, key)
+		}
+		repos[key] = append(repos[key], p)
+	}
+
+	var (
+		mu   sync.Mutex
+		refs []ProjectRef
+		errs = make(map[ProjectRef]error)
+		wg   sync.WaitGroup
+		sem  = make(chan struct{}, enrichWorkers)
+	)
+
+	for _, p := range projects {
+		if p != nil {
+			refs = append(refs, p.Ref())
+		}
+	}
+
+	for _, key := range keys {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		// A slot may have been free when ctx was cancelled
+		if err := ctx.Err(); err != nil {
+			wg.Wait()
+			return err
+		}
+		wg.Add(1)
+		go func(key repoKey) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			info, err := c.repoFetcher.FetchRepository(ctx, key.host, key.owner, key.name)
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, p := range repos[key] {
+				if err != nil {
+					errs[p.Ref()] = err
+					continue
+				}
+				p.Enrichment = info
+			}
+		}(key)
+	}
+	wg.Wait()
+
+	return batchError(refs, errs)
+}
@@ -0,0 +1,83 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+func TestEnrichProjects(t *testing.T) {
+	var calls int32
+	fetcher := RepositoryFetcherFunc(func(ctx context.Context, host, owner, name string) (*RepositoryInfo, error) {
+		atomic.AddInt32(&calls, 1)
+		if name == "broken" {
+			return nil, errors.New("rate limited")
+		}
+		return &RepositoryInfo{HasReadme: true, Topics: []string{host, owner + "/" + name}}, nil
+	})
+	client := NewClient(APIKey, WithRepositoryFetcher(fetcher))
+
+	core := &Project{Platform: String("NPM"), Name: String("@babel/core"), RepositoryURL: String("https://github.com/babel/babel")}
+	parser := &Project{Platform: String("NPM"), Name: String("@babel/parser"), Homepage: String("https://github.com/babel/babel/tree/main/packages/babel-parser")}
+	broken := &Project{Platform: String("NPM"), Name: String("broken"), RepositoryURL: String("https://gitlab.com/group/broken")}
+	unknown := &Project{Platform: String("NPM"), Name: String("unknown"), Homepage: String("https://example.com")}
+
+	err := client.EnrichProjects(context.Background(), core, nil, parser, broken, unknown)
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected a *BatchError, got %v", err)
+	}
+	if want := []ProjectRef{broken.Ref()}; !reflect.DeepEqual(batchErr.Failed(), want) {
+		t.Errorf("\nExpected %v\nGot %v", want, batchErr.Failed())
+	}
+
+	want := &RepositoryInfo{HasReadme: true, Topics: []string{"GitHub", "babel/babel"}}
+	if !reflect.DeepEqual(core.Enrichment, want) || parser.Enrichment != core.Enrichment {
+		t.Errorf("expected both babel packages to share %+v, got %+v and %+v", want, core.Enrichment, parser.Enrichment)
+	}
+	if broken.Enrichment != nil || unknown.Enrichment != nil {
+		t.Errorf("expected no enrichment for broken and unknown")
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("expected 2 repositories to be fetched, got %d", got)
+	}
+}
+
+func TestEnrichProjects_noFetcher(t *testing.T) {
+	project := &Project{RepositoryURL: String("https://github.com/babel/babel")}
+	if err := NewClient(APIKey).EnrichProjects(context.Background(), project); err != nil || project.Enrichment != nil {
+		t.Errorf("expected projects to be left unchanged, got %v", err)
+	}
+}
+
+func TestEnrichProjects_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var started int32
+	fetcher := RepositoryFetcherFunc(func(ctx context.Context, host, owner, name string) (*RepositoryInfo, error) {
+		if atomic.AddInt32(&started, 1) == enrichWorkers {
+			cancel()
+		}
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	client := NewClient(APIKey, WithRepositoryFetcher(fetcher))
+
+	var projects []*Project
+	for i := 0; i < 2*enrichWorkers; i++ {
+		url := fmt.Sprintf("https://github.com/owner/repo%d", i)
+		projects = append(projects, &Project{Platform: String("NPM"), Name: String(url), RepositoryURL: String(url)})
+	}
+
+	if err := client.EnrichProjects(ctx, projects...); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if got := atomic.LoadInt32(&started); got != enrichWorkers {
+		t.Errorf("expected no fetches to start after cancellation, got %d", got)
+	}
+}
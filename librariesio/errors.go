@@ -0,0 +1,112 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// ErrProjectNotFound is matched by errors.Is for every ProjectNotFoundError
+var ErrProjectNotFound = errors.New("project not found")
+
+// ProjectNotFoundError is returned when the API responds with a 404
+// for a project lookup
+type ProjectNotFoundError struct {
+	Platform string
+	Name     string
+
+	// Suggestion is the normalized name of the project on the platform,
+	// it is only set if it differs from the requested name
+	Suggestion string
+
+	// Err is the underlying ErrorResponse
+	Err error
+}
+
+// Error returns information about the project that could not be found
+func (e *ProjectNotFoundError) Error() string {
+	msg := fmt.Sprintf("project %v/%v not found", e.Platform, e.Name)
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(" (did you mean %q?)", e.Suggestion)
+	}
+	return msg
+}
+
+// Is reports whether target is ErrProjectNotFound
+func (e *ProjectNotFoundError) Is(target error) bool {
+	return target == ErrProjectNotFound
+}
+
+// Unwrap returns the underlying ErrorResponse
+func (e *ProjectNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// projectError turns 404 error responses into a ProjectNotFoundError
+// and returns any other error as is
+func projectError(err error, plat, name string) error {
+	errResp, ok := err.(*ErrorResponse)
+	if !ok || errResp.Response == nil || errResp.Response.StatusCode != http.StatusNotFound {
+		return err
+	}
+
+	notFound := &ProjectNotFoundError{Platform: plat, Name: name, Err: err}
+	if normalized := normalizeName(plat, name); normalized != name {
+		notFound.Suggestion = normalized
+	}
+	return notFound
+}
+
+// ErrPartialResult is matched by errors.Is for every PartialResultError
+var ErrPartialResult = errors.New("partial result")
+
+// PartialResultError is returned together with the results gathered so
+// far when the context is cancelled in the middle of a call that makes
+// several requests, such as ResolveTree or CompareProjects
+type PartialResultError struct {
+	// Err is the error that interrupted the call, usually wrapping
+	// context.Canceled or context.DeadlineExceeded
+	Err error
+}
+
+// Error returns the error that interrupted the call
+func (e *PartialResultError) Error() string {
+	return fmt.Sprintf("partial result: %v", e.Err)
+}
+
+// Is reports whether target is ErrPartialResult
+func (e *PartialResultError) Is(target error) bool {
+	return target == ErrPartialResult
+}
+
+// Unwrap returns the error that interrupted the call
+func (e *PartialResultError) Unwrap() error {
+	return e.Err
+}
+
+// partialResult wraps err in a PartialResultError if ctx is done,
+// ok is false if the error is unrelated to the context
+func partialResult(ctx context.Context, err error) (partial error, ok bool) {
+	if ctx.Err() == nil {
+		return err, false
+	}
+	return &PartialResultError{Err: err}, true
+}
+
+var pypiSeparators = regexp.MustCompile(`[-_.]+`)
+
+// normalizeName returns the canonical form of a project name on platforms
+// with well-known normalization rules and the unchanged name otherwise
+func normalizeName(plat, name string) string {
+	switch strings.ToLower(plat) {
+	case "pypi":
+		// See PEP 503
+		return pypiSeparators.ReplaceAllString(strings.ToLower(name), "-")
+	case "npm":
+		return strings.ToLower(name)
+	}
+	return name
+}
@@ -0,0 +1,98 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestProjectNotFoundError(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Error 404, project or project version not found."}`, http.StatusNotFound)
+	})
+
+	_, _, err := client.Project(context.Background(), "pypi", "Flask_SQLAlchemy")
+
+	if !errors.Is(err, ErrProjectNotFound) {
+		t.Fatalf("expected ErrProjectNotFound, got %v", err)
+	}
+
+	var notFound *ProjectNotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("expected *ProjectNotFoundError, got %T", err)
+	}
+
+	if notFound.Platform != "pypi" || notFound.Name != "Flask_SQLAlchemy" {
+		t.Errorf("unexpected project %v/%v", notFound.Platform, notFound.Name)
+	}
+
+	want := `project pypi/Flask_SQLAlchemy not found (did you mean "flask-sqlalchemy"?)`
+	if got := err.Error(); got != want {
+		t.Errorf("\nExpected %q\nGot %q", want, got)
+	}
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Errorf("expected error to wrap *ErrorResponse")
+	}
+}
+
+func TestProjectDeps_notFound(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Not Found"}`, http.StatusNotFound)
+	})
+
+	_, _, err := client.ProjectDeps(context.Background(), "npm", "ava", "latest")
+
+	if !errors.Is(err, ErrProjectNotFound) {
+		t.Fatalf("expected ErrProjectNotFound, got %v", err)
+	}
+	if got, want := err.Error(), "project npm/ava not found"; got != want {
+		t.Errorf("\nExpected %q\nGot %q", want, got)
+	}
+}
+
+func TestProject_serverError(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Internal Server Error"}`, http.StatusInternalServerError)
+	})
+
+	_, _, err := client.Project(context.Background(), "npm", "ava")
+
+	if _, ok := err.(*ErrorResponse); !ok {
+		t.Fatalf("expected *ErrorResponse, got %T", err)
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	testCases := []struct {
+		plat, name, want string
+	}{
+		{"pypi", "Flask_SQLAlchemy", "flask-sqlalchemy"},
+		{"PyPI", "zope.interface", "zope-interface"},
+		{"npm", "JSONStream", "jsonstream"},
+		{"maven", "org.Foo:Bar", "org.Foo:Bar"},
+	}
+
+	for _, testCase := range testCases {
+		if got := normalizeName(testCase.plat, testCase.name); got != testCase.want {
+			t.Errorf("normalizeName(%q, %q) is %q, want %q", testCase.plat, testCase.name, got, testCase.want)
+		}
+	}
+}
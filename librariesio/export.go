@@ -0,0 +1,52 @@
+package librariesio
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// MarshalStable encodes v as JSON indented by two spaces, for artifacts
+// that are kept under version control. Struct fields keep the order of
+// their declaration, map keys are sorted, HTML characters are not escaped
+// and the output ends with a newline, so the same value always encodes to
+// the same bytes and changes diff cleanly.
+func MarshalStable(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// NDJSONEncoder writes values as newline delimited JSON, one compact
+// line per value, with the same guarantees as MarshalStable
+type NDJSONEncoder struct {
+	enc *json.Encoder
+}
+
+// NewNDJSONEncoder returns an encoder writing to w
+func NewNDJSONEncoder(w io.Writer) *NDJSONEncoder {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &NDJSONEncoder{enc: enc}
+}
+
+// Encode writes v as a single line
+func (e *NDJSONEncoder) Encode(v interface{}) error {
+	return e.enc.Encode(v)
+}
+
+// marshalLine encodes v as a single NDJSON line including the newline
+func marshalLine(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := NewNDJSONEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
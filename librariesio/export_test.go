@@ -0,0 +1,56 @@
+package librariesio
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestMarshalStable(t *testing.T) {
+	v := map[string]interface{}{
+		"url":      "https://libraries.io/api/search?q=a&platforms=npm",
+		"projects": []*Project{{Name: String("b"), Platform: String("NPM"), Stars: Int(1)}},
+		"at":       time.Date(2017, time.April, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	want := `{
+  "at": "2017-04-01T00:00:00Z",
+  "projects": [
+    {
+      "name": "b",
+      "platform": "NPM",
+      "stars": 1
+    }
+  ],
+  "url": "https://libraries.io/api/search?q=a&platforms=npm"
+}
+`
+
+	for i := 0; i < 3; i++ {
+		got, err := MarshalStable(v)
+		if err != nil {
+			t.Fatalf("MarshalStable returned unexpected error: %v", err)
+		}
+		if string(got) != want {
+			t.Errorf("\nExpected %v\nGot %v", want, string(got))
+		}
+	}
+}
+
+func TestNDJSONEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewNDJSONEncoder(&buf)
+
+	for _, ref := range []ProjectRef{{Platform: "npm", Name: "a&b"}, {Platform: "pypi", Name: "c"}} {
+		if err := enc.Encode(ref); err != nil {
+			t.Fatalf("Encode returned unexpected error: %v", err)
+		}
+	}
+
+	want := `{"Platform":"npm","Name":"a&b","Version":""}
+{"Platform":"pypi","Name":"c","Version":""}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("\nExpected %v\nGot %v", want, got)
+	}
+}
@@ -0,0 +1,98 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// FeedOptions specifies the time window and filters of NewProjects and
+// UpdatedProjects
+type FeedOptions struct {
+	// Since is the start of the window and is required
+	Since time.Time
+
+	// Until is the end of the window, it defaults to now
+	Until time.Time
+
+	// Filters, see SearchOptions
+	Platforms []string
+	Languages []string
+	Keywords  []string
+
+	// MaxResults stops paging once as many projects were found,
+	// 0 means no limit
+	MaxResults int
+}
+
+// NewProjects returns the projects first released within the window of
+// opts, newest first. The API has no feed of new projects, so this pages
+// through the search results sorted by creation time until a project was
+// created before the window. Projects without any release, or whose first
+// release is outside of the window, are left out.
+//
+// GET https://libraries.io/api/search?sort=created_at
+func (c *Client) NewProjects(ctx context.Context, opts FeedOptions) ([]*Project, error) {
+	created := func(p *Project) *time.Time {
+		return p.CreatedAt
+	}
+	return c.feed(ctx, "created_at", opts, created, func(p *Project) *time.Time {
+		if published := publishDates(p.Versions); len(published) > 0 {
+			return &published[0]
+		}
+		return nil
+	})
+}
+
+// UpdatedProjects returns the projects with a release published within
+// the window of opts, most recently released first. Like NewProjects it
+// pages through search results, sorted by their latest release.
+//
+// GET https://libraries.io/api/search?sort=latest_release_published_at
+func (c *Client) UpdatedProjects(ctx context.Context, opts FeedOptions) ([]*Project, error) {
+	released := func(p *Project) *time.Time {
+		return p.LatestReleasePublishedAt
+	}
+	return c.feed(ctx, "latest_release_published_at", opts, released, released)
+}
+
+// feed pages through search results sorted descending by sort until the
+// sort time of a project, as returned by sorted, is before opts.Since.
+// Projects whose time as returned by at is outside of the window are
+// skipped.
+func (c *Client) feed(ctx context.Context, sort string, opts FeedOptions, sorted, at func(*Project) *time.Time) ([]*Project, error) {
+	if opts.Since.IsZero() {
+		return nil, errors.New("feed options need a start time")
+	}
+	until := opts.Until
+	if until.IsZero() {
+		until = now()
+	}
+
+	var projects []*Project
+
+	_, _, err := c.SearchUntil(ctx, "", &SearchOptions{
+		Sort:      sort,
+		Platforms: opts.Platforms,
+		Languages: opts.Languages,
+		Keywords:  opts.Keywords,
+	}, func(p *Project) bool {
+		if t := sorted(p); t != nil && t.Before(opts.Since) {
+			return true
+		}
+		if t := at(p); t == nil || t.After(until) || t.Before(opts.Since) {
+			return false
+		}
+
+		projects = append(projects, p)
+		return opts.MaxResults > 0 && len(projects) >= opts.MaxResults
+	})
+	if err != nil {
+		if errors.Is(err, ErrPartialResult) {
+			return projects, err
+		}
+		return nil, err
+	}
+
+	return projects, nil
+}
@@ -0,0 +1,109 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func feedNames(projects []*Project) []string {
+	var names []string
+	for _, p := range projects {
+		names = append(names, stringValue(p.Name))
+	}
+	return names
+}
+
+func TestUpdatedProjects(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if got := q.Get("sort"); got != "latest_release_published_at" {
+			t.Errorf("sort is %q, want latest_release_published_at", got)
+		}
+		if got := q["platforms"]; !reflect.DeepEqual(got, []string{"npm"}) {
+			t.Errorf("platforms are %v, want [npm]", got)
+		}
+		fmt.Fprint(w, `[
+			{"name":"future","latest_release_published_at":"2017-04-05T00:00:00Z"},
+			{"name":"recent","latest_release_published_at":"2017-03-20T00:00:00Z"},
+			{"name":"unreleased"},
+			{"name":"early","latest_release_published_at":"2017-03-02T00:00:00Z"},
+			{"name":"old","latest_release_published_at":"2017-02-01T00:00:00Z"},
+			{"name":"unsorted","latest_release_published_at":"2017-03-10T00:00:00Z"}
+		]`)
+	})
+
+	opts := FeedOptions{
+		Since:     time.Date(2017, time.March, 1, 0, 0, 0, 0, time.UTC),
+		Until:     time.Date(2017, time.April, 1, 0, 0, 0, 0, time.UTC),
+		Platforms: []string{"npm"},
+	}
+
+	projects, err := client.UpdatedProjects(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("UpdatedProjects returned unexpected error: %v", err)
+	}
+	if got, want := feedNames(projects), []string{"recent", "early"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, got)
+	}
+
+	opts.MaxResults = 1
+	projects, err = client.UpdatedProjects(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("UpdatedProjects returned unexpected error: %v", err)
+	}
+	if got, want := feedNames(projects), []string{"recent"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, got)
+	}
+}
+
+func TestNewProjects(t *testing.T) {
+	defer func(f func() time.Time) { now = f }(now)
+	now = func() time.Time {
+		return time.Date(2017, time.April, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("sort"); got != "created_at" {
+			t.Errorf("sort is %q, want created_at", got)
+		}
+		fmt.Fprint(w, `[
+			{"name":"new","created_at":"2017-03-26T00:00:00Z","versions":[
+				{"number":"1.1.0","published_at":"2017-03-25T00:00:00Z"},
+				{"number":"1.0.0","published_at":"2017-03-15T00:00:00Z"}
+			]},
+			{"name":"empty","created_at":"2017-03-24T00:00:00Z","versions":[]},
+			{"name":"imported","created_at":"2017-03-22T00:00:00Z","versions":[
+				{"number":"2.0.0","published_at":"2017-03-21T00:00:00Z"},
+				{"number":"1.0.0","published_at":"2016-01-01T00:00:00Z"}
+			]},
+			{"name":"newer","created_at":"2017-03-21T00:00:00Z","versions":[{"number":"0.1.0","published_at":"2017-03-20T00:00:00Z"}]},
+			{"name":"established","created_at":"2016-01-01T00:00:00Z","versions":[{"number":"3.0.0","published_at":"2017-03-10T00:00:00Z"}]}
+		]`)
+	})
+
+	projects, err := client.NewProjects(context.Background(), FeedOptions{Since: time.Date(2017, time.March, 1, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatalf("NewProjects returned unexpected error: %v", err)
+	}
+	if got, want := feedNames(projects), []string{"new", "newer"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, got)
+	}
+
+	if _, err := client.NewProjects(context.Background(), FeedOptions{}); err == nil {
+		t.Error("Expected error without a start time")
+	}
+}
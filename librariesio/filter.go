@@ -0,0 +1,49 @@
+package librariesio
+
+import "strings"
+
+// RepositoryFilter selects repositories on the client side, e.g. to only
+// keep actively maintained dependents of a project. It is applied to every
+// page fetched by ProjectDependentRepositoriesAll.
+type RepositoryFilter struct {
+	// HostType only keeps repositories on the given host such as GitHub,
+	// compared case insensitively. All hosts are kept if empty.
+	HostType string
+
+	// MinStars only keeps repositories with at least as many stars
+	MinStars int
+
+	// PushedWithinMonths only keeps repositories pushed to within the
+	// given number of months, 0 keeps repositories regardless of activity
+	PushedWithinMonths int
+}
+
+// Match reports whether the repository passes the filter
+func (f *RepositoryFilter) Match(r *Repository) bool {
+	if r == nil {
+		return false
+	}
+	if f.HostType != "" && !strings.EqualFold(f.HostType, stringValue(r.HostType)) {
+		return false
+	}
+	if intValue(r.StargazersCount) < f.MinStars {
+		return false
+	}
+	if f.PushedWithinMonths > 0 {
+		if r.PushedAt == nil || r.PushedAt.Before(now().AddDate(0, -f.PushedWithinMonths, 0)) {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterRepositories returns the repositories that pass the filter
+func FilterRepositories(repos []*Repository, f *RepositoryFilter) []*Repository {
+	var filtered []*Repository
+	for _, r := range repos {
+		if f.Match(r) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
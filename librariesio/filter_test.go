@@ -0,0 +1,46 @@
+package librariesio
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRepositoryFilter(t *testing.T) {
+	defer func(f func() time.Time) { now = f }(now)
+	at := time.Date(2017, time.April, 1, 0, 0, 0, 0, time.UTC)
+	now = func() time.Time { return at }
+
+	recently := at.AddDate(0, -2, 0)
+	longAgo := at.AddDate(-2, 0, 0)
+
+	repos := []*Repository{
+		{FullName: String("a"), HostType: String("GitHub"), StargazersCount: Int(50), PushedAt: &recently},
+		{FullName: String("b"), HostType: String("GitLab"), StargazersCount: Int(50), PushedAt: &recently},
+		{FullName: String("c"), HostType: String("GitHub"), StargazersCount: Int(5), PushedAt: &recently},
+		{FullName: String("d"), HostType: String("GitHub"), StargazersCount: Int(50), PushedAt: &longAgo},
+		{FullName: String("e"), HostType: String("GitHub"), StargazersCount: Int(50)},
+		nil,
+	}
+
+	testCases := []struct {
+		name   string
+		filter *RepositoryFilter
+		want   string
+	}{
+		{"none", &RepositoryFilter{}, "abcde"},
+		{"host", &RepositoryFilter{HostType: "github"}, "acde"},
+		{"stars", &RepositoryFilter{MinStars: 10}, "abde"},
+		{"pushed", &RepositoryFilter{PushedWithinMonths: 6}, "abc"},
+		{"all", &RepositoryFilter{HostType: "GitHub", MinStars: 10, PushedWithinMonths: 6}, "a"},
+	}
+
+	for _, testCase := range testCases {
+		var got string
+		for _, r := range FilterRepositories(repos, testCase.filter) {
+			got += *r.FullName
+		}
+		if got != testCase.want {
+			t.Errorf("%v: got %q, want %q", testCase.name, got, testCase.want)
+		}
+	}
+}
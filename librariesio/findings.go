@@ -0,0 +1,121 @@
+package librariesio
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity ranks how serious a finding of an analyzer is
+type Severity int
+
+// Severities in increasing order
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+var severityNames = []string{"info", "warning", "error"}
+
+// String returns the lowercase name of the severity
+func (s Severity) String() string {
+	if s >= 0 && int(s) < len(severityNames) {
+		return severityNames[s]
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// MarshalText encodes the severity as its name
+func (s Severity) MarshalText() ([]byte, error) {
+	if s < 0 || int(s) >= len(severityNames) {
+		return nil, fmt.Errorf("unknown severity %d", int(s))
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a severity from its name
+func (s *Severity) UnmarshalText(text []byte) error {
+	for i, name := range severityNames {
+		if strings.EqualFold(string(text), name) {
+			*s = Severity(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown severity %q", text)
+}
+
+// FindingCode identifies a kind of finding. Codes are stable across
+// releases of this package, so they can be used in allow and deny lists.
+type FindingCode string
+
+// Codes of the findings reported by DetectStale and FindConflicts
+const (
+	CodeNoRelease       FindingCode = "stale.no-release"
+	CodeReleaseAge      FindingCode = "stale.release-age"
+	CodeNoStableRelease FindingCode = "stale.no-stable-release"
+	CodeDeprecated      FindingCode = "status.deprecated"
+	CodeUnmaintained    FindingCode = "status.unmaintained"
+	CodeRemoved         FindingCode = "status.removed"
+	CodeInactive        FindingCode = "status.inactive"
+	CodeVersionConflict FindingCode = "deps.version-conflict"
+)
+
+var findingSeverities = map[FindingCode]Severity{
+	CodeNoRelease:       SeverityWarning,
+	CodeReleaseAge:      SeverityWarning,
+	CodeNoStableRelease: SeverityInfo,
+	CodeDeprecated:      SeverityWarning,
+	CodeUnmaintained:    SeverityWarning,
+	CodeRemoved:         SeverityError,
+	CodeInactive:        SeverityWarning,
+	CodeVersionConflict: SeverityWarning,
+}
+
+// Severity returns the severity findings with the code are reported with,
+// unknown codes are errors
+func (c FindingCode) Severity() Severity {
+	if s, ok := findingSeverities[c]; ok {
+		return s
+	}
+	return SeverityError
+}
+
+// Issue is a single problem found by an analyzer
+type Issue struct {
+	Code     FindingCode `json:"code"`
+	Severity Severity    `json:"severity"`
+	Message  string      `json:"message"`
+}
+
+func newIssue(code FindingCode, format string, args ...interface{}) Issue {
+	return Issue{Code: code, Severity: code.Severity(), Message: fmt.Sprintf(format, args...)}
+}
+
+// String returns the code and message of the issue
+func (i Issue) String() string {
+	return fmt.Sprintf("%v [%v]: %v", i.Code, i.Severity, i.Message)
+}
+
+// maxSeverity returns the highest severity of the issues
+func maxSeverity(issues []Issue) Severity {
+	var max Severity
+	for _, i := range issues {
+		if i.Severity > max {
+			max = i.Severity
+		}
+	}
+	return max
+}
+
+// statusCode returns the code for an inactive project status
+func statusCode(status string) FindingCode {
+	switch strings.ToLower(status) {
+	case "deprecated":
+		return CodeDeprecated
+	case "unmaintained":
+		return CodeUnmaintained
+	case "removed":
+		return CodeRemoved
+	}
+	return CodeInactive
+}
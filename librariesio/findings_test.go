@@ -0,0 +1,67 @@
+package librariesio
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSeverityText(t *testing.T) {
+	for _, s := range []Severity{SeverityInfo, SeverityWarning, SeverityError} {
+		data, err := json.Marshal(s)
+		if err != nil {
+			t.Fatalf("Marshal(%v) returned unexpected error: %v", s, err)
+		}
+
+		var got Severity
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal(%s) returned unexpected error: %v", data, err)
+		}
+		if got != s {
+			t.Errorf("%v round-tripped as %v", s, got)
+		}
+	}
+
+	if data, _ := json.Marshal(SeverityWarning); string(data) != `"warning"` {
+		t.Errorf("SeverityWarning encoded as %s, want %q", data, "warning")
+	}
+
+	var s Severity
+	if err := s.UnmarshalText([]byte("fatal")); err == nil {
+		t.Error("Expected error for unknown severity")
+	}
+	if _, err := Severity(7).MarshalText(); err == nil {
+		t.Error("Expected error for out of range severity")
+	}
+}
+
+// TestFindingCodes pins the codes, CI pipelines match them literally
+func TestFindingCodes(t *testing.T) {
+	testCases := []struct {
+		code     FindingCode
+		want     string
+		severity Severity
+	}{
+		{CodeNoRelease, "stale.no-release", SeverityWarning},
+		{CodeReleaseAge, "stale.release-age", SeverityWarning},
+		{CodeNoStableRelease, "stale.no-stable-release", SeverityInfo},
+		{CodeDeprecated, "status.deprecated", SeverityWarning},
+		{CodeUnmaintained, "status.unmaintained", SeverityWarning},
+		{CodeRemoved, "status.removed", SeverityError},
+		{CodeInactive, "status.inactive", SeverityWarning},
+		{CodeVersionConflict, "deps.version-conflict", SeverityWarning},
+		{FindingCode("unknown"), "unknown", SeverityError},
+	}
+
+	for _, testCase := range testCases {
+		if string(testCase.code) != testCase.want {
+			t.Errorf("code is %q, want %q", testCase.code, testCase.want)
+		}
+		if got := testCase.code.Severity(); got != testCase.severity {
+			t.Errorf("%v.Severity() returned %v, want %v", testCase.code, got, testCase.severity)
+		}
+	}
+
+	if got := (&DependencyConflict{}).Code(); got != CodeVersionConflict {
+		t.Errorf("DependencyConflict.Code() returned %v, want %v", got, CodeVersionConflict)
+	}
+}
@@ -0,0 +1,182 @@
+package librariesio
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FlattenStrategy selects the version Flatten installs for a package that
+// occurs with different versions in a dependency tree
+type FlattenStrategy int
+
+const (
+	// HighestWins picks the highest version, like npm and Cargo
+	// deduplicate compatible versions
+	HighestWins FlattenStrategy = iota
+
+	// FirstWins picks the version closest to the root, the first
+	// one found in breadth-first order, like Maven's nearest wins
+	FirstWins
+
+	// ReportConflicts fails with a *VersionConflictError if a
+	// package occurs with more than one version
+	ReportConflicts
+)
+
+// VersionConflict is a package that occurs with different versions
+type VersionConflict struct {
+	Platform string
+	Name     string
+
+	// Versions are the distinct versions in breadth-first order
+	Versions []string
+}
+
+// VersionConflictError is returned by Flatten with ReportConflicts
+type VersionConflictError struct {
+	Conflicts []*VersionConflict
+}
+
+// Error lists the conflicting packages
+func (e *VersionConflictError) Error() string {
+	var conflicts []string
+	for _, c := range e.Conflicts {
+		conflicts = append(conflicts, fmt.Sprintf("%v/%v (%v)", c.Platform, c.Name, strings.Join(c.Versions, ", ")))
+	}
+	return "version conflicts: " + strings.Join(conflicts, "; ")
+}
+
+// Flatten returns every package of the tree, excluding the root, once with
+// the version selected by strategy, similar to how package managers install
+// a tree. The refs are sorted by platform and name.
+func Flatten(tree *DependencyNode, strategy FlattenStrategy) ([]ProjectRef, error) {
+	selected := make(map[string]*ProjectRef)
+	versions := make(map[string][]string)
+	var keys []string
+
+	// Visit the tree breadth-first, so the first occurrence of a
+	// package is the one closest to the root
+	queue := append([]*DependencyNode(nil), tree.Dependencies...)
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = append(queue[1:], node.Dependencies...)
+
+		key := strings.ToLower(node.Platform) + "/" + normalizeName(node.Platform, node.Name)
+		ref, ok := selected[key]
+		if !ok {
+			ref = &ProjectRef{Platform: node.Platform, Name: node.Name, Version: node.Version}
+			selected[key] = ref
+			keys = append(keys, key)
+		}
+		if !containsString(versions[key], node.Version) {
+			versions[key] = append(versions[key], node.Version)
+		}
+
+		if strategy == HighestWins && compareVersionNumbers(node.Version, ref.Version) > 0 {
+			ref.Version = node.Version
+		}
+	}
+
+	sort.Strings(keys)
+
+	if strategy == ReportConflicts {
+		conflictErr := new(VersionConflictError)
+		for _, key := range keys {
+			if len(versions[key]) > 1 {
+				ref := selected[key]
+				conflictErr.Conflicts = append(conflictErr.Conflicts, &VersionConflict{
+					Platform: ref.Platform,
+					Name:     ref.Name,
+					Versions: versions[key],
+				})
+			}
+		}
+		if len(conflictErr.Conflicts) > 0 {
+			return nil, conflictErr
+		}
+	}
+
+	refs := make([]ProjectRef, 0, len(keys))
+	for _, key := range keys {
+		refs = append(refs, *selected[key])
+	}
+	return refs, nil
+}
+
+// compareVersionNumbers compares two version numbers segment by segment,
+// numerically where both segments are numbers. A release is higher than
+// a prerelease of the same version, e.g. 1.0.0 > 1.0.0-rc1.
+func compareVersionNumbers(a, b string) int {
+	split := func(v string) (release []string, pre string) {
+		v = strings.TrimPrefix(strings.TrimPrefix(v, "v"), "V")
+		if i := strings.IndexAny(v, "-+"); i >= 0 {
+			v, pre = v[:i], v[i+1:]
+		}
+		return strings.Split(v, "."), pre
+	}
+
+	as, apre := split(a)
+	bs, bpre := split(b)
+
+	if c := compareSegmentLists(as, bs); c != 0 {
+		return c
+	}
+
+	switch {
+	case apre == bpre:
+		return 0
+	case apre == "":
+		return 1
+	case bpre == "":
+		return -1
+	}
+	return compareSegmentLists(strings.Split(apre, "."), strings.Split(bpre, "."))
+}
+
+func compareSegmentLists(as, bs []string) int {
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y string
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+		if c := compareSegments(x, y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func compareSegments(x, y string) int {
+	xn, xerr := strconv.Atoi(x)
+	yn, yerr := strconv.Atoi(y)
+	switch {
+	case x == y:
+		return 0
+	case xerr == nil && yerr == nil:
+		if xn < yn {
+			return -1
+		} else if xn > yn {
+			return 1
+		}
+		return 0
+	case x == "":
+		return -1
+	case y == "":
+		return 1
+	}
+	return strings.Compare(x, y)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
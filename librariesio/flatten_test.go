@@ -0,0 +1,94 @@
+package librariesio
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hackebrot/go-repr/repr"
+)
+
+// flattenTree has c twice, 1.0.3 nearest to the root and 1.10.0 deeper
+var flattenTree = &DependencyNode{
+	Platform: "npm",
+	Name:     "app",
+	Version:  "1.0.0",
+	Dependencies: []*DependencyNode{
+		{Platform: "npm", Name: "a", Version: "1.2.0", Dependencies: []*DependencyNode{
+			{Platform: "npm", Name: "C", Version: "1.10.0"},
+		}},
+		{Platform: "npm", Name: "c", Version: "1.0.3"},
+		{Platform: "npm", Name: "b", Version: "2.0.0", Dependencies: []*DependencyNode{
+			{Platform: "npm", Name: "a", Version: "1.2.0"},
+		}},
+	},
+}
+
+func TestFlatten(t *testing.T) {
+	testCases := []struct {
+		name     string
+		strategy FlattenStrategy
+		c        string
+	}{
+		{"highest wins", HighestWins, "1.10.0"},
+		{"first wins", FirstWins, "1.0.3"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			refs, err := Flatten(flattenTree, testCase.strategy)
+			if err != nil {
+				t.Fatalf("Flatten returned unexpected error: %v", err)
+			}
+
+			want := []ProjectRef{
+				{Platform: "npm", Name: "a", Version: "1.2.0"},
+				{Platform: "npm", Name: "b", Version: "2.0.0"},
+				{Platform: "npm", Name: "c", Version: testCase.c},
+			}
+			if !reflect.DeepEqual(refs, want) {
+				t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(refs))
+			}
+		})
+	}
+}
+
+func TestFlatten_reportConflicts(t *testing.T) {
+	_, err := Flatten(flattenTree, ReportConflicts)
+
+	var conflictErr *VersionConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatalf("expected *VersionConflictError, got %v", err)
+	}
+
+	want := []*VersionConflict{
+		{Platform: "npm", Name: "c", Versions: []string{"1.0.3", "1.10.0"}},
+	}
+	if !reflect.DeepEqual(conflictErr.Conflicts, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(conflictErr.Conflicts))
+	}
+
+	refs, err := Flatten(flattenTree.Dependencies[2], ReportConflicts)
+	if err != nil || len(refs) != 1 {
+		t.Errorf("expected a single dependency without conflicts, got %v, %v", refs, err)
+	}
+}
+
+func TestCompareVersionNumbers(t *testing.T) {
+	testCases := []struct {
+		a, b string
+		want int
+	}{
+		{"1.10.0", "1.9.0", 1},
+		{"1.0", "1.0.0", -1},
+		{"v2.0.0", "2.0.0", 0},
+		{"1.0.0", "1.0.0-rc1", 1},
+		{"1.0.0-beta.10", "1.0.0-beta.9", 1},
+	}
+
+	for _, testCase := range testCases {
+		if got := compareVersionNumbers(testCase.a, testCase.b); got != testCase.want {
+			t.Errorf("compareVersionNumbers(%q, %q) is %d, want %d", testCase.a, testCase.b, got, testCase.want)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+//go:build ignore
+
+// gen_endpoints generates endpoints_gen.go from openapi.json,
+// run it with go generate.
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/hackebrot/go-librariesio/librariesio/internal/openapi"
+)
+
+func main() {
+	spec, err := os.ReadFile("openapi.json")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := openapi.Generate(spec)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile("endpoints_gen.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
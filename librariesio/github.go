@@ -3,7 +3,8 @@ package librariesio
 import (
 	"context"
 	"fmt"
-	"net/http"
+	"net/url"
+	"strings"
 	"time"
 )
 
@@ -29,7 +30,17 @@ type User struct {
 	GitHubID     *int       `json:"github_id,omitempty"`
 }
 
-// Repository represents a GitHub project
+// IsOrganization reports whether the user is an organization,
+// libraries.io spells the user type Organisation
+func (u *User) IsOrganization() bool {
+	switch strings.ToLower(stringValue(u.UserType)) {
+	case "organisation", "organization":
+		return true
+	}
+	return false
+}
+
+// Repository represents a source repository on GitHub, GitLab or Bitbucket
 type Repository struct {
 	ContributionsCount       *int       `json:"contributions_count,omitempty"`
 	CreatedAt                *time.Time `json:"created_at,omitempty"`
@@ -74,6 +85,37 @@ type Repository struct {
 	SubscribersCount         *int       `json:"subscribers_count,omitempty"`
 	UUID                     *string    `json:"uuid,omitempty"`
 	UpdatedAt                *time.Time `json:"updated_at,omitempty"`
+
+	// Dependencies are only set by RepositoryDependencies
+	Dependencies []*RepositoryDependency `json:"dependencies,omitempty"`
+}
+
+// RepositoryDependency represents a dependency declared in a manifest
+// of a repository
+type RepositoryDependency struct {
+	Deprecated         *bool    `json:"deprecated,omitempty"`
+	Filepath           *string  `json:"filepath,omitempty"`
+	Kind               *string  `json:"kind,omitempty"`
+	Latest             *string  `json:"latest,omitempty"`
+	LatestStable       *string  `json:"latest_stable,omitempty"`
+	Name               *string  `json:"name,omitempty"`
+	NormalizedLicenses []string `json:"normalized_licenses,omitempty"`
+	Optional           *bool    `json:"optional,omitempty"`
+	Outdated           *bool    `json:"outdated,omitempty"`
+	Platform           *string  `json:"platform,omitempty"`
+	ProjectName        *string  `json:"project_name,omitempty"`
+	Requirements       *string  `json:"requirements,omitempty"`
+}
+
+// DependenciesByManifest groups the dependencies of the repository by
+// the path of the manifest declaring them
+func (r *Repository) DependenciesByManifest() map[string][]*RepositoryDependency {
+	manifests := make(map[string][]*RepositoryDependency)
+	for _, dep := range r.Dependencies {
+		path := stringValue(dep.Filepath)
+		manifests[path] = append(manifests[path], dep)
+	}
+	return manifests
 }
 
 // User returns information for a given user or organization
@@ -81,8 +123,8 @@ type Repository struct {
 // GET https://libraries.io/api/github/:login
 //
 // login is a user or organization on GitHub
-func (c *Client) User(ctx context.Context, login string) (*User, *http.Response, error) {
-	urlStr := fmt.Sprintf("github/%v", login)
+func (c *Client) User(ctx context.Context, login string) (*User, *Response, error) {
+	urlStr := fmt.Sprintf("github/%v", url.PathEscape(login))
 
 	request, err := c.NewRequest("GET", urlStr, nil)
 
@@ -96,20 +138,22 @@ func (c *Client) User(ctx context.Context, login string) (*User, *http.Response,
 	if err != nil {
 		return nil, response, err
 	}
+	if response.unchanged() {
+		return nil, response, nil
+	}
 
 	return user, response, nil
 }
 
-// UserProjects returns projects referencing the given GitHub user
+// UserProjects returns a page of the projects referencing the given
+// GitHub user
 //
 // GET https://libraries.io/api/github/:login/projects
 //
 // login is a user or organization on GitHub
-func (c *Client) UserProjects(ctx context.Context, login string) ([]*Project, *http.Response, error) {
-	urlStr := fmt.Sprintf("github/%v/projects", login)
-
-	request, err := c.NewRequest("GET", urlStr, nil)
-
+// opts selects the page, it may be nil for the first page
+func (c *Client) UserProjects(ctx context.Context, login string, opts *ListOptions) ([]*Project, *Response, error) {
+	request, err := c.newListRequest(fmt.Sprintf("github/%v/projects", url.PathEscape(login)), opts)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -124,19 +168,65 @@ func (c *Client) UserProjects(ctx context.Context, login string) ([]*Project, *h
 	return projects, response, nil
 }
 
-// UserRepositories returns repositories owned by the given GitHub user
+// UserRepositories returns a page of the repositories owned by the
+// given GitHub user
 //
 // GET https://libraries.io/api/github/:login/repositories
 //
 // login is a user or organization on GitHub
-func (c *Client) UserRepositories(ctx context.Context, login string) ([]*Repository, *http.Response, error) {
-	urlStr := fmt.Sprintf("github/%v/repositories", login)
+// opts selects the page, it may be nil for the first page
+func (c *Client) UserRepositories(ctx context.Context, login string, opts *ListOptions) ([]*Repository, *Response, error) {
+	request, err := c.newListRequest(fmt.Sprintf("github/%v/repositories", url.PathEscape(login)), opts)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	request, err := c.NewRequest("GET", urlStr, nil)
+	var repos []*Repository
 
+	response, err := c.Do(ctx, request, &repos)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return repos, response, nil
+}
+
+// UserProjectContributions returns a page of the projects the given
+// GitHub user contributed to
+//
+// GET https://libraries.io/api/github/:login/project-contributions
+//
+// login is a user or organization on GitHub
+// opts selects the page, it may be nil for the first page
+func (c *Client) UserProjectContributions(ctx context.Context, login string, opts *ListOptions) ([]*Project, *Response, error) {
+	request, err := c.newListRequest(fmt.Sprintf("github/%v/project-contributions", url.PathEscape(login)), opts)
 	if err != nil {
 		return nil, nil, err
 	}
+
+	var projects []*Project
+
+	response, err := c.Do(ctx, request, &projects)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return projects, response, nil
+}
+
+// UserRepositoryContributions returns a page of the repositories the
+// given GitHub user contributed to
+//
+// GET https://libraries.io/api/github/:login/repository-contributions
+//
+// login is a user or organization on GitHub
+// opts selects the page, it may be nil for the first page
+func (c *Client) UserRepositoryContributions(ctx context.Context, login string, opts *ListOptions) ([]*Repository, *Response, error) {
+	request, err := c.newListRequest(fmt.Sprintf("github/%v/repository-contributions", url.PathEscape(login)), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var repos []*Repository
 
 	response, err := c.Do(ctx, request, &repos)
@@ -146,3 +236,102 @@ func (c *Client) UserRepositories(ctx context.Context, login string) ([]*Reposit
 
 	return repos, response, nil
 }
+
+// Repository returns information for the given GitHub repository
+//
+// GET https://libraries.io/api/github/:owner/:name
+//
+// owner is the user or organization owning the repository
+// name is the name of the repository
+func (c *Client) Repository(ctx context.Context, owner, name string) (*Repository, *Response, error) {
+	return c.repository(ctx, "GitHub", owner, name)
+}
+
+// RepositoryDependencies returns the given GitHub repository together
+// with the dependencies declared in all of its manifests
+//
+// GET https://libraries.io/api/github/:owner/:name/dependencies
+//
+// owner is the user or organization owning the repository
+// name is the name of the repository
+func (c *Client) RepositoryDependencies(ctx context.Context, owner, name string) (*Repository, *Response, error) {
+	urlStr := fmt.Sprintf("github/%v/%v/dependencies", url.PathEscape(owner), url.PathEscape(name))
+
+	request, err := c.NewRequest("GET", urlStr, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	repo := new(Repository)
+
+	response, err := c.Do(ctx, request, repo)
+	if err != nil {
+		return nil, response, err
+	}
+	if response.unchanged() {
+		return nil, response, nil
+	}
+
+	return repo, response, nil
+}
+
+// RepositoryProjects returns the projects published from the given
+// GitHub repository
+//
+// GET https://libraries.io/api/github/:owner/:name/projects
+//
+// owner is the user or organization owning the repository
+// name is the name of the repository
+func (c *Client) RepositoryProjects(ctx context.Context, owner, name string) ([]*Project, *Response, error) {
+	return c.repositoryProjects(ctx, "GitHub", owner, name)
+}
+
+// repository returns the repository with the given owner and name on the
+// given host type, e.g. GitHub or GitLab
+//
+// GET https://libraries.io/api/:host/:owner/:name
+func (c *Client) repository(ctx context.Context, host, owner, name string) (*Repository, *Response, error) {
+	urlStr := fmt.Sprintf("%v/%v/%v", strings.ToLower(host), url.PathEscape(owner), url.PathEscape(name))
+
+	request, err := c.NewRequest("GET", urlStr, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	repo := new(Repository)
+
+	response, err := c.Do(ctx, request, repo)
+	if err != nil {
+		return nil, response, err
+	}
+	if response.unchanged() {
+		return nil, response, nil
+	}
+
+	return repo, response, nil
+}
+
+// RepositoryForProject returns the source repository of the given project.
+// The repository is looked up from the first of the project's RepositoryURL,
+// PackageManagerURL and Homepage that is a GitHub, GitLab or Bitbucket URL.
+func (c *Client) RepositoryForProject(ctx context.Context, project *Project) (*Repository, *Response, error) {
+	if host, owner, name, ok := projectRepository(project); ok {
+		return c.repository(ctx, host, owner, name)
+	}
+
+	return nil, nil, fmt.Errorf("project %v/%v has no known repository URL",
+		stringValue(project.Platform), stringValue(project.Name))
+}
+
+// projectRepository returns the repository of the first of the project's
+// RepositoryURL, PackageManagerURL and Homepage that is a GitHub, GitLab
+// or Bitbucket URL
+func projectRepository(project *Project) (host, owner, name string, ok bool) {
+	for _, u := range []*string{project.RepositoryURL, project.PackageManagerURL, project.Homepage} {
+		host, owner, name, err := ParseRepoURL(stringValue(u))
+		if err == nil {
+			return host, owner, name, true
+		}
+	}
+	return "", "", "", false
+}
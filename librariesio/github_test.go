@@ -50,6 +50,15 @@ func TestUser(t *testing.T) {
 	}
 }
 
+func TestUserIsOrganization(t *testing.T) {
+	for userType, want := range map[string]bool{"Organisation": true, "organization": true, "User": false, "": false} {
+		u := &User{UserType: String(userType)}
+		if got := u.IsOrganization(); got != want {
+			t.Errorf("IsOrganization() for %q returned %v, want %v", userType, got, want)
+		}
+	}
+}
+
 func TestUserProjects(t *testing.T) {
 	server, mux, url := startNewServer()
 	client := NewClient(APIKey)
@@ -64,6 +73,9 @@ func TestUserProjects(t *testing.T) {
 		if url := r.URL.String(); !strings.Contains(url, "/github/hackebrot/projects") {
 			t.Errorf("unexpected URL, got %v", url)
 		}
+		if got := r.URL.Query().Get("per_page"); got != "100" {
+			t.Errorf("per_page is %q, want 100", got)
+		}
 
 		fmt.Fprintf(w, `[
 			{
@@ -79,7 +91,7 @@ func TestUserProjects(t *testing.T) {
 		]`)
 	})
 
-	projects, _, err := client.UserProjects(context.Background(), "hackebrot")
+	projects, _, err := client.UserProjects(context.Background(), "hackebrot", &ListOptions{PerPage: MaxPerPage})
 
 	if err != nil {
 		t.Fatalf("UserProjects returned unexpected error: %v", err)
@@ -125,6 +137,9 @@ func TestUserRepositories(t *testing.T) {
 		if url := r.URL.String(); !strings.Contains(url, "/github/hackebrot/repositories") {
 			t.Errorf("unexpected URL, got %v", url)
 		}
+		if q := r.URL.Query(); q.Get("page") != "2" || q.Get("per_page") != "10" {
+			t.Errorf("unexpected page %v", q)
+		}
 
 		fmt.Fprintf(w, `[
 			{
@@ -144,7 +159,7 @@ func TestUserRepositories(t *testing.T) {
 		]`)
 	})
 
-	repos, _, err := client.UserRepositories(context.Background(), "hackebrot")
+	repos, _, err := client.UserRepositories(context.Background(), "hackebrot", &ListOptions{Page: 2, PerPage: 10})
 
 	if err != nil {
 		t.Fatalf("UserRepositories returned unexpected error: %v", err)
@@ -175,3 +190,286 @@ func TestUserRepositories(t *testing.T) {
 		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(repos))
 	}
 }
+
+func TestUserProjectContributions(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/github/hackebrot/project-contributions", func(w http.ResponseWriter, r *http.Request) {
+		if method := "GET"; method != r.Method {
+			t.Errorf("expected HTTP %v request, got %v", method, r.Method)
+		}
+		if got := r.URL.Query().Get("page"); got != "3" {
+			t.Errorf("page is %q, want 3", got)
+		}
+		fmt.Fprint(w, `[{"name":"cookiecutter","platform":"Pypi"},{"name":"pytest","platform":"Pypi"}]`)
+	})
+
+	projects, _, err := client.UserProjectContributions(context.Background(), "hackebrot", &ListOptions{Page: 3})
+	if err != nil {
+		t.Fatalf("UserProjectContributions returned unexpected error: %v", err)
+	}
+
+	want := []*Project{
+		{Name: String("cookiecutter"), Platform: String("Pypi")},
+		{Name: String("pytest"), Platform: String("Pypi")},
+	}
+	if !reflect.DeepEqual(projects, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(projects))
+	}
+}
+
+func TestUserRepositoryContributions(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/github/hackebrot/repository-contributions", func(w http.ResponseWriter, r *http.Request) {
+		if method := "GET"; method != r.Method {
+			t.Errorf("expected HTTP %v request, got %v", method, r.Method)
+		}
+		fmt.Fprint(w, `[{"full_name":"pytest-dev/pytest","language":"Python"}]`)
+	})
+
+	repos, _, err := client.UserRepositoryContributions(context.Background(), "hackebrot", nil)
+	if err != nil {
+		t.Fatalf("UserRepositoryContributions returned unexpected error: %v", err)
+	}
+
+	want := []*Repository{{FullName: String("pytest-dev/pytest"), Language: String("Python")}}
+	if !reflect.DeepEqual(repos, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(repos))
+	}
+}
+
+func TestRepository(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/github/hackebrot/go-librariesio", func(w http.ResponseWriter, r *http.Request) {
+		if method := "GET"; method != r.Method {
+			t.Errorf("expected HTTP %v request, got %v", method, r.Method)
+		}
+
+		fmt.Fprintf(w, `{
+			"full_name": "hackebrot/go-librariesio",
+			"host_type": "GitHub",
+			"stargazers_count": 20,
+			"forks_count": 4,
+			"license": "MIT",
+			"status": null,
+			"pushed_at": "2017-04-02T18:08:51.000Z"
+		}`)
+	})
+
+	repo, _, err := client.Repository(context.Background(), "hackebrot", "go-librariesio")
+	if err != nil {
+		t.Fatalf("Repository returned unexpected error: %v", err)
+	}
+
+	want := &Repository{
+		FullName:        String("hackebrot/go-librariesio"),
+		HostType:        String("GitHub"),
+		StargazersCount: Int(20),
+		ForksCount:      Int(4),
+		License:         String("MIT"),
+		PushedAt:        Time(time.Date(2017, time.April, 2, 18, 8, 51, 0, time.UTC)),
+	}
+	if !reflect.DeepEqual(repo, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(repo))
+	}
+}
+
+func TestRepository_escaped(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.EscapedPath(), "/github/hack%23ebrot/go%3Flibrariesio"; got != want {
+			t.Errorf("requested %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{}`)
+	})
+
+	if _, _, err := client.Repository(context.Background(), "hack#ebrot", "go?librariesio"); err != nil {
+		t.Fatalf("Repository returned unexpected error: %v", err)
+	}
+}
+
+func TestRepositoryDependencies(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/github/hackebrot/go-librariesio/dependencies", func(w http.ResponseWriter, r *http.Request) {
+		if method := "GET"; method != r.Method {
+			t.Errorf("expected HTTP %v request, got %v", method, r.Method)
+		}
+
+		fmt.Fprintf(w, `{
+			"full_name": "hackebrot/go-librariesio",
+			"dependencies": [
+				{
+					"project_name": "github.com/hackebrot/go-repr",
+					"name": "github.com/hackebrot/go-repr",
+					"platform": "Go",
+					"requirements": "*",
+					"outdated": false,
+					"filepath": "Gopkg.lock",
+					"kind": "runtime",
+					"normalized_licenses": ["MIT"]
+				},
+				{
+					"name": "pytest",
+					"platform": "Pypi",
+					"requirements": ">=3.0",
+					"filepath": "docs/requirements.txt",
+					"kind": "development"
+				}
+			]
+		}`)
+	})
+
+	repo, _, err := client.RepositoryDependencies(context.Background(), "hackebrot", "go-librariesio")
+	if err != nil {
+		t.Fatalf("RepositoryDependencies returned unexpected error: %v", err)
+	}
+
+	want := &Repository{
+		FullName: String("hackebrot/go-librariesio"),
+		Dependencies: []*RepositoryDependency{
+			{
+				ProjectName:        String("github.com/hackebrot/go-repr"),
+				Name:               String("github.com/hackebrot/go-repr"),
+				Platform:           String("Go"),
+				Requirements:       String("*"),
+				Outdated:           Bool(false),
+				Filepath:           String("Gopkg.lock"),
+				Kind:               String("runtime"),
+				NormalizedLicenses: []string{"MIT"},
+			},
+			{
+				Name:         String("pytest"),
+				Platform:     String("Pypi"),
+				Requirements: String(">=3.0"),
+				Filepath:     String("docs/requirements.txt"),
+				Kind:         String("development"),
+			},
+		},
+	}
+	if !reflect.DeepEqual(repo, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(repo))
+	}
+
+	manifests := repo.DependenciesByManifest()
+	if len(manifests) != 2 || len(manifests["Gopkg.lock"]) != 1 || stringValue(manifests["docs/requirements.txt"][0].Name) != "pytest" {
+		t.Errorf("unexpected manifests %v", repr.Repr(manifests))
+	}
+}
+
+func TestRepositoryDependencies_escaped(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.EscapedPath(), "/github/hack%23ebrot/go%3Flibrariesio/dependencies"; got != want {
+			t.Errorf("requested %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{}`)
+	})
+
+	if _, _, err := client.RepositoryDependencies(context.Background(), "hack#ebrot", "go?librariesio"); err != nil {
+		t.Fatalf("RepositoryDependencies returned unexpected error: %v", err)
+	}
+}
+
+func TestRepositoryProjects(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/github/babel/babel/projects", func(w http.ResponseWriter, r *http.Request) {
+		if method := "GET"; method != r.Method {
+			t.Errorf("expected HTTP %v request, got %v", method, r.Method)
+		}
+		fmt.Fprint(w, `[{"name":"@babel/core","platform":"NPM"},{"name":"@babel/parser","platform":"NPM"}]`)
+	})
+
+	projects, _, err := client.RepositoryProjects(context.Background(), "babel", "babel")
+	if err != nil {
+		t.Fatalf("RepositoryProjects returned unexpected error: %v", err)
+	}
+
+	want := []*Project{
+		{Name: String("@babel/core"), Platform: String("NPM")},
+		{Name: String("@babel/parser"), Platform: String("NPM")},
+	}
+	if !reflect.DeepEqual(projects, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(projects))
+	}
+}
+
+func TestRepositoryProjects_escaped(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.EscapedPath(), "/github/hack%23ebrot/go%3Flibrariesio/projects"; got != want {
+			t.Errorf("requested %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `[]`)
+	})
+
+	if _, _, err := client.RepositoryProjects(context.Background(), "hack#ebrot", "go?librariesio"); err != nil {
+		t.Fatalf("RepositoryProjects returned unexpected error: %v", err)
+	}
+}
+
+func TestRepositoryForProject(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/gitlab/gitlab-org/gitlab-runner", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"full_name": "gitlab-org/gitlab-runner", "host_type": "GitLab"}`)
+	})
+
+	project := &Project{
+		Name:              String("gitlab-runner"),
+		PackageManagerURL: String("https://pkg.go.dev/gitlab.com/gitlab-org/gitlab-runner"),
+		Homepage:          String("https://gitlab.com/gitlab-org/gitlab-runner.git"),
+	}
+
+	repo, _, err := client.RepositoryForProject(context.Background(), project)
+	if err != nil {
+		t.Fatalf("RepositoryForProject returned unexpected error: %v", err)
+	}
+
+	want := &Repository{FullName: String("gitlab-org/gitlab-runner"), HostType: String("GitLab")}
+	if !reflect.DeepEqual(repo, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(repo))
+	}
+}
+
+func TestRepositoryForProject_noRepository(t *testing.T) {
+	client := NewClient(APIKey)
+
+	project := &Project{Platform: String("NPM"), Name: String("left-pad"), Homepage: String("https://example.com")}
+	if _, _, err := client.RepositoryForProject(context.Background(), project); err == nil {
+		t.Error("Expected error for project without repository URL")
+	}
+}
@@ -0,0 +1,138 @@
+package librariesio
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// GraphExporter writes a resolved dependency tree as a graph
+// for loading into graph tooling
+type GraphExporter interface {
+	ExportGraph(w io.Writer, root *DependencyNode) error
+}
+
+// GraphEdge is a dependency of one project version on another
+type GraphEdge struct {
+	From, To     ProjectRef
+	Requirements string
+	Scope        DependencyScope
+}
+
+// Graph returns the distinct project versions of the tree and the edges
+// between them, both in the depth-first order of Walk. Versions that
+// occur several times in the tree are one node of the graph.
+func (n *DependencyNode) Graph() (nodes []ProjectRef, edges []GraphEdge) {
+	seenNodes := make(map[ProjectRef]bool)
+	seenEdges := make(map[GraphEdge]bool)
+
+	n.Walk(func(node *DependencyNode, path []*DependencyNode) bool {
+		ref := node.Ref()
+		if !seenNodes[ref] {
+			seenNodes[ref] = true
+			nodes = append(nodes, ref)
+		}
+
+		if len(path) > 0 {
+			edge := GraphEdge{From: path[len(path)-1].Ref(), To: ref, Requirements: node.Requirements}
+			if node.Dependency != nil {
+				edge.Scope = node.Dependency.Scope()
+			}
+			if !seenEdges[edge] {
+				seenEdges[edge] = true
+				edges = append(edges, edge)
+			}
+		}
+		return true
+	})
+	return nodes, edges
+}
+
+// CypherExporter writes a graph as Cypher statements for Neo4j, one per
+// line, that merge a node per project version and a relationship per
+// dependency, so loading the same graph twice does not duplicate it
+type CypherExporter struct {
+	// Label of the nodes, it defaults to Package
+	Label string
+
+	// RelationshipType of the dependencies, it defaults to DEPENDS_ON
+	RelationshipType string
+}
+
+// ExportGraph writes the statements for the tree to w
+func (e CypherExporter) ExportGraph(w io.Writer, root *DependencyNode) error {
+	label, relType := e.Label, e.RelationshipType
+	if label == "" {
+		label = "Package"
+	}
+	if relType == "" {
+		relType = "DEPENDS_ON"
+	}
+
+	props := func(ref ProjectRef) string {
+		return fmt.Sprintf("{platform: %v, name: %v, version: %v}",
+			strconv.Quote(ref.Platform), strconv.Quote(ref.Name), strconv.Quote(ref.Version))
+	}
+	label, relType = cypherName(label), cypherName(relType)
+
+	buf := bufio.NewWriter(w)
+	nodes, edges := root.Graph()
+	for _, ref := range nodes {
+		fmt.Fprintf(buf, "MERGE (:%v %v);\n", label, props(ref))
+	}
+	for _, edge := range edges {
+		fmt.Fprintf(buf, "MATCH (a:%v %v), (b:%v %v) MERGE (a)-[:%v {requirements: %v, scope: %v}]->(b);\n",
+			label, props(edge.From), label, props(edge.To),
+			relType, strconv.Quote(edge.Requirements), strconv.Quote(string(edge.Scope)))
+	}
+	return buf.Flush()
+}
+
+// cypherName quotes a label or relationship type with backticks
+func cypherName(name string) string {
+	escaped := make([]rune, 0, len(name)+2)
+	escaped = append(escaped, '`')
+	for _, r := range name {
+		if r == '`' {
+			escaped = append(escaped, '`')
+		}
+		escaped = append(escaped, r)
+	}
+	return string(append(escaped, '`'))
+}
+
+// CSVEdgesExporter writes the edges of a graph as CSV with a header,
+// the format most graph tools import
+type CSVEdgesExporter struct{}
+
+// csvEdgesHeader is the header written by CSVEdgesExporter
+var csvEdgesHeader = []string{
+	"from_platform", "from_name", "from_version",
+	"to_platform", "to_name", "to_version",
+	"requirements", "scope",
+}
+
+// ExportGraph writes the edges of the tree to w
+func (CSVEdgesExporter) ExportGraph(w io.Writer, root *DependencyNode) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvEdgesHeader); err != nil {
+		return err
+	}
+
+	_, edges := root.Graph()
+	for _, edge := range edges {
+		record := []string{
+			edge.From.Platform, edge.From.Name, edge.From.Version,
+			edge.To.Platform, edge.To.Name, edge.To.Version,
+			edge.Requirements, string(edge.Scope),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,95 @@
+package librariesio
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// graphTree returns app -> (lib@1.0.0 -> util@2.0.0, util@2.0.0)
+func graphTree() *DependencyNode {
+	util := func() *DependencyNode {
+		return &DependencyNode{Platform: "npm", Name: "util", Version: "2.0.0", Requirements: "^2.0.0",
+			Dependency: &ProjectDependency{Kind: String("runtime")}}
+	}
+	return &DependencyNode{
+		Platform: "npm", Name: "app", Version: "1.0.0",
+		Dependencies: []*DependencyNode{
+			{
+				Platform: "npm", Name: "lib", Version: "1.0.0", Requirements: `~1.0 "quoted"`,
+				Dependency:   &ProjectDependency{Kind: String("Development")},
+				Dependencies: []*DependencyNode{util()},
+			},
+			util(),
+		},
+	}
+}
+
+func TestDependencyNodeGraph(t *testing.T) {
+	nodes, edges := graphTree().Graph()
+
+	app := ProjectRef{Platform: "npm", Name: "app", Version: "1.0.0"}
+	lib := ProjectRef{Platform: "npm", Name: "lib", Version: "1.0.0"}
+	util := ProjectRef{Platform: "npm", Name: "util", Version: "2.0.0"}
+
+	if want := []ProjectRef{app, lib, util}; !reflect.DeepEqual(nodes, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, nodes)
+	}
+
+	want := []GraphEdge{
+		{From: app, To: lib, Requirements: `~1.0 "quoted"`, Scope: ScopeDevelopment},
+		{From: lib, To: util, Requirements: "^2.0.0", Scope: ScopeRuntime},
+		{From: app, To: util, Requirements: "^2.0.0", Scope: ScopeRuntime},
+	}
+	if !reflect.DeepEqual(edges, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, edges)
+	}
+}
+
+func TestCypherExporter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (CypherExporter{}).ExportGraph(&buf, graphTree()); err != nil {
+		t.Fatalf("ExportGraph returned unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("expected 3 nodes and 3 edges, got %d lines:\n%v", len(lines), buf.String())
+	}
+	if want := `MERGE (:` + "`Package`" + ` {platform: "npm", name: "app", version: "1.0.0"});`; lines[0] != want {
+		t.Errorf("\nExpected %v\nGot %v", want, lines[0])
+	}
+	want := `MATCH (a:` + "`Package`" + ` {platform: "npm", name: "app", version: "1.0.0"}), (b:` + "`Package`" +
+		` {platform: "npm", name: "lib", version: "1.0.0"}) MERGE (a)-[:` + "`DEPENDS_ON`" +
+		` {requirements: "~1.0 \"quoted\"", scope: "development"}]->(b);`
+	if lines[3] != want {
+		t.Errorf("\nExpected %v\nGot %v", want, lines[3])
+	}
+
+	buf.Reset()
+	if err := (CypherExporter{Label: "Lib`rary", RelationshipType: "USES"}).ExportGraph(&buf, graphTree()); err != nil {
+		t.Fatalf("ExportGraph returned unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "MERGE (:`Lib``rary` {") || !strings.Contains(buf.String(), "-[:`USES` {") {
+		t.Errorf("expected custom label and relationship type, got\n%v", buf.String())
+	}
+}
+
+func TestCSVEdgesExporter(t *testing.T) {
+	var exporter GraphExporter = CSVEdgesExporter{}
+
+	var buf bytes.Buffer
+	if err := exporter.ExportGraph(&buf, graphTree()); err != nil {
+		t.Fatalf("ExportGraph returned unexpected error: %v", err)
+	}
+
+	want := `from_platform,from_name,from_version,to_platform,to_name,to_version,requirements,scope
+npm,app,1.0.0,npm,lib,1.0.0,"~1.0 ""quoted""",development
+npm,lib,1.0.0,npm,util,2.0.0,^2.0.0,runtime
+npm,app,1.0.0,npm,util,2.0.0,^2.0.0,runtime
+`
+	if got := buf.String(); got != want {
+		t.Errorf("\nExpected %v\nGot %v", want, got)
+	}
+}
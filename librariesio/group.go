@@ -0,0 +1,160 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Group runs related lookups concurrently and collects their results.
+// All lookups are sent through the client, so they share its rate limit
+// and scheduler. A Group must not be reused after Wait.
+type Group struct {
+	c   *Client
+	ctx context.Context
+	wg  sync.WaitGroup
+
+	mu      sync.Mutex
+	results *GroupResults
+	refs    []ProjectRef
+	seen    map[ProjectRef]bool
+	errs    map[ProjectRef]error
+}
+
+// GroupResults holds the results of the lookups of a Group,
+// keyed by lookup
+type GroupResults struct {
+	projects    map[string]*Project
+	deps        map[string]*Project
+	sourceRanks map[string]*SourceRank
+	users       map[string]*User
+}
+
+// Group returns a new group of lookups sent with ctx
+func (c *Client) Group(ctx context.Context) *Group {
+	return &Group{
+		c:    c,
+		ctx:  ctx,
+		seen: make(map[ProjectRef]bool),
+		errs: make(map[ProjectRef]error),
+		results: &GroupResults{
+			projects:    make(map[string]*Project),
+			deps:        make(map[string]*Project),
+			sourceRanks: make(map[string]*SourceRank),
+			users:       make(map[string]*User),
+		},
+	}
+}
+
+// Project looks up the project, see Client.Project
+func (g *Group) Project(plat, name string) {
+	g.run(ProjectRef{Platform: plat, Name: name}, func() error {
+		project, _, err := g.c.Project(g.ctx, plat, name)
+		if err != nil {
+			return fmt.Errorf("project %v: %w", ProjectRef{Platform: plat, Name: name}, err)
+		}
+		g.store(func(r *GroupResults) { r.projects[groupKey(plat, name)] = project })
+		return nil
+	})
+}
+
+// ProjectDeps looks up the dependencies of the project, see Client.ProjectDeps
+func (g *Group) ProjectDeps(plat, name, ver string) {
+	g.run(ProjectRef{Platform: plat, Name: name}, func() error {
+		project, _, err := g.c.ProjectDeps(g.ctx, plat, name, ver)
+		if err != nil {
+			return fmt.Errorf("dependencies %v: %w", ProjectRef{Platform: plat, Name: name, Version: ver}, err)
+		}
+		g.store(func(r *GroupResults) { r.deps[groupKey(plat, name, ver)] = project })
+		return nil
+	})
+}
+
+// SourceRank looks up the SourceRank breakdown of the project
+func (g *Group) SourceRank(plat, name string) {
+	g.run(ProjectRef{Platform: plat, Name: name}, func() error {
+		rank, _, err := g.c.ProjectSourceRank(g.ctx, plat, name)
+		if err != nil {
+			return fmt.Errorf("sourcerank %v: %w", ProjectRef{Platform: plat, Name: name}, err)
+		}
+		g.store(func(r *GroupResults) { r.sourceRanks[groupKey(plat, name)] = rank })
+		return nil
+	})
+}
+
+// User looks up the user, see Client.User. In the BatchError returned
+// by Wait, users are keyed by a ref with the GitHub platform and the
+// login as name.
+func (g *Group) User(login string) {
+	g.run(ProjectRef{Platform: "GitHub", Name: login}, func() error {
+		user, _, err := g.c.User(g.ctx, login)
+		if err != nil {
+			return fmt.Errorf("user %v: %w", login, err)
+		}
+		g.store(func(r *GroupResults) { r.users[groupKey(login)] = user })
+		return nil
+	})
+}
+
+// Wait blocks until all lookups are done and returns their results.
+// Failed lookups have no result, err is then a *BatchError keyed by the
+// project looked up. The errors of several failed lookups of the same
+// project, e.g. Project and SourceRank, are joined.
+func (g *Group) Wait() (*GroupResults, error) {
+	g.wg.Wait()
+	return g.results, batchError(g.refs, g.errs)
+}
+
+// run calls fn in a new goroutine, its error is recorded for ref
+func (g *Group) run(ref ProjectRef, fn func() error) {
+	g.mu.Lock()
+	if !g.seen[ref] {
+		g.seen[ref] = true
+		g.refs = append(g.refs, ref)
+	}
+	g.mu.Unlock()
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if err := fn(); err != nil {
+			g.mu.Lock()
+			if prev, ok := g.errs[ref]; ok {
+				err = errors.Join(prev, err)
+			}
+			g.errs[ref] = err
+			g.mu.Unlock()
+		}
+	}()
+}
+
+func (g *Group) store(fn func(*GroupResults)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	fn(g.results)
+}
+
+// Project returns the result of Group.Project, or nil if it failed
+func (r *GroupResults) Project(plat, name string) *Project {
+	return r.projects[groupKey(plat, name)]
+}
+
+// ProjectDeps returns the result of Group.ProjectDeps, or nil if it failed
+func (r *GroupResults) ProjectDeps(plat, name, ver string) *Project {
+	return r.deps[groupKey(plat, name, ver)]
+}
+
+// SourceRank returns the result of Group.SourceRank, or nil if it failed
+func (r *GroupResults) SourceRank(plat, name string) *SourceRank {
+	return r.sourceRanks[groupKey(plat, name)]
+}
+
+// User returns the result of Group.User, or nil if it failed
+func (r *GroupResults) User(login string) *User {
+	return r.users[groupKey(login)]
+}
+
+func groupKey(parts ...string) string {
+	return fmt.Sprintf("%q", parts)
+}
@@ -0,0 +1,103 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hackebrot/go-repr/repr"
+)
+
+func TestGroup(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/npm/react", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "react"}`)
+	})
+	mux.HandleFunc("/npm/react/sourcerank", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"basic_info_present": 1, "stars": 20}`)
+	})
+	mux.HandleFunc("/npm/react/18.2.0/dependencies", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "react", "dependencies": [{"name": "loose-envify"}]}`)
+	})
+	mux.HandleFunc("/github/facebook", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"login": "facebook"}`)
+	})
+
+	g := client.Group(context.Background())
+	g.Project("npm", "react")
+	g.SourceRank("npm", "react")
+	g.ProjectDeps("npm", "react", "18.2.0")
+	g.User("facebook")
+
+	results, err := g.Wait()
+	if err != nil {
+		t.Fatalf("Wait returned unexpected error: %v", err)
+	}
+
+	if got := results.Project("npm", "react"); got == nil || *got.Name != "react" {
+		t.Errorf("unexpected project %v", repr.Repr(got))
+	}
+	if got := results.ProjectDeps("npm", "react", "18.2.0"); got == nil || len(got.Dependencies) != 1 {
+		t.Errorf("unexpected dependencies %v", repr.Repr(got))
+	}
+	if got := results.User("facebook"); got == nil || *got.Login != "facebook" {
+		t.Errorf("unexpected user %v", repr.Repr(got))
+	}
+
+	want := &SourceRank{BasicInfoPresent: Int(1), Stars: Int(20)}
+	if got := results.SourceRank("npm", "react"); !reflect.DeepEqual(got, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(got))
+	}
+}
+
+func TestGroup_errors(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/npm/react", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "react"}`)
+	})
+	mux.HandleFunc("/npm/nope", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Not Found"}`, http.StatusNotFound)
+	})
+
+	g := client.Group(context.Background())
+	g.Project("npm", "react")
+	g.Project("npm", "nope")
+	g.SourceRank("npm", "nope")
+
+	results, err := g.Wait()
+	if !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("expected ErrProjectNotFound, got %v", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "project npm/nope: ") {
+		t.Errorf("expected the failed lookup in the error, got %v", err)
+	}
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected *BatchError, got %T", err)
+	}
+	nope, react := ProjectRef{Platform: "npm", Name: "nope"}, ProjectRef{Platform: "npm", Name: "react"}
+	if !reflect.DeepEqual(batchErr.Failed(), []ProjectRef{nope}) || !reflect.DeepEqual(batchErr.Succeeded(), []ProjectRef{react}) {
+		t.Errorf("unexpected failed %v and succeeded %v", batchErr.Failed(), batchErr.Succeeded())
+	}
+	if msg := batchErr.Errors[nope].Error(); !strings.Contains(msg, "project npm/nope") || !strings.Contains(msg, "sourcerank npm/nope") {
+		t.Errorf("expected the errors of both lookups, got %v", msg)
+	}
+	if results.Project("npm", "react") == nil {
+		t.Error("expected result of successful lookup")
+	}
+	if results.Project("npm", "nope") != nil {
+		t.Error("expected no result for failed lookup")
+	}
+}
@@ -0,0 +1,131 @@
+package librariesio
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// WithHedging sends a second attempt of interactive GET requests that
+// did not receive a response within after, and uses whichever attempt
+// responds first while cancelling the other. This trades a few extra
+// requests against the rate limit for lower tail latency. The second
+// attempt waits for the limiters like any other request and both
+// attempts are recorded to the audit sink. Requests sent with
+// PriorityBackground are never hedged.
+func WithHedging(after time.Duration) ClientOption {
+	return func(c *Client) {
+		c.hedgeAfter = after
+	}
+}
+
+type hedgeResult struct {
+	resp    *http.Response
+	err     error
+	attempt int
+
+	// start is zero if the attempt was not sent
+	start time.Time
+}
+
+// send sends req, hedging it if enabled for the request
+func (c *Client) send(req *http.Request, cfg *requestConfig) (*http.Response, error) {
+	if c.hedgeAfter <= 0 || req.Method != http.MethodGet || cfg.priority != PriorityInteractive {
+		return c.client.Do(req)
+	}
+
+	results := make(chan hedgeResult, 2)
+	var cancels []context.CancelFunc
+	attempt := func() {
+		ctx, cancel := context.WithCancel(req.Context())
+		n := len(cancels)
+		cancels = append(cancels, cancel)
+		go func() {
+			// The first attempt already waited in DoLazy
+			if n > 0 {
+				if err := c.pace(ctx); err != nil {
+					results <- hedgeResult{err: err, attempt: n}
+					return
+				}
+			}
+			start := time.Now()
+			resp, err := c.client.Do(req.WithContext(ctx))
+			results <- hedgeResult{resp: resp, err: err, attempt: n, start: start}
+		}()
+	}
+
+	attempt()
+	pending := 1
+
+	timer := time.NewTimer(c.hedgeAfter)
+	defer timer.Stop()
+	hedge := timer.C
+
+	for {
+		select {
+		case <-hedge:
+			hedge = nil
+			attempt()
+			pending++
+
+		case result := <-results:
+			pending--
+			if result.err != nil {
+				cancels[result.attempt]()
+				if pending > 0 {
+					// The other attempt may still succeed,
+					// the returned error is audited by DoLazy
+					c.auditHedge(req, result)
+					continue
+				}
+				return nil, result.err
+			}
+
+			for i, cancel := range cancels {
+				if i != result.attempt {
+					cancel()
+				}
+			}
+			if pending > 0 {
+				go c.discardHedge(req, results)
+			}
+
+			// The attempt is cancelled once its body is closed
+			result.resp.Body = &hedgedBody{ReadCloser: result.resp.Body, cancel: cancels[result.attempt]}
+			return result.resp, nil
+		}
+	}
+}
+
+// discardHedge closes the response of the cancelled attempt
+func (c *Client) discardHedge(req *http.Request, results <-chan hedgeResult) {
+	result := <-results
+	if result.resp != nil {
+		result.resp.Body.Close()
+	}
+	c.auditHedge(req, result)
+}
+
+// auditHedge records an attempt whose result is not returned,
+// attempts that were not sent are not recorded
+func (c *Client) auditHedge(req *http.Request, result hedgeResult) {
+	if result.start.IsZero() {
+		return
+	}
+	if result.err != nil {
+		redactURLError(result.err)
+	}
+	c.audit(req, result.resp, result.err, result.start)
+}
+
+type hedgedBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *hedgedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,144 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithHedging(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey, WithHedging(20*time.Millisecond))
+	client.BaseURL = url
+	defer server.Close()
+
+	var calls int32
+	cancelled := make(chan struct{})
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		// Only the first attempt is slow
+		if atomic.AddInt32(&calls, 1) == 1 {
+			select {
+			case <-r.Context().Done():
+				close(cancelled)
+				return
+			case <-time.After(time.Second):
+			}
+		}
+		fmt.Fprint(w, `{"name":"cookiecutter"}`)
+	})
+
+	start := time.Now()
+	project, _, err := client.Project(context.Background(), "pypi", "cookiecutter")
+	if err != nil {
+		t.Fatalf("Project returned unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected the hedged attempt to respond, took %v", elapsed)
+	}
+	if got := stringValue(project.Name); got != "cookiecutter" {
+		t.Errorf("unexpected project name %q", got)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("expected the slow attempt to be cancelled")
+	}
+}
+
+func TestWithHedging_background(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey, WithHedging(time.Millisecond))
+	client.BaseURL = url
+	defer server.Close()
+
+	var calls int32
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(20 * time.Millisecond)
+		fmt.Fprint(w, `{"name":"cookiecutter"}`)
+	})
+
+	req, err := client.NewRequest(http.MethodGet, "pypi/cookiecutter", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned unexpected error: %v", err)
+	}
+	if _, err := client.Do(context.Background(), req, nil, WithPriority(PriorityBackground)); err != nil {
+		t.Fatalf("Do returned unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected background requests not to be hedged, got %d attempts", got)
+	}
+}
+
+func TestWithHedging_paced(t *testing.T) {
+	server, mux, url := startNewServer()
+	sink := new(recordingAuditSink)
+	client := NewClient(APIKey, WithHedging(20*time.Millisecond), WithSharedLimiter(NewSharedLimiter(60, 1)), WithAuditSink(sink))
+	client.BaseURL = url
+	defer server.Close()
+
+	var calls int32
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(200 * time.Millisecond)
+		fmt.Fprint(w, `{"name":"cookiecutter"}`)
+	})
+
+	if _, _, err := client.Project(context.Background(), "pypi", "cookiecutter"); err != nil {
+		t.Fatalf("Project returned unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected the hedged attempt to wait for the limiter, got %d attempts", got)
+	}
+	if got := len(sink.recorded()); got != 1 {
+		t.Errorf("expected only the sent attempt to be audited, got %d entries", got)
+	}
+}
+
+func TestWithHedging_audit(t *testing.T) {
+	server, mux, url := startNewServer()
+	sink := new(recordingAuditSink)
+	client := NewClient(APIKey, WithHedging(20*time.Millisecond), WithAuditSink(sink))
+	client.BaseURL = url
+	defer server.Close()
+
+	var calls int32
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		// Only the first attempt is slow
+		if atomic.AddInt32(&calls, 1) == 1 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(time.Second):
+			}
+		}
+		fmt.Fprint(w, `{"name":"cookiecutter"}`)
+	})
+
+	if _, _, err := client.Project(context.Background(), "pypi", "cookiecutter"); err != nil {
+		t.Fatalf("Project returned unexpected error: %v", err)
+	}
+
+	// The cancelled attempt is recorded once it returned
+	deadline := time.Now().Add(time.Second)
+	for len(sink.recorded()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	entries := sink.recorded()
+	if len(entries) != 2 {
+		t.Fatalf("expected both attempts to be audited, got %d entries", len(entries))
+	}
+	for _, entry := range entries {
+		if strings.Contains(entry.URL, APIKey) || strings.Contains(entry.Error, APIKey) {
+			t.Errorf("expected api_key to be redacted, got %+v", entry)
+		}
+	}
+}
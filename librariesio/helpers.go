@@ -21,3 +21,19 @@ func String(s string) *string {
 func Time(t time.Time) *time.Time {
 	return &t
 }
+
+// stringValue returns the value of s or "" if s is nil
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// intValue returns the value of i or 0 if i is nil
+func intValue(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
@@ -0,0 +1,61 @@
+package librariesio
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Hosts that can be passed to NewClientForHost
+const (
+	// DefaultHost is the public libraries.io API
+	DefaultHost = "https://libraries.io"
+
+	// LocalHost is a libraries.io development server started
+	// with its default settings
+	LocalHost = "http://localhost:3000"
+)
+
+// NewClientForHost returns a client for the libraries.io API served by
+// host, e.g. a mirror or a mock server. host must be an http or https URL.
+// If it has no path, the API is expected at /api/ like on libraries.io.
+func NewClientForHost(apiKey, host string, opts ...ClientOption) (*Client, error) {
+	baseURL, err := parseHost(host)
+	if err != nil {
+		return nil, err
+	}
+
+	c := NewClient(apiKey, opts...)
+	c.BaseURL = baseURL
+	return c, nil
+}
+
+// parseHost validates host and returns the base URL of its API
+func parseHost(host string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(host))
+	if err != nil {
+		return nil, fmt.Errorf("invalid host %q: %w", host, err)
+	}
+
+	switch {
+	case u.Scheme != "http" && u.Scheme != "https":
+		return nil, fmt.Errorf("invalid host %q: scheme must be http or https", host)
+	case u.Host == "":
+		return nil, fmt.Errorf("invalid host %q: missing host name", host)
+	case u.User != nil:
+		return nil, fmt.Errorf("invalid host %q: credentials are not supported, use the API key", host)
+	case u.RawQuery != "" || u.Fragment != "":
+		return nil, fmt.Errorf("invalid host %q: query and fragment are not supported", host)
+	}
+
+	if u.Path == "" || u.Path == "/" {
+		u.Path = "/api/"
+	} else if !strings.HasSuffix(u.Path, "/") {
+		// Relative endpoint paths are resolved against the base URL,
+		// which drops its last path segment without a trailing slash
+		u.Path += "/"
+	}
+	u.RawPath = ""
+
+	return u, nil
+}
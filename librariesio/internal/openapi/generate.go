@@ -0,0 +1,489 @@
+// Package openapi generates the endpoint methods of the librariesio package
+// from an OpenAPI description of the libraries.io API, see openapi.json
+// and gen_endpoints.go in the librariesio package.
+//
+// Only the subset of OpenAPI used by the libraries.io API is supported:
+// GET operations with string path parameters, required string query
+// parameters, optional string, integer, boolean and string array query
+// parameters, page and per_page for paging, and JSON responses that are
+// a model of the librariesio package or an array of one.
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"net/http"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// Header is the first line of the generated file
+const Header = "// Code generated by gen_endpoints.go from openapi.json; DO NOT EDIT."
+
+type spec struct {
+	Servers []struct {
+		URL string `json:"url"`
+	} `json:"servers"`
+	Paths map[string]map[string]*operation `json:"paths"`
+}
+
+type operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Parameters  []*parameter         `json:"parameters"`
+	Responses   map[string]*response `json:"responses"`
+}
+
+type parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Required    bool    `json:"required"`
+	Description string  `json:"description"`
+	Schema      *schema `json:"schema"`
+}
+
+type response struct {
+	Content map[string]struct {
+		Schema *schema `json:"schema"`
+	} `json:"content"`
+}
+
+type schema struct {
+	Type  string  `json:"type"`
+	Ref   string  `json:"$ref"`
+	Items *schema `json:"items"`
+}
+
+// endpoint is an operation prepared for the template
+type endpoint struct {
+	Name string
+	Doc  string
+
+	// OptionsDoc is the doc comment of the options struct
+	OptionsDoc string
+
+	// Args are the path parameters followed by the required query parameters
+	Args []*arg
+
+	// URL is the Go expression of the URL relative to the BaseURL
+	URL string
+
+	// Options are the fields of the generated options struct,
+	// Paged is set if the endpoint takes page and per_page
+	Options []*field
+	Paged   bool
+
+	// Model is the type the response is decoded into, List is set
+	// if the response is an array of it
+	Model string
+	List  bool
+}
+
+type arg struct {
+	Name string
+}
+
+type field struct {
+	Name string
+	Type string
+	Tag  string
+	Doc  string
+}
+
+// OptionsType returns the type of the opts argument, it is empty
+// for endpoints without optional parameters
+func (e *endpoint) OptionsType() string {
+	switch {
+	case len(e.Options) > 0:
+		return "*" + e.Name + "Options"
+	case e.Paged:
+		return "*ListOptions"
+	}
+	return ""
+}
+
+// Result returns the type of the first result
+func (e *endpoint) Result() string {
+	if e.List {
+		return "[]*" + e.Model
+	}
+	return "*" + e.Model
+}
+
+// Var returns the name of the variable holding the result
+func (e *endpoint) Var() string {
+	name := strings.ToLower(e.Model[:1]) + e.Model[1:]
+	if e.List {
+		return name + "s"
+	}
+	return name
+}
+
+// Generate returns the Go source of the endpoint methods and option
+// structs described by the given OpenAPI document
+func Generate(data []byte) ([]byte, error) {
+	var s spec
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing OpenAPI description: %w", err)
+	}
+	if len(s.Servers) == 0 {
+		return nil, fmt.Errorf("OpenAPI description has no server")
+	}
+	server := strings.TrimSuffix(s.Servers[0].URL, "/")
+
+	var endpoints []*endpoint
+	for path, methods := range s.Paths {
+		for method, op := range methods {
+			e, err := newEndpoint(server, path, method, op)
+			if err != nil {
+				return nil, fmt.Errorf("%v %v: %w", strings.ToUpper(method), path, err)
+			}
+			endpoints = append(endpoints, e)
+		}
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].Name < endpoints[j].Name
+	})
+
+	var imports []string
+	if len(endpoints) > 0 {
+		imports = append(imports, "context")
+	}
+	if uses(endpoints, "fmt.") {
+		imports = append(imports, "fmt")
+	}
+	if uses(endpoints, "url.") {
+		imports = append(imports, "net/url")
+	}
+
+	var buf bytes.Buffer
+	if err := fileTemplate.Execute(&buf, map[string]interface{}{
+		"Header":    Header,
+		"Imports":   imports,
+		"Endpoints": endpoints,
+	}); err != nil {
+		return nil, err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+func newEndpoint(server, path, method string, op *operation) (*endpoint, error) {
+	if !strings.EqualFold(method, http.MethodGet) {
+		return nil, fmt.Errorf("method is not supported")
+	}
+	if !token.IsExported(op.OperationID) {
+		return nil, fmt.Errorf("operationId %q is not an exported Go name", op.OperationID)
+	}
+
+	e := &endpoint{Name: op.OperationID}
+
+	var (
+		pathArgs  []string
+		queryArgs []string
+		argDocs   []string
+	)
+
+	for _, p := range op.Parameters {
+		typ, err := goType(p.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %v: %w", p.Name, err)
+		}
+
+		switch {
+		case p.In == "path" || p.In == "query" && p.Required:
+			if typ != "string" {
+				return nil, fmt.Errorf("parameter %v: required parameters must be strings", p.Name)
+			}
+			name := lowerCamel(p.Name)
+			if token.IsKeyword(name) {
+				return nil, fmt.Errorf("parameter %v: %q is a Go keyword", p.Name, name)
+			}
+			e.Args = append(e.Args, &arg{Name: name})
+			argDocs = append(argDocs, fmt.Sprintf("%v is %v", name, p.Description))
+			if p.In == "path" {
+				pathArgs = append(pathArgs, p.Name)
+			} else {
+				queryArgs = append(queryArgs, p.Name)
+			}
+
+		case p.In == "query" && (p.Name == "page" || p.Name == "per_page"):
+			e.Paged = true
+
+		case p.In == "query":
+			e.Options = append(e.Options, &field{
+				Name: upperCamel(p.Name),
+				Type: typ,
+				Tag:  fmt.Sprintf("`url:%q`", p.Name+",omitempty"),
+				Doc:  p.Description,
+			})
+
+		default:
+			return nil, fmt.Errorf("parameter %v: location %q is not supported", p.Name, p.In)
+		}
+	}
+
+	urlExpr, err := urlExpression(path, pathArgs, queryArgs)
+	if err != nil {
+		return nil, err
+	}
+	e.URL = urlExpr
+
+	if e.Model, e.List, err = model(op); err != nil {
+		return nil, err
+	}
+
+	apiPath := path
+	for _, name := range pathArgs {
+		apiPath = strings.Replace(apiPath, "{"+name+"}", ":"+name, 1)
+	}
+
+	doc := []string{
+		wrap(e.Name + " " + op.Summary),
+		"//",
+		"// GET " + server + apiPath,
+	}
+	switch {
+	case len(e.Options) > 0:
+		argDocs = append(argDocs, "opts holds the optional parameters, it may be nil")
+	case e.Paged:
+		argDocs = append(argDocs, "opts selects the page, it may be nil for the first page")
+	}
+	if len(argDocs) > 0 {
+		doc = append(doc, "//")
+		for _, d := range argDocs {
+			doc = append(doc, "// "+d)
+		}
+	}
+	e.Doc = strings.Join(doc, "\n")
+
+	e.OptionsDoc = wrap(e.Name + "Options specifies the optional parameters of " + e.Name)
+	for _, f := range e.Options {
+		f.Doc = wrap(f.Name + " " + f.Doc)
+	}
+
+	return e, nil
+}
+
+// urlExpression returns the Go expression building the URL of path,
+// the path parameters are escaped and the required query parameters
+// are appended
+func urlExpression(path string, pathArgs, queryArgs []string) (string, error) {
+	rel := strings.TrimPrefix(path, "/")
+	format := strings.ReplaceAll(rel, "%", "%%")
+	var values []string
+	for _, name := range pathArgs {
+		placeholder := "{" + name + "}"
+		if !strings.Contains(format, placeholder) {
+			return "", fmt.Errorf("path parameter %v is not part of the path", name)
+		}
+		format = strings.Replace(format, placeholder, "%v", 1)
+		values = append(values, fmt.Sprintf("url.PathEscape(%v)", lowerCamel(name)))
+	}
+	if strings.ContainsAny(format, "{}") {
+		return "", fmt.Errorf("path has undeclared parameters")
+	}
+
+	// literal is the text not yet added to expr as a string literal
+	var expr, literal string
+	if len(values) > 0 {
+		expr = fmt.Sprintf("fmt.Sprintf(%q, %v)", format, strings.Join(values, ", "))
+	} else {
+		literal = rel
+	}
+
+	add := func(s string) {
+		if expr != "" {
+			expr += " + "
+		}
+		expr += s
+	}
+	for i, name := range queryArgs {
+		sep := "?"
+		if i > 0 {
+			sep = "&"
+		}
+		add(fmt.Sprintf("%q", literal+sep+name+"="))
+		add(fmt.Sprintf("url.QueryEscape(%v)", lowerCamel(name)))
+		literal = ""
+	}
+	if literal != "" {
+		add(fmt.Sprintf("%q", literal))
+	}
+	return expr, nil
+}
+
+// model returns the name of the model the successful response is
+// decoded into and whether the response is an array of it
+func model(op *operation) (string, bool, error) {
+	resp, ok := op.Responses["200"]
+	if !ok {
+		return "", false, fmt.Errorf("no 200 response")
+	}
+	content, ok := resp.Content["application/json"]
+	if !ok || content.Schema == nil {
+		return "", false, fmt.Errorf("200 response is not JSON")
+	}
+
+	s, list := content.Schema, false
+	if s.Type == "array" && s.Items != nil {
+		s, list = s.Items, true
+	}
+
+	const prefix = "#/components/schemas/"
+	if !strings.HasPrefix(s.Ref, prefix) {
+		return "", false, fmt.Errorf("200 response must reference a schema of %v", prefix)
+	}
+	name := strings.TrimPrefix(s.Ref, prefix)
+	if !token.IsExported(name) {
+		return "", false, fmt.Errorf("schema %q is not an exported Go name", name)
+	}
+	return name, list, nil
+}
+
+func goType(s *schema) (string, error) {
+	if s == nil {
+		return "", fmt.Errorf("no schema")
+	}
+	switch s.Type {
+	case "string":
+		return "string", nil
+	case "integer":
+		return "int", nil
+	case "boolean":
+		return "bool", nil
+	case "array":
+		if s.Items != nil && s.Items.Type == "string" {
+			return "[]string", nil
+		}
+	}
+	return "", fmt.Errorf("type %q is not supported", s.Type)
+}
+
+// initialisms are spelled in upper case in Go names
+var initialisms = map[string]bool{"id": true, "url": true, "api": true, "spdx": true}
+
+func upperCamel(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' }) {
+		if initialisms[part] {
+			b.WriteString(strings.ToUpper(part))
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+func lowerCamel(name string) string {
+	upper := upperCamel(name)
+	for word := range initialisms {
+		if strings.HasPrefix(upper, strings.ToUpper(word)) && len(upper) == len(word) {
+			return word
+		}
+	}
+	return strings.ToLower(upper[:1]) + upper[1:]
+}
+
+// wrap formats text as a comment wrapped at 72 columns
+func wrap(text string) string {
+	var lines []string
+	line := "//"
+	for _, word := range strings.Fields(text) {
+		if len(line)+1+len(word) > 72 && line != "//" {
+			lines = append(lines, line)
+			line = "//"
+		}
+		line += " " + word
+	}
+	return strings.Join(append(lines, line), "\n")
+}
+
+func uses(endpoints []*endpoint, pkg string) bool {
+	for _, e := range endpoints {
+		if strings.Contains(e.URL, pkg) {
+			return true
+		}
+	}
+	return false
+}
+
+var fileTemplate = template.Must(template.New("endpoints").Parse(`{{.Header}}
+
+package librariesio
+{{if .Imports}}
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{end}}
+{{- range .Endpoints}}
+{{- if .Options}}
+{{.OptionsDoc}}
+type {{.Name}}Options struct {
+{{- range .Options}}
+{{.Doc}}
+	{{.Name}} {{.Type}} {{.Tag}}
+{{end}}
+{{- if .Paged}}
+	ListOptions
+{{- end}}
+}
+{{end}}
+{{.Doc}}
+func (c *Client) {{.Name}}(ctx context.Context{{range .Args}}, {{.Name}} string{{end}}{{with .OptionsType}}, opts {{.}}{{end}}) ({{.Result}}, *Response, error) {
+{{- if .Options}}
+	var o {{.Name}}Options
+	if opts != nil {
+		o = *opts
+	}
+{{if .Paged}}
+	var err error
+	if o.ListOptions, err = o.ListOptions.normalize(); err != nil {
+		return nil, nil, err
+	}
+{{end}}
+	urlStr, err := addOptions({{.URL}}, o)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request, err := c.NewRequest("GET", urlStr, nil)
+{{- else if .Paged}}
+	request, err := c.newListRequest({{.URL}}, opts)
+{{- else}}
+	request, err := c.NewRequest("GET", {{.URL}}, nil)
+{{- end}}
+	if err != nil {
+		return nil, nil, err
+	}
+{{if .List}}
+	var {{.Var}} {{.Result}}
+
+	response, err := c.Do(ctx, request, &{{.Var}})
+	if err != nil {
+		return nil, response, err
+	}
+{{else}}
+	{{.Var}} := new({{.Model}})
+
+	response, err := c.Do(ctx, request, {{.Var}})
+	if err != nil {
+		return nil, response, err
+	}
+	if response.unchanged() {
+		return nil, response, nil
+	}
+{{end}}
+	return {{.Var}}, response, nil
+}
+{{end}}`))
@@ -0,0 +1,71 @@
+package openapi
+
+import (
+	"strings"
+	"testing"
+)
+
+const testSpec = `{
+	"servers": [{"url": "https://libraries.io/api/"}],
+	"paths": {
+		"/{platform}/{name}/tree": {
+			"get": {
+				"operationId": "ProjectTree",
+				"summary": "returns the dependency tree of the given project",
+				"parameters": [
+					{"name": "platform", "in": "path", "required": true, "description": "the platform of the project", "schema": {"type": "string"}},
+					{"name": "name", "in": "path", "required": true, "description": "the name of the project", "schema": {"type": "string"}},
+					{"name": "kind", "in": "query", "description": "selects the kind of dependencies", "schema": {"type": "string"}},
+					{"name": "max_depth", "in": "query", "description": "limits the depth of the tree", "schema": {"type": "integer"}}
+				],
+				"responses": {
+					"200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Project"}}}}
+				}
+			}
+		}
+	}
+}`
+
+func TestGenerate(t *testing.T) {
+	src, err := Generate([]byte(testSpec))
+	if err != nil {
+		t.Fatalf("Generate returned unexpected error: %v", err)
+	}
+
+	got := string(src)
+	for _, want := range []string{
+		Header,
+		"type ProjectTreeOptions struct {",
+		"\tMaxDepth int `url:\"max_depth,omitempty\"`",
+		"// GET https://libraries.io/api/:platform/:name/tree",
+		"func (c *Client) ProjectTree(ctx context.Context, platform string, name string, opts *ProjectTreeOptions) (*Project, *Response, error) {",
+		`addOptions(fmt.Sprintf("%v/%v/tree", url.PathEscape(platform), url.PathEscape(name)), o)`,
+		"if response.unchanged() {",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("generated code does not contain %q\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "ListOptions") {
+		t.Errorf("expected endpoint without paging not to take ListOptions\n%s", got)
+	}
+}
+
+func TestGenerate_errors(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{"method", `{"servers": [{"url": "x"}], "paths": {"/a": {"post": {"operationId": "A"}}}}`},
+		{"operationId", `{"servers": [{"url": "x"}], "paths": {"/a": {"get": {"operationId": "a"}}}}`},
+		{"undeclared", `{"servers": [{"url": "x"}], "paths": {"/{a}": {"get": {"operationId": "A"}}}}`},
+		{"response", `{"servers": [{"url": "x"}], "paths": {"/a": {"get": {"operationId": "A", "responses": {}}}}}`},
+		{"server", `{"paths": {}}`},
+	}
+
+	for _, tt := range tests {
+		if _, err := Generate([]byte(tt.spec)); err == nil {
+			t.Errorf("%v: expected an error", tt.name)
+		}
+	}
+}
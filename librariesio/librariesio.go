@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"sync/atomic"
 	"time"
 )
 
@@ -28,27 +29,64 @@ type Client struct {
 	UserAgent string
 	BaseURL   *url.URL
 	Retry     bool
+
+	provider    Provider
+	decodeHooks []DecodeHook
+	rate        rateStatus
+	logger      requestLogger
+	limiter     *limiter
+	shared      *SharedLimiter
+	scheduler   *Scheduler
+	smoothPages bool
+	replayQueue ReplayQueue
+	tolerant    bool
+	useNumber   bool
+	cache       Cache
+	dryRun      bool
+	auditSink   AuditSink
+	repoFetcher RepositoryFetcher
+
+	revalidation *revalidation
+	background   background
+
+	deprecationHook   func(*DeprecationNotice)
+	deprecationLogged atomic.Bool
+
+	defaultTimeout    time.Duration
+	maxRateLimitWait  time.Duration
+	maxResponseBytes  int64
+	hedgeAfter        time.Duration
+	backgroundReserve int
 }
 
-// NewClient returns a new libraries.io API client
-func NewClient(apiKey string) *Client {
+// NewClient returns a new libraries.io API client, configured with the
+// given options
+func NewClient(apiKey string, opts ...ClientOption) *Client {
 	APIBaseURL, _ := url.Parse(baseURL)
 
 	transport := &http.Transport{}
 	client := &http.Client{Transport: transport}
 
-	return &Client{
+	c := &Client{
 		apiKey:    apiKey,
 		client:    client,
 		transport: transport,
 		UserAgent: userAgent,
 		BaseURL:   APIBaseURL,
 	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
 // NewRequest creates a new API request, that can be used for client.Do().
 // It creates an absolute URL from the given URL string and serialize the
 // given payload, set the according headers and add the api_key query param.
+// The api_key is replaced when the request is sent with an API key set by
+// WithAPIKey or NewAPIKeyContext.
 func (c *Client) NewRequest(method, urlStr string, data interface{}) (*http.Request, error) {
 	relativeURL, err := url.Parse(urlStr)
 	if err != nil {
@@ -87,12 +125,24 @@ func (c *Client) NewRequest(method, urlStr string, data interface{}) (*http.Requ
 	return req, nil
 }
 
-// redactAPIKey overwrites the secret api_key query param
+// redactAPIKey returns a copy of url with the secret api_key query param
+// overwritten, the given url is left untouched as it may be sent again
 func redactAPIKey(url *url.URL) *url.URL {
-	q := url.Query()
+	redacted := *url
+	q := redacted.Query()
 	q.Set("api_key", "REDACTED")
-	url.RawQuery = q.Encode()
-	return url
+	redacted.RawQuery = q.Encode()
+	return &redacted
+}
+
+// redactURLError redacts the API secret key from the URL of err
+// if it is an url.Error
+func redactURLError(err error) {
+	if urlError, ok := err.(*url.Error); ok {
+		if url, err := url.Parse(urlError.URL); err == nil {
+			urlError.URL = redactAPIKey(url).String()
+		}
+	}
 }
 
 // ErrorResponse holds information about an unsuccessful API request.
@@ -129,60 +179,328 @@ func CheckResponse(resp *http.Response) error {
 	return errResp
 }
 
+// Response wraps the http.Response returned by the libraries.io API
+type Response struct {
+	*http.Response
+
+	// Raw holds the undecoded response body, it is only populated
+	// for requests sent with the WithRawBody option
+	Raw json.RawMessage
+
+	// Diagnostics lists the fields skipped by Decode for clients
+	// created with WithTolerantDecoding
+	Diagnostics []DecodeDiagnostic
+
+	// ETag and LastModified are the cache validators sent by the API,
+	// they can be passed to WithIfNoneMatch and WithIfModifiedSince
+	// to make a conditional request for the same resource
+	ETag         string
+	LastModified string
+
+	// DryRun is set for synthesized responses of clients
+	// created with WithDryRun
+	DryRun bool
+
+	// Stale is set for cached responses older than the maximum
+	// age set with WithStaleWhileRevalidate
+	Stale bool
+
+	// NotModified is set if the API answered a conditional request with
+	// 304 Not Modified. Do then decodes the body cached for the resource
+	// with WithCache into obj, or leaves obj unchanged if there is none,
+	// so pollers can pass their last value and keep it. Methods returning
+	// a single object, e.g. Project, return a nil object if there is no
+	// cached body, lists are returned empty.
+	NotModified bool
+
+	body      []byte
+	hooks     []DecodeHook
+	tolerant  bool
+	useNumber bool
+}
+
+// Decode loads the JSON response body into the given obj and runs
+// the decode hooks registered with WithDecodeHook on it
+func (r *Response) Decode(obj interface{}) error {
+	if r.tolerant {
+		diagnostics, err := decodeTolerant(r.body, obj, r.useNumber)
+		r.Diagnostics = append(r.Diagnostics, diagnostics...)
+		if err != nil {
+			return err
+		}
+	} else if err := unmarshal(r.body, obj, r.useNumber); err != nil {
+		return err
+	}
+	for _, hook := range r.hooks {
+		if err := hook(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unchanged reports whether the response answered a conditional request
+// with 304 Not Modified and there is no cached body to decode
+func (r *Response) unchanged() bool {
+	return r.NotModified && r.body == nil
+}
+
 // Do sends an HTTP request, that can be cancelled via the given context.
 // It makes sure to redact the API secret key from any URL errors and load
 // the body from the HTTP response into the given obj and return the response.
-func (c *Client) Do(ctx context.Context, req *http.Request, obj interface{}) (*http.Response, error) {
+func (c *Client) Do(ctx context.Context, req *http.Request, obj interface{}, opts ...RequestOption) (*Response, error) {
+	response, err := c.DoLazy(ctx, req, opts...)
+	if err != nil {
+		return response, err
+	}
+
+	// Load body into the given obj
+	if obj != nil && !response.unchanged() {
+		if err := response.Decode(obj); err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+// DoLazy sends an HTTP request like Do, but does not decode the response
+// body. This allows callers to inspect the status and headers of the
+// response before calling Decode on it.
+func (c *Client) DoLazy(ctx context.Context, req *http.Request, opts ...RequestOption) (*Response, error) {
+	cfg := new(requestConfig)
+	for _, opt := range contextOptions(ctx) {
+		opt(cfg)
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if c.dryRun && req.Method != http.MethodGet {
+		return c.dryRunResponse(ctx, req, cfg)
+	}
+
+	if cfg.conditional() || len(cfg.header) > 0 || cfg.apiKey != "" {
+		req = req.Clone(req.Context())
+		if cfg.apiKey != "" {
+			q := req.URL.Query()
+			q.Set("api_key", cfg.apiKey)
+			req.URL.RawQuery = q.Encode()
+		}
+		for key, values := range cfg.header {
+			req.Header[key] = values
+		}
+		if cfg.ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", cfg.ifNoneMatch)
+		}
+		if cfg.ifModifiedSince != "" {
+			req.Header.Set("If-Modified-Since", cfg.ifModifiedSince)
+		}
+	}
+
+	// Conditional requests bypass the cache as their
+	// caller keeps track of the response itself
+	var cacheKey string
+	if c.cache != nil && req.Method == http.MethodGet && !cfg.conditional() && !cfg.noCache {
+		cacheKey = c.cacheKey(req, cfg)
+		if response, ok := c.cached(ctx, req, cacheKey, cfg); ok {
+			return response, nil
+		}
+	}
+
+	// Writes invalidate the cached response of their resource
+	if c.cache != nil && req.Method != http.MethodGet {
+		defer c.cache.Delete(c.cacheKey(req, cfg))
+	}
+
+	if c.scheduler != nil && !cfg.scheduled {
+		opts = append(opts[:len(opts):len(opts)], scheduled())
+		response, err := c.scheduler.do(ctx, scheduleKey(req, cfg), cfg.priority, func(ctx context.Context) (*Response, error) {
+			return c.DoLazy(ctx, req, opts...)
+		})
+		if response == nil {
+			return nil, err
+		}
+
+		// The response may be shared with other callers
+		shared := *response
+		shared.Diagnostics = nil
+		shared.Raw = nil
+		if cfg.rawBody {
+			shared.Raw = json.RawMessage(shared.body)
+		}
+		return &shared, err
+	}
+
+	if cfg.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.timeout)
+		defer cancel()
+	} else if _, ok := ctx.Deadline(); !ok && c.defaultTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.defaultTimeout)
+		defer cancel()
+	}
+
+	if cfg.priority == PriorityBackground && c.backgroundReserve > 0 {
+		if err := sleepContext(ctx, c.rate.backgroundDelay(now(), c.backgroundReserve)); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.pace(ctx); err != nil {
+		return nil, err
+	}
+
 	req = req.WithContext(ctx)
+	start := time.Now()
 
-	resp, err := c.client.Do(req)
+	resp, err := c.send(req, cfg)
 	if err != nil {
-		// If we have encountered an url.Error make sure
-		// to redact the API secret key from the URL
-		if urlError, ok := err.(*url.Error); ok {
-			if url, err := url.Parse(urlError.URL); err == nil {
-				urlError.URL = redactAPIKey(url).String()
-				return nil, urlError
-			}
-		}
+		redactURLError(err)
+		c.logRequest(ctx, req, nil, err, start)
+		c.audit(req, nil, err, start)
+		c.recordFailure(ctx, req, nil, cfg, err)
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	c.limitBody(resp)
+	if c.auditSink != nil {
+		resp.Body = &countingBody{ReadCloser: resp.Body}
+	}
+
+	c.rate.update(resp)
+	c.checkDeprecation(ctx, req, resp)
+	response := &Response{Response: resp}
+	response.ETag, response.LastModified = validators(resp.Header)
+
+	if resp.StatusCode == http.StatusNotModified && cfg.conditional() {
+		c.logRequest(ctx, req, resp, nil, start)
+		c.audit(req, resp, nil, start)
+		return c.notModified(req, resp, cfg), nil
+	}
+
 	// Check that the response's status code is OK
 	if err := CheckResponse(resp); err != nil {
+		c.logRequest(ctx, req, resp, err, start)
+		c.audit(req, resp, err, start)
+
 		// If we got a 429 and want to retry, just execute again.
 		// Note: only supported for GET requests.
 		if c.Retry &&
 			resp.StatusCode == http.StatusTooManyRequests &&
 			req.Method == http.MethodGet &&
 			resp.Header.Get("X-RateLimit-Reset") != "" {
-			timeToWait, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Reset"))
-			if err != nil {
-				return resp, err
+			timeToWait, convErr := strconv.Atoi(resp.Header.Get("X-RateLimit-Reset"))
+			if convErr != nil {
+				return response, convErr
 			}
 
 			// Wait the reset time + 1 second before retrying.
-			time.Sleep(time.Second * time.Duration(timeToWait+1))
+			wait := time.Second * time.Duration(timeToWait+1)
+			if c.maxRateLimitWait > 0 && wait > c.maxRateLimitWait {
+				c.recordFailure(ctx, req, resp, cfg, err)
+				return response, &RateLimitError{Wait: wait, MaxWait: c.maxRateLimitWait, Err: err}
+			}
+			if err := sleepContext(ctx, wait); err != nil {
+				return response, err
+			}
 
-			return c.Do(ctx, req, obj)
+			return c.DoLazy(ctx, req, opts...)
 		}
-		return resp, err
+		c.recordFailure(ctx, req, resp, cfg, err)
+		return response, err
 	}
 
 	// Always read the full body to prevent leaving the request open.
-	body, err := io.ReadAll(resp.Body)
+	body, err := c.readBody(resp)
+	c.logRequest(ctx, req, resp, err, start)
+	c.audit(req, resp, err, start)
 	if err != nil {
 		return nil, err
 	}
 
-	// Load body into the given obj
-	if obj != nil {
-		err = json.Unmarshal(body, obj)
-		if err != nil {
-			return nil, err
+	if cacheKey != "" {
+		c.cache.Set(cacheKey, body)
+		if c.revalidation != nil {
+			c.revalidation.stored(cacheKey, now())
+		}
+	}
+
+	return c.newResponse(resp, body, cfg), nil
+}
+
+// cacheKey returns the key of req in the cache set with WithCache
+func (c *Client) cacheKey(req *http.Request, cfg *requestConfig) string {
+	key := redactAPIKey(req.URL).String()
+	if cfg.apiKey != "" {
+		key += " " + tenantKey(cfg.apiKey)
+	}
+	return key
+}
+
+// notModified returns the response for a conditional request answered
+// with 304 Not Modified, it carries the cached body if there is one
+func (c *Client) notModified(req *http.Request, resp *http.Response, cfg *requestConfig) *Response {
+	var body []byte
+	if c.cache != nil {
+		body, _ = c.cache.Get(c.cacheKey(req, cfg))
+	}
+	response := c.newResponse(resp, body, cfg)
+	response.NotModified = true
+	return response
+}
+
+// cached returns the cached response for req if it may be served,
+// stale entries are refreshed in the background
+func (c *Client) cached(ctx context.Context, req *http.Request, key string, cfg *requestConfig) (*Response, bool) {
+	if cfg.revalidating {
+		return nil, false
+	}
+	body, ok := c.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+
+	var stale bool
+	if c.revalidation != nil {
+		var refresh bool
+		if ok, stale, refresh = c.revalidation.lookup(key, now()); !ok {
+			return nil, false
+		}
+		if refresh {
+			c.revalidate(ctx, req, key)
 		}
 	}
 
-	return resp, nil
+	resp := &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       http.NoBody,
+		Request:    req,
+	}
+	response := c.newResponse(resp, body, cfg)
+	response.Stale = stale
+	return response, true
+}
+
+// newResponse wraps resp with its body read in full
+func (c *Client) newResponse(resp *http.Response, body []byte, cfg *requestConfig) *Response {
+	response := &Response{
+		Response:  resp,
+		body:      body,
+		hooks:     c.decodeHooks,
+		tolerant:  c.tolerant,
+		useNumber: c.useNumber,
+	}
+	response.ETag, response.LastModified = validators(resp.Header)
+	if cfg.rawBody {
+		response.Raw = json.RawMessage(body)
+	}
+	return response
+}
+
+func validators(h http.Header) (etag, lastModified string) {
+	return h.Get("ETag"), h.Get("Last-Modified")
 }
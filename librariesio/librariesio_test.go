@@ -333,3 +333,86 @@ func TestDo_badResponseBody(t *testing.T) {
 		t.Fatal("Expected response body error")
 	}
 }
+
+func TestDo_rawBody(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	type foo struct {
+		Bar string `json:"bar"`
+	}
+
+	body := `{"bar":"helloworld","baz":1}`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	})
+
+	req, _ := client.NewRequest("GET", "/", nil)
+
+	got := new(foo)
+	resp, err := client.Do(context.Background(), req, got, WithRawBody())
+	if err != nil {
+		t.Fatalf("Do returned unexpected error: %v", err)
+	}
+
+	if want := (&foo{Bar: "helloworld"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("response body does not match, want %v, got %v", want, got)
+	}
+	if string(resp.Raw) != body {
+		t.Errorf("\nExpected raw body %s\nGot %s", body, resp.Raw)
+	}
+
+	req, _ = client.NewRequest("GET", "/", nil)
+
+	resp, err = client.Do(context.Background(), req, nil)
+	if err != nil {
+		t.Fatalf("Do returned unexpected error: %v", err)
+	}
+	if resp.Raw != nil {
+		t.Errorf("expected no raw body without WithRawBody, got %s", resp.Raw)
+	}
+}
+
+func TestDoLazy(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "59")
+		fmt.Fprint(w, `{"bar":"helloworld"}`)
+	})
+
+	req, _ := client.NewRequest("GET", "/", nil)
+
+	resp, err := client.DoLazy(context.Background(), req)
+	if err != nil {
+		t.Fatalf("DoLazy returned unexpected error: %v", err)
+	}
+
+	if got := resp.Header.Get("X-RateLimit-Remaining"); got != "59" {
+		t.Errorf("unexpected X-RateLimit-Remaining header %q", got)
+	}
+
+	type foo struct {
+		Bar string `json:"bar"`
+	}
+
+	got := new(foo)
+	if err := resp.Decode(got); err != nil {
+		t.Fatalf("Decode returned unexpected error: %v", err)
+	}
+
+	if want := (&foo{Bar: "helloworld"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("response body does not match, want %v, got %v", want, got)
+	}
+
+	var bad []string
+	if err := resp.Decode(&bad); err == nil {
+		t.Error("Expected Decode into wrong type to return an error")
+	}
+}
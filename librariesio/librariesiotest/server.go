@@ -0,0 +1,162 @@
+/*
+Package librariesiotest provides a fake libraries.io API for testing code
+that uses the librariesio client, including scripted misbehavior such as
+rate limiting, flaky server errors and slow responses.
+*/
+package librariesiotest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hackebrot/go-librariesio/librariesio"
+)
+
+// APIKey is the API key of clients returned by Server.Client
+const APIKey = "librariesiotest"
+
+// Step is one response of a scripted path
+type Step struct {
+	// Status defaults to 200 OK
+	Status int
+	Header http.Header
+	Body   string
+
+	// Delay is waited before responding, unless the request is cancelled
+	Delay time.Duration
+
+	// Times is the number of calls the step answers, it defaults to 1
+	Times int
+}
+
+// OK returns a step responding with body
+func OK(body string) Step {
+	return Step{Body: body}
+}
+
+// RateLimited returns a step responding with 429 Too Many Requests and
+// a rate limit that resets after reset seconds
+func RateLimited(reset int) Step {
+	h := make(http.Header)
+	h.Set("X-RateLimit-Remaining", "0")
+	h.Set("X-RateLimit-Reset", strconv.Itoa(reset))
+	return Step{Status: http.StatusTooManyRequests, Header: h, Body: `{"error":"Rate limit exceeded"}`}
+}
+
+// Failing returns a step responding times times with the given
+// server error status, e.g. http.StatusBadGateway
+func Failing(status, times int) Step {
+	return Step{Status: status, Body: fmt.Sprintf(`{"error":%q}`, http.StatusText(status)), Times: times}
+}
+
+// Slow returns a step responding with body after delay
+func Slow(delay time.Duration, body string) Step {
+	return Step{Body: body, Delay: delay}
+}
+
+// Server is a fake libraries.io API. Paths answer with the steps
+// scripted for them in order, the last step answers all further calls.
+// Paths without a script respond with 404 Not Found.
+type Server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	scripts map[string][]Step
+	calls   map[string]int
+}
+
+// NewServer starts a fake API, it must be closed by the caller
+func NewServer() *Server {
+	s := &Server{
+		scripts: make(map[string][]Step),
+		calls:   make(map[string]int),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Client returns a client sending requests to the server
+func (s *Server) Client(opts ...librariesio.ClientOption) *librariesio.Client {
+	c := librariesio.NewClient(APIKey, opts...)
+	c.BaseURL, _ = url.Parse(s.URL + "/")
+	return c
+}
+
+// Script sets the steps answering calls to path, e.g. "/npm/react",
+// and resets its call count
+func (s *Server) Script(path string, steps ...Step) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scripts[path] = steps
+	s.calls[path] = 0
+}
+
+// Handle answers all calls to path with body
+func (s *Server) Handle(path, body string) {
+	s.Script(path, OK(body))
+}
+
+// Calls returns the number of calls to path
+func (s *Server) Calls(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[path]
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	step, ok := s.next(r.URL.Path)
+	if !ok {
+		http.Error(w, `{"error":"Not Found"}`, http.StatusNotFound)
+		return
+	}
+
+	if step.Delay > 0 {
+		timer := time.NewTimer(step.Delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	for key, values := range step.Header {
+		w.Header()[key] = values
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if step.Status != 0 {
+		w.WriteHeader(step.Status)
+	}
+	fmt.Fprint(w, step.Body)
+}
+
+// next returns the step answering the next call to path
+func (s *Server) next(path string) (Step, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	steps, ok := s.scripts[path]
+	if !ok || len(steps) == 0 {
+		return Step{}, false
+	}
+
+	call := s.calls[path]
+	s.calls[path]++
+
+	for _, step := range steps {
+		times := step.Times
+		if times < 1 {
+			times = 1
+		}
+		if call < times {
+			return step, true
+		}
+		call -= times
+	}
+	return steps[len(steps)-1], true
+}
@@ -0,0 +1,75 @@
+package librariesiotest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hackebrot/go-librariesio/librariesio"
+)
+
+func TestServer_script(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.Script("/npm/react",
+		RateLimited(30),
+		Failing(http.StatusBadGateway, 2),
+		OK(`{"name":"react","platform":"NPM"}`),
+	)
+
+	client := server.Client()
+	ctx := context.Background()
+
+	var errResp *librariesio.ErrorResponse
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusBadGateway} {
+		_, _, err := client.Project(ctx, "npm", "react")
+		if !errors.As(err, &errResp) || errResp.Response.StatusCode != status {
+			t.Fatalf("expected HTTP %d, got %v", status, err)
+		}
+	}
+	if got := client.RateRemaining(); got != 0 {
+		t.Errorf("expected the rate limit of the 429 response, got %d remaining", got)
+	}
+
+	for i := 0; i < 2; i++ {
+		project, _, err := client.Project(ctx, "npm", "react")
+		if err != nil {
+			t.Fatalf("Project returned unexpected error: %v", err)
+		}
+		if got := *project.Name; got != "react" {
+			t.Errorf("expected react, got %v", got)
+		}
+	}
+
+	if got := server.Calls("/npm/react"); got != 5 {
+		t.Errorf("expected 5 calls, got %d", got)
+	}
+}
+
+func TestServer_slow(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.Script("/npm/react", Slow(time.Second, `{"name":"react"}`), OK(`{"name":"react"}`))
+
+	client := server.Client(librariesio.WithDefaultCallTimeout(10 * time.Millisecond))
+
+	if _, _, err := client.Project(context.Background(), "npm", "react"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if _, _, err := client.Project(context.Background(), "npm", "react"); err != nil {
+		t.Errorf("Project returned unexpected error: %v", err)
+	}
+}
+
+func TestServer_notFound(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	if _, _, err := server.Client().Project(context.Background(), "npm", "nope"); !errors.Is(err, librariesio.ErrProjectNotFound) {
+		t.Errorf("expected ErrProjectNotFound, got %v", err)
+	}
+}
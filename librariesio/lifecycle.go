@@ -0,0 +1,220 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrShutdown is returned for work started after Shutdown
+var ErrShutdown = errors.New("shut down")
+
+// Flusher is implemented by caches, audit sinks and replay queues that
+// buffer writes, Client.Shutdown flushes them
+type Flusher interface {
+	Flush() error
+}
+
+// background tracks the background work of a client, such as
+// refreshing stale cache entries
+type background struct {
+	mu     sync.Mutex
+	closed bool
+	ctx    context.Context
+	cancel context.CancelFunc
+	active activity
+}
+
+// start (re)opens b, background work is cancelled when ctx is done
+func (b *background) start(ctx context.Context) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.cancel != nil {
+		b.cancel()
+	}
+	b.closed = false
+	b.ctx, b.cancel = context.WithCancel(ctx)
+}
+
+// run calls fn in a new goroutine with a context that keeps the values
+// of ctx and is cancelled by the lifecycle of b. It returns false
+// without calling fn if b is shut down.
+func (b *background) run(ctx context.Context, fn func(ctx context.Context)) bool {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return false
+	}
+	if b.ctx == nil {
+		b.ctx, b.cancel = context.WithCancel(context.Background())
+	}
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(b.ctx, cancel)
+	b.active.add()
+	b.mu.Unlock()
+
+	go func() {
+		defer b.active.done()
+		defer cancel()
+		defer stop()
+		fn(ctx)
+	}()
+	return true
+}
+
+// enter registers work running in the goroutine of the caller, it
+// returns false if b is shut down. The returned context is also cancelled
+// by the lifecycle of b, done must be called once the work is finished.
+func (b *background) enter(ctx context.Context) (_ context.Context, done func(), ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return ctx, nil, false
+	}
+	if b.ctx == nil {
+		b.ctx, b.cancel = context.WithCancel(context.Background())
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(b.ctx, cancel)
+	b.active.add()
+
+	return ctx, func() {
+		stop()
+		cancel()
+		b.active.done()
+	}, true
+}
+
+// stopping reports whether b is shut down
+func (b *background) stopping() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.closed
+}
+
+// shutdown stops new work and waits for running work until ctx is done,
+// running work is then cancelled
+func (b *background) shutdown(ctx context.Context) error {
+	b.mu.Lock()
+	b.closed = true
+	cancel := b.cancel
+	b.mu.Unlock()
+
+	if err := b.active.wait(ctx); err != nil {
+		if cancel != nil {
+			cancel()
+		}
+		return err
+	}
+	return nil
+}
+
+// Start starts accepting background work again after Shutdown, and
+// cancels background work when ctx is done. The scheduler of the client
+// is started as well. Calling Start is optional, a new client accepts
+// background work right away.
+func (c *Client) Start(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.background.start(ctx)
+	if c.scheduler != nil {
+		return c.scheduler.Start(ctx)
+	}
+	return nil
+}
+
+// Shutdown stops the background work of the client and waits for
+// refreshes of stale cache entries that are in flight. Running calls of
+// MirrorDependents stop at the next checkpoint and return ErrShutdown.
+// The scheduler set with WithScheduler or WithPacingProfile is shut down
+// next, which affects all clients sharing it. The cache, audit sink and
+// replay queue are flushed if they implement Flusher. If ctx is done
+// first, the remaining background work is cancelled and ctx.Err() is
+// returned. Cached responses are served stale without being refreshed
+// after Shutdown.
+func (c *Client) Shutdown(ctx context.Context) error {
+	if err := c.background.shutdown(ctx); err != nil {
+		return err
+	}
+	if c.scheduler != nil {
+		if err := c.scheduler.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
+
+	var errs []error
+	for _, v := range []interface{}{c.cache, c.auditSink, c.replayQueue} {
+		if f, ok := v.(Flusher); ok {
+			errs = append(errs, f.Flush())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Start makes a scheduler accept requests again after Shutdown. Calling
+// Start is optional, a new scheduler accepts requests right away.
+func (s *Scheduler) Start(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = false
+	return nil
+}
+
+// Shutdown makes the scheduler fail new requests with ErrShutdown and
+// waits until the queued and in-flight requests are done, or returns
+// ctx.Err() if ctx is done first
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	return s.active.wait(ctx)
+}
+
+// activity counts running work and lets callers wait until there is
+// none. Unlike a sync.WaitGroup it may be reused while waiters time out.
+type activity struct {
+	mu   sync.Mutex
+	n    int
+	idle chan struct{}
+}
+
+func (a *activity) add() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.n == 0 {
+		a.idle = make(chan struct{})
+	}
+	a.n++
+}
+
+func (a *activity) done() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.n--
+	if a.n == 0 {
+		close(a.idle)
+	}
+}
+
+// wait blocks until no work is running or ctx is done
+func (a *activity) wait(ctx context.Context) error {
+	a.mu.Lock()
+	if a.n == 0 {
+		a.mu.Unlock()
+		return nil
+	}
+	idle := a.idle
+	a.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,202 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScheduler_shutdown(t *testing.T) {
+	s := NewScheduler(1, nil, nil)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go s.do(context.Background(), "", PriorityInteractive, func(context.Context) (*Response, error) {
+		close(started)
+		<-release
+		return nil, nil
+	})
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded while draining, got %v", err)
+	}
+
+	if _, err := s.do(context.Background(), "", PriorityInteractive, nil); !errors.Is(err, ErrShutdown) {
+		t.Errorf("expected ErrShutdown, got %v", err)
+	}
+
+	close(release)
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown returned unexpected error: %v", err)
+	}
+
+	if err := s.Start(context.Background()); err != nil {
+		t.Fatalf("Start returned unexpected error: %v", err)
+	}
+	if _, err := s.do(context.Background(), "", PriorityInteractive, func(context.Context) (*Response, error) {
+		return nil, nil
+	}); err != nil {
+		t.Errorf("expected requests to be accepted after Start, got %v", err)
+	}
+}
+
+// flushingCache counts the calls to Flush
+type flushingCache struct {
+	*LRUCache
+	flushes int32
+}
+
+func (c *flushingCache) Flush() error {
+	atomic.AddInt32(&c.flushes, 1)
+	return nil
+}
+
+func TestClientShutdown(t *testing.T) {
+	defer func(f func() time.Time) { now = f }(now)
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var mu sync.Mutex
+	now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return at
+	}
+	advance := func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		at = at.Add(d)
+	}
+
+	cache := &flushingCache{LRUCache: NewLRUCache(1<<20, nil)}
+
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey, WithCache(cache), WithStaleWhileRevalidate(time.Minute, time.Hour))
+	client.BaseURL = url
+	defer server.Close()
+
+	var calls int32
+	refreshing := make(chan struct{}, 1)
+	release := make(chan struct{})
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) > 1 {
+			refreshing <- struct{}{}
+			select {
+			case <-release:
+			case <-r.Context().Done():
+				return
+			}
+		}
+		fmt.Fprint(w, `{"name":"cookiecutter"}`)
+	})
+
+	ctx := context.Background()
+	if _, _, err := client.Project(ctx, "pypi", "cookiecutter"); err != nil {
+		t.Fatalf("Project returned unexpected error: %v", err)
+	}
+
+	// Refreshes that do not finish in time are cancelled
+	advance(2 * time.Minute)
+	if _, _, err := client.Project(ctx, "pypi", "cookiecutter"); err != nil {
+		t.Fatalf("Project returned unexpected error: %v", err)
+	}
+	<-refreshing
+
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := client.Shutdown(timeout); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if err := client.Shutdown(ctx); err != nil {
+		t.Errorf("expected the cancelled refresh to finish, got %v", err)
+	}
+
+	// Refreshes in flight are waited for
+	if err := client.Start(ctx); err != nil {
+		t.Fatalf("Start returned unexpected error: %v", err)
+	}
+	advance(2 * time.Second)
+	if _, _, err := client.Project(ctx, "pypi", "cookiecutter"); err != nil {
+		t.Fatalf("Project returned unexpected error: %v", err)
+	}
+	<-refreshing
+
+	done := make(chan error)
+	go func() { done <- client.Shutdown(ctx) }()
+	select {
+	case err := <-done:
+		t.Fatalf("Shutdown returned before the refresh finished: %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Errorf("Shutdown returned unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt32(&cache.flushes); got != 2 {
+		t.Errorf("expected the cache to be flushed twice, got %d", got)
+	}
+
+	// Stale entries are served without refreshing after Shutdown
+	advance(2 * time.Minute)
+	_, resp, err := client.Project(ctx, "pypi", "cookiecutter")
+	if err != nil || !resp.Stale {
+		t.Fatalf("expected a stale response, got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("expected no refresh after Shutdown, got %d requests", got)
+	}
+}
+
+func TestClientShutdown_scheduler(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey, WithPacingProfile(ProfileAggressive))
+	client.BaseURL = url
+	defer server.Close()
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		fmt.Fprint(w, `{"name":"cookiecutter"}`)
+	})
+
+	ctx := context.Background()
+	project := make(chan error)
+	go func() {
+		_, _, err := client.Project(ctx, "pypi", "cookiecutter")
+		project <- err
+	}()
+	<-started
+
+	done := make(chan error)
+	go func() { done <- client.Shutdown(ctx) }()
+	select {
+	case err := <-done:
+		t.Fatalf("Shutdown returned before the request finished: %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+	if err := <-project; err != nil {
+		t.Errorf("Project returned unexpected error: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Shutdown returned unexpected error: %v", err)
+	}
+
+	if _, _, err := client.Project(ctx, "pypi", "cookiecutter"); !errors.Is(err, ErrShutdown) {
+		t.Errorf("expected ErrShutdown, got %v", err)
+	}
+	if err := client.Start(ctx); err != nil {
+		t.Fatalf("Start returned unexpected error: %v", err)
+	}
+	if _, _, err := client.Project(ctx, "pypi", "cookiecutter"); err != nil {
+		t.Errorf("expected requests to be accepted after Start, got %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+package librariesio
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// limiter is a token bucket that allows bursts of up to burst requests
+// and refills at a sustained rate
+type limiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newLimiter(perMinute, burst int) *limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &limiter{
+		rate:   float64(perMinute) / 60,
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+}
+
+// WithRateLimit paces requests on the client side to a sustained rate of
+// perMinute requests, while allowing short bursts of up to burst requests
+// when the client has been idle
+func WithRateLimit(perMinute, burst int) ClientOption {
+	return func(c *Client) {
+		if perMinute > 0 {
+			c.limiter = newLimiter(perMinute, burst)
+		}
+	}
+}
+
+// SharedLimiter paces the requests of every client constructed with it
+// against one budget, so the total rate of a process stays predictable
+// when it uses several clients, e.g. with different API keys or base URLs
+type SharedLimiter struct {
+	l *limiter
+}
+
+// NewSharedLimiter returns a limiter allowing a sustained rate of
+// perMinute requests with bursts of up to burst requests
+func NewSharedLimiter(perMinute, burst int) *SharedLimiter {
+	return &SharedLimiter{l: newLimiter(perMinute, burst)}
+}
+
+// Wait blocks until a request may be sent or ctx is done,
+// it can be used to pace traffic sent outside of a Client
+func (s *SharedLimiter) Wait(ctx context.Context) error {
+	return s.l.wait(ctx)
+}
+
+// WithSharedLimiter makes the client wait for l before every request.
+// It can be combined with WithRateLimit, requests then wait for both.
+func WithSharedLimiter(l *SharedLimiter) ClientOption {
+	return func(c *Client) {
+		c.shared = l
+	}
+}
+
+// wait blocks until a request may be sent or ctx is done
+func (l *limiter) wait(ctx context.Context) error {
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token and returns how long to wait until it is available
+func (l *limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	t := time.Now()
+	if !l.last.IsZero() {
+		l.tokens += t.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = t
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token that was not used
+func (l *limiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens++
+}
+
+// duration returns how long sending n requests takes from now on,
+// without taking tokens
+func (l *limiter) duration(n int) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	tokens := l.tokens
+	if !l.last.IsZero() {
+		tokens += time.Since(l.last).Seconds() * l.rate
+		if tokens > l.burst {
+			tokens = l.burst
+		}
+	}
+
+	missing := float64(n) - tokens
+	if missing <= 0 {
+		return 0
+	}
+	return time.Duration(missing / l.rate * float64(time.Second))
+}
+
+// pace waits for the limiters of the client before a request is sent
+func (c *Client) pace(ctx context.Context) error {
+	if c.limiter != nil {
+		if err := c.limiter.wait(ctx); err != nil {
+			return err
+		}
+	}
+	if c.shared != nil {
+		return c.shared.Wait(ctx)
+	}
+	return nil
+}
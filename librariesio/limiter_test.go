@@ -0,0 +1,94 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	l := newLimiter(600, 3)
+
+	for i := 0; i < 3; i++ {
+		if delay := l.reserve(); delay != 0 {
+			t.Fatalf("request %d within burst delayed by %v", i, delay)
+		}
+	}
+
+	// 600 per minute refills a token every 100ms
+	if delay := l.reserve(); delay <= 50*time.Millisecond || delay > 100*time.Millisecond {
+		t.Errorf("expected request after burst to wait about 100ms, got %v", delay)
+	}
+	if delay := l.reserve(); delay <= 150*time.Millisecond || delay > 200*time.Millisecond {
+		t.Errorf("expected next request to wait about 200ms, got %v", delay)
+	}
+}
+
+func TestLimiter_cancel(t *testing.T) {
+	l := newLimiter(1, 1)
+	l.reserve()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := l.wait(ctx); err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if l.tokens < 0 || l.tokens > 0.01 {
+		t.Errorf("expected cancelled reservation to be returned, got %v tokens", l.tokens)
+	}
+}
+
+func TestWithRateLimit(t *testing.T) {
+	server, mux, url := startNewServer()
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	})
+
+	client := NewClient(APIKey, WithRateLimit(1200, 2))
+	client.BaseURL = url
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if _, _, err := client.Project(context.Background(), "npm", "ava"); err != nil {
+			t.Fatalf("Project returned unexpected error: %v", err)
+		}
+	}
+
+	// 2 requests burst, the other 2 are paced at 50ms each
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("expected requests to be paced, took %v", elapsed)
+	}
+}
+
+func TestWithSharedLimiter(t *testing.T) {
+	server, mux, url := startNewServer()
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	})
+
+	shared := NewSharedLimiter(1200, 2)
+
+	a := NewClient(APIKey, WithSharedLimiter(shared))
+	a.BaseURL = url
+	b := NewClient("5678", WithSharedLimiter(shared))
+	b.BaseURL = url
+
+	start := time.Now()
+	for _, client := range []*Client{a, b, a, b} {
+		if _, _, err := client.Project(context.Background(), "npm", "ava"); err != nil {
+			t.Fatalf("Project returned unexpected error: %v", err)
+		}
+	}
+
+	// Both clients draw from the same budget of 2 burst requests
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("expected requests of both clients to be paced, took %v", elapsed)
+	}
+}
@@ -0,0 +1,69 @@
+package librariesio
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// requestLogger logs requests sent by the client, successful
+// requests are sampled while failed requests are always logged
+type requestLogger struct {
+	logger *slog.Logger
+	every  uint64
+	count  atomic.Uint64
+}
+
+// WithLogger logs every request sent by the client to logger at info
+// level, failed requests are logged at error level
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger.logger = logger
+	}
+}
+
+// WithLogSampling only logs 1 in every n successful requests to the logger
+// configured with WithLogger, failed requests are always logged. Sampled
+// entries carry the sample rate so counts can be extrapolated.
+func WithLogSampling(n int) ClientOption {
+	return func(c *Client) {
+		if n > 0 {
+			c.logger.every = uint64(n)
+		}
+	}
+}
+
+// logRequest logs the outcome of req, resp is nil if no response was received
+func (c *Client) logRequest(ctx context.Context, req *http.Request, resp *http.Response, err error, start time.Time) {
+	l := &c.logger
+	if l.logger == nil {
+		return
+	}
+
+	level := slog.LevelInfo
+	msg := "libraries.io request"
+	if err != nil {
+		level = slog.LevelError
+		msg = "libraries.io request failed"
+	} else if l.every > 1 && (l.count.Add(1)-1)%l.every != 0 {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", redactAPIKey(req.URL).String()),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	} else if l.every > 1 {
+		attrs = append(attrs, slog.Uint64("sample_rate", l.every))
+	}
+
+	l.logger.LogAttrs(ctx, level, msg, attrs...)
+}
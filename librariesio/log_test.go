@@ -0,0 +1,52 @@
+package librariesio
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestWithLogger(t *testing.T) {
+	server, mux, url := startNewServer()
+	defer server.Close()
+
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"cookiecutter"}`)
+	})
+	mux.HandleFunc("/pypi/nope", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Not Found"}`, http.StatusNotFound)
+	})
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	client := NewClient(APIKey, WithLogger(logger), WithLogSampling(3))
+	client.BaseURL = url
+
+	for i := 0; i < 5; i++ {
+		client.Project(context.Background(), "pypi", "cookiecutter")
+	}
+	client.Project(context.Background(), "pypi", "nope")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 2 sampled and 1 failed request to be logged, got:\n%v", buf.String())
+	}
+
+	for _, line := range lines[:2] {
+		if !strings.Contains(line, "level=INFO") || !strings.Contains(line, "status=200") || !strings.Contains(line, "sample_rate=3") {
+			t.Errorf("unexpected log line %v", line)
+		}
+	}
+	if line := lines[2]; !strings.Contains(line, "level=ERROR") || !strings.Contains(line, "status=404") {
+		t.Errorf("unexpected log line %v", line)
+	}
+
+	if strings.Contains(buf.String(), "api_key="+APIKey) {
+		t.Errorf("expected API key to be redacted, got:\n%v", buf.String())
+	}
+}
@@ -0,0 +1,41 @@
+package librariesio
+
+import "sync"
+
+// Names of the counters reported to Metrics
+const (
+	MetricCacheHits      = "cache_hits"
+	MetricCacheMisses    = "cache_misses"
+	MetricCacheEvictions = "cache_evictions"
+)
+
+// Metrics receives counters from the client,
+// implementations must be safe for concurrent use
+type Metrics interface {
+	Add(name string, delta int64)
+}
+
+// Counters is an in-memory Metrics implementation
+type Counters struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+// Add increments the counter name by delta
+func (c *Counters) Add(name string, delta int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.counts == nil {
+		c.counts = make(map[string]int64)
+	}
+	c.counts[name] += delta
+}
+
+// Get returns the current value of the counter name
+func (c *Counters) Get(name string) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.counts[name]
+}
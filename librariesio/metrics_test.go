@@ -0,0 +1,27 @@
+package librariesio
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestCounters(t *testing.T) {
+	var counters Counters
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			counters.Add(MetricCacheHits, 2)
+		}()
+	}
+	wg.Wait()
+
+	if got := counters.Get(MetricCacheHits); got != 20 {
+		t.Errorf("expected 20, got %d", got)
+	}
+	if got := counters.Get(MetricCacheMisses); got != 0 {
+		t.Errorf("expected unknown counter to be 0, got %d", got)
+	}
+}
@@ -0,0 +1,229 @@
+package librariesio
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// mirrorWorkers is the number of pages MirrorDependents fetches at once
+const mirrorWorkers = 4
+
+// MirrorCheckpoint records how far MirrorDependents got for a project,
+// pages are the last pages stored
+type MirrorCheckpoint struct {
+	DependentsPage   int  `json:"dependents_page"`
+	DependentsDone   bool `json:"dependents_done"`
+	RepositoriesPage int  `json:"repositories_page"`
+	RepositoriesDone bool `json:"repositories_done"`
+}
+
+// Done reports whether all dependents and dependent repositories are stored
+func (cp MirrorCheckpoint) Done() bool {
+	return cp.DependentsDone && cp.RepositoriesDone
+}
+
+// MirrorStore receives the pages streamed by MirrorDependents. Pages of
+// a project are stored one at a time and in order, each together with
+// the checkpoint after it, so a store that saves both atomically can
+// always resume where it left off.
+type MirrorStore interface {
+	// Checkpoint returns the last checkpoint stored for ref,
+	// or the zero value if there is none
+	Checkpoint(ref ProjectRef) (MirrorCheckpoint, error)
+
+	// StoreDependents stores a page of projects depending on ref
+	StoreDependents(ref ProjectRef, projects []*Project, cp MirrorCheckpoint) error
+
+	// StoreDependentRepositories stores a page of repositories depending on ref
+	StoreDependentRepositories(ref ProjectRef, repos []*Repository, cp MirrorCheckpoint) error
+}
+
+// MirrorDependents streams all dependents and dependent repositories of
+// the project into store. Pages are fetched by a small pool of workers and
+// stored in order, a mirror that was interrupted continues after the last
+// checkpoint in store. Up to a few pages past the end of a list are
+// requested in vain, which is cheap compared to the lists of popular
+// packages this is meant for. The version of ref is ignored.
+//
+// Client.Shutdown makes it return ErrShutdown once the pages in flight
+// are stored, and waits for that. The store is flushed before returning
+// if it implements Flusher.
+//
+// It returns the checkpoint reached, which is Done unless err is set.
+func (c *Client) MirrorDependents(ctx context.Context, ref ProjectRef, store MirrorStore) (cp MirrorCheckpoint, err error) {
+	ref.Version = ""
+
+	ctx, finish, ok := c.background.enter(ctx)
+	if !ok {
+		return cp, ErrShutdown
+	}
+	defer finish()
+
+	if f, ok := store.(Flusher); ok {
+		defer func() {
+			if flushErr := f.Flush(); err == nil {
+				err = flushErr
+			}
+		}()
+	}
+
+	if cp, err = store.Checkpoint(ref); err != nil {
+		return cp, err
+	}
+
+	if !cp.DependentsDone {
+		err := c.mirrorPages(ctx, cp.DependentsPage+1, func(ctx context.Context, page int) (int, func(bool) error, error) {
+			projects, _, err := c.ProjectDependents(ctx, ref.Platform, ref.Name, &ListOptions{Page: page, PerPage: MaxPerPage})
+			return len(projects), func(done bool) error {
+				next := cp
+				next.DependentsPage, next.DependentsDone = page, done
+				if err := store.StoreDependents(ref, projects, next); err != nil {
+					return err
+				}
+				cp = next
+				return nil
+			}, err
+		})
+		if err != nil {
+			return cp, err
+		}
+	}
+
+	if !cp.RepositoriesDone {
+		err := c.mirrorPages(ctx, cp.RepositoriesPage+1, func(ctx context.Context, page int) (int, func(bool) error, error) {
+			repos, _, err := c.ProjectDependentRepositories(ctx, ref.Platform, ref.Name, &ListOptions{Page: page, PerPage: MaxPerPage})
+			return len(repos), func(done bool) error {
+				next := cp
+				next.RepositoriesPage, next.RepositoriesDone = page, done
+				if err := store.StoreDependentRepositories(ref, repos, next); err != nil {
+					return err
+				}
+				cp = next
+				return nil
+			}, err
+		})
+		if err != nil {
+			return cp, err
+		}
+	}
+
+	return cp, nil
+}
+
+// mirrorFetch fetches a page and returns the number of results and a
+// function storing them, done is set for the last page
+type mirrorFetch func(ctx context.Context, page int) (n int, store func(done bool) error, err error)
+
+// mirrorPages fetches windows of mirrorWorkers pages concurrently starting
+// at page and stores them in order until a page is not full, or until
+// the client is shut down
+func (c *Client) mirrorPages(ctx context.Context, page int, fetch mirrorFetch) error {
+	type result struct {
+		n     int
+		store func(bool) error
+		err   error
+	}
+
+	for first := true; ; first = false {
+		if c.background.stopping() {
+			return ErrShutdown
+		}
+		if !first {
+			if err := c.waitForNextPage(ctx); err != nil {
+				return err
+			}
+		}
+
+		results := make([]result, mirrorWorkers)
+
+		var wg sync.WaitGroup
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				r := &results[i]
+				r.n, r.store, r.err = fetch(ctx, page+i)
+			}(i)
+		}
+		wg.Wait()
+
+		for _, r := range results {
+			if r.err != nil {
+				return r.err
+			}
+			done := r.n < MaxPerPage
+			if err := r.store(done); err != nil {
+				return err
+			}
+			if done {
+				return nil
+			}
+		}
+
+		page += mirrorWorkers
+	}
+}
+
+// MemoryMirrorStore is an in-memory MirrorStore, safe for concurrent use
+type MemoryMirrorStore struct {
+	mu          sync.Mutex
+	checkpoints map[ProjectRef]MirrorCheckpoint
+	dependents  map[ProjectRef][]*Project
+	repos       map[ProjectRef][]*Repository
+}
+
+// NewMemoryMirrorStore returns an empty store
+func NewMemoryMirrorStore() *MemoryMirrorStore {
+	return &MemoryMirrorStore{
+		checkpoints: make(map[ProjectRef]MirrorCheckpoint),
+		dependents:  make(map[ProjectRef][]*Project),
+		repos:       make(map[ProjectRef][]*Repository),
+	}
+}
+
+// Checkpoint returns the last checkpoint stored for ref
+func (s *MemoryMirrorStore) Checkpoint(ref ProjectRef) (MirrorCheckpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.checkpoints[mirrorKey(ref)], nil
+}
+
+// StoreDependents appends the projects to the dependents of ref
+func (s *MemoryMirrorStore) StoreDependents(ref ProjectRef, projects []*Project, cp MirrorCheckpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := mirrorKey(ref)
+	s.dependents[key] = append(s.dependents[key], projects...)
+	s.checkpoints[key] = cp
+	return nil
+}
+
+// StoreDependentRepositories appends the repositories to the dependent
+// repositories of ref
+func (s *MemoryMirrorStore) StoreDependentRepositories(ref ProjectRef, repos []*Repository, cp MirrorCheckpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := mirrorKey(ref)
+	s.repos[key] = append(s.repos[key], repos...)
+	s.checkpoints[key] = cp
+	return nil
+}
+
+// Dependents returns the stored dependents of ref
+func (s *MemoryMirrorStore) Dependents(ref ProjectRef) []*Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dependents[mirrorKey(ref)]
+}
+
+// DependentRepositories returns the stored dependent repositories of ref
+func (s *MemoryMirrorStore) DependentRepositories(ref ProjectRef) []*Repository {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.repos[mirrorKey(ref)]
+}
+
+func mirrorKey(ref ProjectRef) ProjectRef {
+	return ProjectRef{Platform: strings.ToLower(ref.Platform), Name: normalizeName(ref.Platform, ref.Name)}
+}
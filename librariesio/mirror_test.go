@@ -0,0 +1,240 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// handleDependents serves total items in pages of per_page for path,
+// formatted by item, and returns the requested pages
+func handleDependents(mux *http.ServeMux, path string, total int, item func(i int) string) func() []int {
+	var mu sync.Mutex
+	var pages []int
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+
+		mu.Lock()
+		pages = append(pages, page)
+		mu.Unlock()
+
+		var items []string
+		for i := (page - 1) * perPage; i < total && i < page*perPage; i++ {
+			items = append(items, item(i))
+		}
+		fmt.Fprintf(w, "[%v]", strings.Join(items, ","))
+	})
+	return func() []int {
+		mu.Lock()
+		defer mu.Unlock()
+		sort.Ints(pages)
+		return pages
+	}
+}
+
+func TestMirrorDependents(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	dependentPages := handleDependents(mux, "/npm/left-pad/dependents", 250, func(i int) string {
+		return fmt.Sprintf(`{"name":"p%d"}`, i)
+	})
+	repoPages := handleDependents(mux, "/npm/left-pad/dependent_repositories", 30, func(i int) string {
+		return fmt.Sprintf(`{"full_name":"o/r%d"}`, i)
+	})
+
+	store := NewMemoryMirrorStore()
+	ref := ProjectRef{Platform: "npm", Name: "left-pad", Version: "1.3.0"}
+
+	cp, err := client.MirrorDependents(context.Background(), ref, store)
+	if err != nil {
+		t.Fatalf("MirrorDependents returned unexpected error: %v", err)
+	}
+
+	want := MirrorCheckpoint{DependentsPage: 3, DependentsDone: true, RepositoriesPage: 1, RepositoriesDone: true}
+	if cp != want || !cp.Done() {
+		t.Errorf("\nExpected %+v\nGot %+v", want, cp)
+	}
+
+	dependents := store.Dependents(ProjectRef{Platform: "NPM", Name: "left-pad"})
+	if len(dependents) != 250 {
+		t.Fatalf("expected 250 dependents, got %d", len(dependents))
+	}
+	for i, p := range dependents {
+		if got, want := stringValue(p.Name), fmt.Sprintf("p%d", i); got != want {
+			t.Fatalf("dependent %d is %v, want %v", i, got, want)
+		}
+	}
+	if repos := store.DependentRepositories(ref); len(repos) != 30 {
+		t.Errorf("expected 30 dependent repositories, got %d", len(repos))
+	}
+
+	if want := []int{1, 2, 3, 4}; !reflect.DeepEqual(dependentPages(), want) {
+		t.Errorf("\nExpected %v\nGot %v", want, dependentPages())
+	}
+	if want := []int{1, 2, 3, 4}; !reflect.DeepEqual(repoPages(), want) {
+		t.Errorf("\nExpected %v\nGot %v", want, repoPages())
+	}
+
+	// A finished mirror is not fetched again
+	if _, err := client.MirrorDependents(context.Background(), ref, store); err != nil {
+		t.Fatalf("MirrorDependents returned unexpected error: %v", err)
+	}
+	if got := len(dependentPages()); got != 4 {
+		t.Errorf("expected no further requests, got %d", got)
+	}
+}
+
+// failingMirrorStore fails to store the given dependents page
+type failingMirrorStore struct {
+	*MemoryMirrorStore
+	failPage int
+}
+
+func (s *failingMirrorStore) StoreDependents(ref ProjectRef, projects []*Project, cp MirrorCheckpoint) error {
+	if cp.DependentsPage == s.failPage {
+		return errors.New("disk full")
+	}
+	return s.MemoryMirrorStore.StoreDependents(ref, projects, cp)
+}
+
+func TestMirrorDependents_resume(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	dependentPages := handleDependents(mux, "/npm/left-pad/dependents", 450, func(i int) string {
+		return fmt.Sprintf(`{"name":"p%d"}`, i)
+	})
+	handleDependents(mux, "/npm/left-pad/dependent_repositories", 0, nil)
+
+	ref := ProjectRef{Platform: "npm", Name: "left-pad"}
+	store := &failingMirrorStore{MemoryMirrorStore: NewMemoryMirrorStore(), failPage: 3}
+
+	cp, err := client.MirrorDependents(context.Background(), ref, store)
+	if err == nil {
+		t.Fatal("expected the error of the store")
+	}
+	if want := (MirrorCheckpoint{DependentsPage: 2}); cp != want {
+		t.Errorf("\nExpected %+v\nGot %+v", want, cp)
+	}
+
+	store.failPage = 0
+	if cp, err = client.MirrorDependents(context.Background(), ref, store); err != nil {
+		t.Fatalf("MirrorDependents returned unexpected error: %v", err)
+	}
+	if cp.DependentsPage != 5 || !cp.Done() {
+		t.Errorf("unexpected checkpoint %+v", cp)
+	}
+
+	if got := len(store.Dependents(ref)); got != 450 {
+		t.Errorf("expected 450 dependents, got %d", got)
+	}
+	// The second run starts at page 3
+	if want := []int{1, 2, 3, 3, 4, 4, 5, 6}; !reflect.DeepEqual(dependentPages(), want) {
+		t.Errorf("\nExpected %v\nGot %v", want, dependentPages())
+	}
+}
+
+func TestMirrorDependents_notFound(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/npm/nope/dependents", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Not Found"}`, http.StatusNotFound)
+	})
+
+	_, err := client.MirrorDependents(context.Background(), ProjectRef{Platform: "npm", Name: "nope"}, NewMemoryMirrorStore())
+	if !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("expected ErrProjectNotFound, got %v", err)
+	}
+}
+
+// flushingMirrorStore counts the calls to Flush
+type flushingMirrorStore struct {
+	*MemoryMirrorStore
+	flushes int
+}
+
+func (s *flushingMirrorStore) Flush() error {
+	s.flushes++
+	return nil
+}
+
+func TestMirrorDependents_shutdown(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	started := make(chan struct{}, mirrorWorkers)
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var requested []int
+	mux.HandleFunc("/npm/left-pad/dependents", func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		mu.Lock()
+		requested = append(requested, page)
+		mu.Unlock()
+		started <- struct{}{}
+		<-release
+		fmt.Fprintf(w, "[%v]", strings.TrimSuffix(strings.Repeat(`{"name":"p"},`, MaxPerPage), ","))
+	})
+
+	ref := ProjectRef{Platform: "npm", Name: "left-pad"}
+	store := &flushingMirrorStore{MemoryMirrorStore: NewMemoryMirrorStore()}
+
+	type result struct {
+		cp  MirrorCheckpoint
+		err error
+	}
+	mirrored := make(chan result)
+	go func() {
+		cp, err := client.MirrorDependents(context.Background(), ref, store)
+		mirrored <- result{cp, err}
+	}()
+	for i := 0; i < mirrorWorkers; i++ {
+		<-started
+	}
+
+	done := make(chan error)
+	go func() { done <- client.Shutdown(context.Background()) }()
+	select {
+	case err := <-done:
+		t.Fatalf("Shutdown returned before the pages in flight were stored: %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+
+	r := <-mirrored
+	if !errors.Is(r.err, ErrShutdown) {
+		t.Errorf("expected ErrShutdown, got %v", r.err)
+	}
+	if want := (MirrorCheckpoint{DependentsPage: mirrorWorkers}); r.cp != want {
+		t.Errorf("\nExpected %+v\nGot %+v", want, r.cp)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Shutdown returned unexpected error: %v", err)
+	}
+	if store.flushes != 1 || len(requested) != mirrorWorkers {
+		t.Errorf("expected 1 flush and %d requests, got %d and %v", mirrorWorkers, store.flushes, requested)
+	}
+
+	if _, err := client.MirrorDependents(context.Background(), ref, store); !errors.Is(err, ErrShutdown) {
+		t.Errorf("expected ErrShutdown after Shutdown, got %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package librariesio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// EventType identifies the kind of change an Event describes
+type EventType string
+
+// Event types emitted when monitoring projects
+const (
+	EventNewRelease     EventType = "new_release"
+	EventReleaseRemoved EventType = "release_removed"
+)
+
+// Event describes a change detected for a project on libraries.io
+type Event struct {
+	Type     EventType
+	Platform string
+	Name     string
+	Version  string
+	Time     time.Time
+	Message  string
+}
+
+// String returns a short human readable summary of the Event
+func (e Event) String() string {
+	s := fmt.Sprintf("%v/%v: %v", e.Platform, e.Name, strings.Replace(string(e.Type), "_", " ", -1))
+	if e.Version != "" {
+		s += " " + e.Version
+	}
+	if e.Message != "" {
+		s += " (" + e.Message + ")"
+	}
+	return s
+}
+
+// Notifier delivers events to humans, e.g. via chat or email
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+// NotifierFunc is an adapter to allow the use of ordinary functions as Notifier
+type NotifierFunc func(ctx context.Context, event Event) error
+
+// Notify calls f(ctx, event)
+func (f NotifierFunc) Notify(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+// SlackNotifier posts events to a Slack incoming webhook
+type SlackNotifier struct {
+	WebhookURL string
+
+	// HTTPClient is used to send the webhook request,
+	// http.DefaultClient is used if it is nil
+	HTTPClient *http.Client
+}
+
+// Notify posts the event as a plain text message to the webhook
+func (n *SlackNotifier) Notify(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(map[string]string{"text": event.String()})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, n.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	client := n.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if code := resp.StatusCode; code < 200 || code > 299 {
+		return fmt.Errorf("slack webhook returned %d", code)
+	}
+	return nil
+}
+
+// smtpSendMail is swapped out in tests
+var smtpSendMail = smtp.SendMail
+
+// SMTPNotifier sends events as plain text emails via an SMTP server
+type SMTPNotifier struct {
+	// Addr is the host:port of the SMTP server
+	Addr string
+	Auth smtp.Auth
+	From string
+	To   []string
+}
+
+// Notify sends the event as an email to all recipients.
+// Note that net/smtp does not support cancellation, the context
+// is only checked before the email is sent.
+func (n *SMTPNotifier) Notify(ctx context.Context, event Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %v\r\n", n.From)
+	fmt.Fprintf(&msg, "To: %v\r\n", strings.Join(n.To, ", "))
+	// The subject is encoded, as names or versions containing line
+	// breaks would otherwise add headers
+	fmt.Fprintf(&msg, "Subject: %v\r\n", mime.QEncoding.Encode("utf-8", "[libraries.io] "+event.String()))
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&msg, "%v\r\n", event)
+
+	return smtpSendMail(n.Addr, n.Auth, n.From, n.To, msg.Bytes())
+}
@@ -0,0 +1,124 @@
+package librariesio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"testing"
+)
+
+func TestEventString(t *testing.T) {
+	event := Event{
+		Type:     EventNewRelease,
+		Platform: "pypi",
+		Name:     "cookiecutter",
+		Version:  "1.5.1",
+	}
+
+	want := "pypi/cookiecutter: new release 1.5.1"
+	if got := event.String(); got != want {
+		t.Errorf("\nExpected %q\nGot %q", want, got)
+	}
+}
+
+func TestSlackNotifier(t *testing.T) {
+	server, mux, url := startNewServer()
+	defer server.Close()
+
+	var got map[string]string
+
+	mux.HandleFunc("/hook", func(w http.ResponseWriter, r *http.Request) {
+		if method := "POST"; method != r.Method {
+			t.Errorf("expected HTTP %v request, got %v", method, r.Method)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("unexpected payload: %v", err)
+		}
+	})
+
+	n := &SlackNotifier{WebhookURL: url.String() + "/hook"}
+	event := Event{Type: EventNewRelease, Platform: "npm", Name: "ava", Version: "0.19.0"}
+
+	if err := n.Notify(context.Background(), event); err != nil {
+		t.Fatalf("Notify returned unexpected error: %v", err)
+	}
+
+	if want := "npm/ava: new release 0.19.0"; got["text"] != want {
+		t.Errorf("\nExpected %q\nGot %q", want, got["text"])
+	}
+}
+
+func TestSlackNotifier_badStatus(t *testing.T) {
+	server, mux, url := startNewServer()
+	defer server.Close()
+
+	mux.HandleFunc("/hook", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	})
+
+	n := &SlackNotifier{WebhookURL: url.String() + "/hook"}
+
+	if err := n.Notify(context.Background(), Event{}); err == nil {
+		t.Fatal("Expected error to be returned")
+	}
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	defer func(f func(string, smtp.Auth, string, []string, []byte) error) {
+		smtpSendMail = f
+	}(smtpSendMail)
+
+	var gotAddr string
+	var gotMsg []byte
+
+	smtpSendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr = addr
+		gotMsg = msg
+		return nil
+	}
+
+	n := &SMTPNotifier{
+		Addr: "mail.example.com:25",
+		From: "bot@example.com",
+		To:   []string{"dev@example.com"},
+	}
+	event := Event{Type: EventNewRelease, Platform: "pypi", Name: "poyo", Version: "0.4.1"}
+
+	if err := n.Notify(context.Background(), event); err != nil {
+		t.Fatalf("Notify returned unexpected error: %v", err)
+	}
+
+	if gotAddr != n.Addr {
+		t.Errorf("sent mail to %v, want %v", gotAddr, n.Addr)
+	}
+	if want := "Subject: [libraries.io] pypi/poyo: new release 0.4.1"; !strings.Contains(string(gotMsg), want) {
+		t.Errorf("message does not contain %q\n%s", want, gotMsg)
+	}
+}
+
+func TestSMTPNotifier_headerInjection(t *testing.T) {
+	defer func(f func(string, smtp.Auth, string, []string, []byte) error) {
+		smtpSendMail = f
+	}(smtpSendMail)
+
+	var gotMsg []byte
+	smtpSendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotMsg = msg
+		return nil
+	}
+
+	n := &SMTPNotifier{Addr: "mail.example.com:25", From: "bot@example.com", To: []string{"dev@example.com"}}
+	event := Event{Type: EventNewRelease, Platform: "npm", Name: "poyo\r\nBcc: victim@example.com", Version: "0.4.1"}
+
+	if err := n.Notify(context.Background(), event); err != nil {
+		t.Fatalf("Notify returned unexpected error: %v", err)
+	}
+
+	header := string(gotMsg[:bytes.Index(gotMsg, []byte("\r\n\r\n"))])
+	if strings.Contains(header, "\r\nBcc:") {
+		t.Errorf("expected line breaks in the subject to be encoded\n%s", header)
+	}
+}
@@ -0,0 +1,144 @@
+package librariesio
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ClientOption configures a Client in NewClient
+type ClientOption func(*Client)
+
+// WithDialContext sets the function used by the client's transport
+// to create network connections
+func WithDialContext(dial func(ctx context.Context, network, addr string) (net.Conn, error)) ClientOption {
+	return func(c *Client) {
+		c.transport.DialContext = dial
+	}
+}
+
+// WithDNSResolver makes the client's transport look up hosts
+// with the given resolver
+func WithDNSResolver(resolver *net.Resolver) ClientOption {
+	dialer := &net.Dialer{
+		Resolver:  resolver,
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	return WithDialContext(dialer.DialContext)
+}
+
+// WithProvider looks up projects, their dependencies and search results
+// with the given provider instead of the libraries.io API, e.g.
+// NewEcosystemsProvider(nil). It applies to Project, ProjectDeps and
+// Search and every method built on them, and is returned by
+// Client.Provider. Search fails with ErrNotSupported if the provider
+// cannot search.
+func WithProvider(p Provider) ClientOption {
+	return func(c *Client) {
+		c.provider = p
+	}
+}
+
+// WithFallbackProvider looks up projects, their dependencies and search
+// results with the libraries.io API first and with the given providers if
+// it fails, e.g. while libraries.io is down, see FallbackProvider and
+// WithProvider
+func WithFallbackProvider(providers ...Provider) ClientOption {
+	return func(c *Client) {
+		c.provider = FallbackProvider(append([]Provider{&librariesIOProvider{client: c}}, providers...)...)
+	}
+}
+
+// WithDefaultCallTimeout sets a deadline of d for every call
+// whose context has no deadline
+func WithDefaultCallTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.defaultTimeout = d
+	}
+}
+
+// DecodeHook is called with every value decoded from a response, obj is
+// the pointer passed to Do, e.g. *Project or *[]*Project. It can modify
+// the value in place and fails the call by returning an error.
+type DecodeHook func(obj interface{}) error
+
+// WithDecodeHook registers hooks that run in order after every response
+// is unmarshalled, to clean up data without wrapping every call
+func WithDecodeHook(hooks ...DecodeHook) ClientOption {
+	return func(c *Client) {
+		c.decodeHooks = append(c.decodeHooks, hooks...)
+	}
+}
+
+// ProjectDecodeHook returns a DecodeHook that calls fn for every project
+// decoded from a response, including the projects of a list
+func ProjectDecodeHook(fn func(*Project)) DecodeHook {
+	return func(obj interface{}) error {
+		switch v := obj.(type) {
+		case *Project:
+			fn(v)
+		case *[]*Project:
+			for _, p := range *v {
+				if p != nil {
+					fn(p)
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// RequestOption configures how Do handles a single request
+type RequestOption func(*requestConfig)
+
+type requestConfig struct {
+	rawBody   bool
+	replaying bool
+	deferred  **FailedRequest
+	priority  Priority
+	scheduled bool
+
+	revalidating bool
+	noCache      bool
+
+	ifNoneMatch     string
+	ifModifiedSince string
+
+	header  http.Header
+	timeout time.Duration
+	apiKey  string
+}
+
+func (cfg *requestConfig) conditional() bool {
+	return cfg.ifNoneMatch != "" || cfg.ifModifiedSince != ""
+}
+
+// WithRawBody makes Do attach the undecoded response body
+// to the Raw field of the returned Response
+func WithRawBody() RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.rawBody = true
+	}
+}
+
+// WithIfNoneMatch makes the request conditional on the resource no longer
+// matching etag, as returned in Response.ETag. If it is unchanged, the API
+// responds with 304 Not Modified, which is returned without an error as a
+// Response with NotModified set, see there for the value returned.
+// Conditional requests are never served from the cache set with WithCache.
+func WithIfNoneMatch(etag string) RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.ifNoneMatch = etag
+	}
+}
+
+// WithIfModifiedSince makes the request conditional on the resource being
+// modified after lastModified, as returned in Response.LastModified. It
+// behaves like WithIfNoneMatch otherwise.
+func WithIfModifiedSince(lastModified string) RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.ifModifiedSince = lastModified
+	}
+}
@@ -0,0 +1,219 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hackebrot/go-repr/repr"
+)
+
+func TestWithDialContext(t *testing.T) {
+	server, mux, serverURL := startNewServer()
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"cookiecutter"}`)
+	})
+
+	var dialed string
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialed = addr
+		var d net.Dialer
+		return d.DialContext(ctx, network, serverURL.Host)
+	}
+
+	client := NewClient(APIKey, WithDialContext(dial))
+	client.BaseURL = &url.URL{Scheme: "http", Host: "libraries.example:80", Path: "/"}
+
+	if _, _, err := client.Project(context.Background(), "pypi", "cookiecutter"); err != nil {
+		t.Fatalf("Project returned unexpected error: %v", err)
+	}
+
+	if want := "libraries.example:80"; dialed != want {
+		t.Errorf("dialed %q, want %q", dialed, want)
+	}
+}
+
+func TestWithDNSResolver(t *testing.T) {
+	client := NewClient(APIKey, WithDNSResolver(&net.Resolver{PreferGo: true}))
+
+	if client.transport.DialContext == nil {
+		t.Error("WithDNSResolver did not configure the transport")
+	}
+}
+
+func TestWithDecodeHook(t *testing.T) {
+	server, mux, serverURL := startNewServer()
+	defer server.Close()
+
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"cookiecutter","description":"  <b>Templates</b> "}`)
+	})
+	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"name":"a","description":" a "},{"name":"b"}]`)
+	})
+
+	trim := ProjectDecodeHook(func(p *Project) {
+		if p.Description != nil {
+			p.Description = String(strings.TrimSpace(*p.Description))
+		}
+	})
+
+	var calls int
+	count := func(obj interface{}) error {
+		calls++
+		return nil
+	}
+
+	client := NewClient(APIKey, WithDecodeHook(trim, count))
+	client.BaseURL = serverURL
+
+	project, _, err := client.Project(context.Background(), "pypi", "cookiecutter")
+	if err != nil {
+		t.Fatalf("Project returned unexpected error: %v", err)
+	}
+	if got, want := *project.Description, "<b>Templates</b>"; got != want {
+		t.Errorf("\nExpected %q\nGot %q", want, got)
+	}
+
+	projects, _, err := client.Search(context.Background(), "a", nil)
+	if err != nil {
+		t.Fatalf("Search returned unexpected error: %v", err)
+	}
+	if got, want := *projects[0].Description, "a"; got != want {
+		t.Errorf("\nExpected %q\nGot %q", want, got)
+	}
+
+	if calls != 2 {
+		t.Errorf("expected hook to be called twice, got %d", calls)
+	}
+}
+
+func TestWithDecodeHook_error(t *testing.T) {
+	server, mux, serverURL := startNewServer()
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"cookiecutter"}`)
+	})
+
+	hookErr := errors.New("invalid project")
+	client := NewClient(APIKey, WithDecodeHook(func(obj interface{}) error { return hookErr }))
+	client.BaseURL = serverURL
+
+	if _, _, err := client.Project(context.Background(), "pypi", "cookiecutter"); err != hookErr {
+		t.Errorf("expected hook error, got %v", err)
+	}
+}
+
+func TestWithDefaultCallTimeout(t *testing.T) {
+	server, mux, serverURL := startNewServer()
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	client := NewClient(APIKey, WithDefaultCallTimeout(10*time.Millisecond))
+	client.BaseURL = serverURL
+
+	_, _, err := client.Project(context.Background(), "pypi", "cookiecutter")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestWithDefaultCallTimeout_callerDeadline(t *testing.T) {
+	server, mux, serverURL := startNewServer()
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		fmt.Fprint(w, `{"name":"cookiecutter"}`)
+	})
+
+	client := NewClient(APIKey, WithDefaultCallTimeout(time.Millisecond))
+	client.BaseURL = serverURL
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if _, _, err := client.Project(ctx, "pypi", "cookiecutter"); err != nil {
+		t.Errorf("expected deadline of the caller to be used, got %v", err)
+	}
+}
+
+func TestWithIfNoneMatch(t *testing.T) {
+	server, mux, serverURL := startNewServer()
+	defer server.Close()
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` || r.Header.Get("If-Modified-Since") == "Wed, 01 May 2024 00:00:00 GMT" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Wed, 01 May 2024 00:00:00 GMT")
+		fmt.Fprint(w, `{"name":"cookiecutter"}`)
+	}
+	mux.HandleFunc("/pypi/cookiecutter", handler)
+	mux.HandleFunc("/pypi/cookiecutter/latest/dependencies", handler)
+
+	client := NewClient(APIKey, WithCache(NewLRUCache(1<<20, nil)))
+	client.BaseURL = serverURL
+	ctx := context.Background()
+
+	req, err := client.NewRequest("GET", "pypi/cookiecutter", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned unexpected error: %v", err)
+	}
+
+	resp, err := client.Do(ctx, req, new(Project))
+	if err != nil {
+		t.Fatalf("Do returned unexpected error: %v", err)
+	}
+	if resp.ETag != `"v1"` || resp.LastModified != "Wed, 01 May 2024 00:00:00 GMT" {
+		t.Errorf("unexpected validators %q and %q", resp.ETag, resp.LastModified)
+	}
+
+	for _, opt := range []RequestOption{WithIfNoneMatch(resp.ETag), WithIfModifiedSince(resp.LastModified)} {
+		project := new(Project)
+		resp, err := client.Do(ctx, req, project, opt)
+		if err != nil || resp.StatusCode != http.StatusNotModified || !resp.NotModified {
+			t.Errorf("expected 304 Not Modified, got %v", err)
+		}
+		if stringValue(project.Name) != "cookiecutter" {
+			t.Errorf("expected the cached project, got %v", repr.Repr(project))
+		}
+	}
+
+	// Without a cache the value passed to Do is kept
+	client = NewClient(APIKey)
+	client.BaseURL = serverURL
+	project := &Project{Name: String("previous")}
+	resp, err = client.Do(ctx, req, project, WithIfNoneMatch(`"v1"`))
+	if err != nil || !resp.NotModified || stringValue(project.Name) != "previous" {
+		t.Errorf("expected the previous project to be kept, got %v (%v)", repr.Repr(project), err)
+	}
+
+	// Without a cache typed methods return no project
+	project, resp, err = client.Project(NewContext(ctx, WithIfNoneMatch(`"v1"`)), "pypi", "cookiecutter")
+	if err != nil || !resp.NotModified || project != nil {
+		t.Errorf("expected no project, got %v (%v)", repr.Repr(project), err)
+	}
+	project, resp, err = client.ProjectDepsFiltered(NewContext(ctx, WithIfNoneMatch(`"v1"`)), "pypi", "cookiecutter", "latest", &DependencyFilter{})
+	if err != nil || !resp.NotModified || project != nil {
+		t.Errorf("expected no project, got %v (%v)", repr.Repr(project), err)
+	}
+
+	if req.Header.Get("If-None-Match") != "" {
+		t.Error("expected the request passed to Do not to be modified")
+	}
+}
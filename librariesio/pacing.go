@@ -0,0 +1,105 @@
+package librariesio
+
+import "time"
+
+// PacingProfile bundles the settings that control how hard a client
+// uses the API, see WithPacingProfile
+type PacingProfile int
+
+const (
+	// ProfileDefault suits most tools, it stays within the documented
+	// limit of 60 requests per minute and spreads out paginated calls
+	ProfileDefault PacingProfile = iota
+
+	// ProfileAggressive allows more concurrency and longer bursts
+	// for short interactive sessions
+	ProfileAggressive
+
+	// ProfilePolite is for long running crawls that share the API
+	// key with other traffic, it uses half of the rate limit
+	ProfilePolite
+)
+
+// PacingSettings are the settings applied by a PacingProfile
+type PacingSettings struct {
+	// RequestsPerMinute and Burst configure WithRateLimit
+	RequestsPerMinute int
+	Burst             int
+
+	// Concurrency is the number of requests a Scheduler sends at once
+	Concurrency int
+
+	// Retry sets Client.Retry, MaxRateLimitWait bounds its wait,
+	// see WithMaxRateLimitWait
+	Retry            bool
+	MaxRateLimitWait time.Duration
+
+	// RateSmoothing enables WithRateSmoothing
+	RateSmoothing bool
+
+	// BackgroundReserve configures WithBackgroundReserve
+	BackgroundReserve int
+}
+
+var pacingSettings = map[PacingProfile]PacingSettings{
+	ProfileDefault: {
+		RequestsPerMinute: 60,
+		Burst:             10,
+		Concurrency:       4,
+		Retry:             true,
+		MaxRateLimitWait:  time.Minute,
+		RateSmoothing:     true,
+		BackgroundReserve: 5,
+	},
+	ProfileAggressive: {
+		RequestsPerMinute: 60,
+		Burst:             30,
+		Concurrency:       8,
+		Retry:             true,
+		MaxRateLimitWait:  10 * time.Second,
+	},
+	ProfilePolite: {
+		RequestsPerMinute: 30,
+		Burst:             1,
+		Concurrency:       1,
+		Retry:             true,
+		MaxRateLimitWait:  5 * time.Minute,
+		RateSmoothing:     true,
+		BackgroundReserve: 10,
+	},
+}
+
+// Settings returns the settings of the profile,
+// unknown profiles use the settings of ProfileDefault
+func (p PacingProfile) Settings() PacingSettings {
+	if settings, ok := pacingSettings[p]; ok {
+		return settings
+	}
+	return pacingSettings[ProfileDefault]
+}
+
+// String returns the name of the profile
+func (p PacingProfile) String() string {
+	switch p {
+	case ProfileAggressive:
+		return "aggressive"
+	case ProfilePolite:
+		return "polite"
+	}
+	return "default"
+}
+
+// WithPacingProfile configures rate limiting, concurrency, retries and
+// backoff of the client with the settings of the given profile. Options
+// given after it override single settings.
+func WithPacingProfile(p PacingProfile) ClientOption {
+	settings := p.Settings()
+	return func(c *Client) {
+		c.limiter = newLimiter(settings.RequestsPerMinute, settings.Burst)
+		c.scheduler = NewScheduler(settings.Concurrency, nil, nil)
+		c.Retry = settings.Retry
+		c.maxRateLimitWait = settings.MaxRateLimitWait
+		c.smoothPages = settings.RateSmoothing
+		c.backgroundReserve = settings.BackgroundReserve
+	}
+}
@@ -0,0 +1,41 @@
+package librariesio
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithPacingProfile(t *testing.T) {
+	for _, profile := range []PacingProfile{ProfileDefault, ProfileAggressive, ProfilePolite} {
+		settings := profile.Settings()
+		client := NewClient(APIKey, WithPacingProfile(profile))
+
+		if client.limiter == nil || client.limiter.rate != float64(settings.RequestsPerMinute)/60 || client.limiter.burst != float64(settings.Burst) {
+			t.Errorf("%v: unexpected limiter %+v", profile, client.limiter)
+		}
+		if client.scheduler == nil || client.scheduler.free != settings.Concurrency {
+			t.Errorf("%v: expected scheduler with concurrency %d", profile, settings.Concurrency)
+		}
+		if client.Retry != settings.Retry || client.maxRateLimitWait != settings.MaxRateLimitWait {
+			t.Errorf("%v: unexpected retry settings %v, %v", profile, client.Retry, client.maxRateLimitWait)
+		}
+		if client.smoothPages != settings.RateSmoothing || client.backgroundReserve != settings.BackgroundReserve {
+			t.Errorf("%v: unexpected smoothing %v and reserve %d", profile, client.smoothPages, client.backgroundReserve)
+		}
+	}
+
+	if settings := ProfilePolite.Settings(); settings.RequestsPerMinute >= ProfileDefault.Settings().RequestsPerMinute {
+		t.Errorf("expected polite profile to send fewer requests, got %+v", settings)
+	}
+}
+
+func TestWithPacingProfile_override(t *testing.T) {
+	client := NewClient(APIKey, WithPacingProfile(ProfilePolite), WithMaxRateLimitWait(time.Second))
+
+	if client.maxRateLimitWait != time.Second {
+		t.Errorf("expected later options to override the profile, got %v", client.maxRateLimitWait)
+	}
+	if client.backgroundReserve != ProfilePolite.Settings().BackgroundReserve {
+		t.Errorf("expected other settings of the profile to be kept, got %d", client.backgroundReserve)
+	}
+}
@@ -0,0 +1,75 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+)
+
+// Page size limits of the libraries.io API
+const (
+	DefaultPerPage = 30
+	MaxPerPage     = 100
+)
+
+// ListOptions specifies the pagination of endpoints returning lists
+type ListOptions struct {
+	// Page is the page to request, starting at 1
+	Page int `url:"page,omitempty"`
+
+	// PerPage is the number of results per page, it defaults to
+	// DefaultPerPage, or MaxPerPage for methods that fetch all pages
+	// such as SearchAll, and may not exceed MaxPerPage
+	PerPage int `url:"per_page,omitempty"`
+}
+
+// PerPageError is returned for ListOptions with an invalid PerPage value
+type PerPageError struct {
+	PerPage int
+}
+
+// Error returns information about the allowed range
+func (e *PerPageError) Error() string {
+	return fmt.Sprintf("per_page must be between 1 and %d, got %d", MaxPerPage, e.PerPage)
+}
+
+// normalize validates the options and returns a copy
+// with the default page size applied
+func (o ListOptions) normalize() (ListOptions, error) {
+	if o.PerPage == 0 {
+		o.PerPage = DefaultPerPage
+	}
+	if o.PerPage < 0 || o.PerPage > MaxPerPage {
+		return o, &PerPageError{PerPage: o.PerPage}
+	}
+	if o.Page < 0 {
+		return o, fmt.Errorf("page must not be negative, got %d", o.Page)
+	}
+	return o, nil
+}
+
+// normalizeAll is normalize for methods that fetch all pages, they
+// default to the largest page size to use as few requests as possible
+func (o ListOptions) normalizeAll() (ListOptions, error) {
+	if o.PerPage == 0 {
+		o.PerPage = MaxPerPage
+	}
+	return o.normalize()
+}
+
+// WithRateSmoothing makes methods that fetch all pages of a list wait
+// between pages, so the remaining rate limit reported by the API is
+// spread evenly until it resets instead of being used up at once
+func WithRateSmoothing() ClientOption {
+	return func(c *Client) {
+		c.smoothPages = true
+	}
+}
+
+// waitForNextPage is called by auto-paginating methods before
+// fetching the next page
+func (c *Client) waitForNextPage(ctx context.Context) error {
+	if !c.smoothPages {
+		return ctx.Err()
+	}
+	return sleepContext(ctx, c.rate.pageDelay(now()))
+}
@@ -0,0 +1,220 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSearch_pagination(t *testing.T) {
+	testCases := []struct {
+		name    string
+		opts    *SearchOptions
+		page    string
+		perPage string
+	}{
+		{"default", nil, "", "30"},
+		{"page", &SearchOptions{ListOptions: ListOptions{Page: 2}}, "2", "30"},
+		{"per_page", &SearchOptions{ListOptions: ListOptions{Page: 3, PerPage: 100}}, "3", "100"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server, mux, url := startNewServer()
+			client := NewClient(APIKey)
+			client.BaseURL = url
+			defer server.Close()
+
+			mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
+				q := r.URL.Query()
+				if got := q.Get("page"); got != testCase.page {
+					t.Errorf("page is %q, want %q", got, testCase.page)
+				}
+				if got := q.Get("per_page"); got != testCase.perPage {
+					t.Errorf("per_page is %q, want %q", got, testCase.perPage)
+				}
+				fmt.Fprint(w, `[]`)
+			})
+
+			if _, _, err := client.Search(context.Background(), "pytest", testCase.opts); err != nil {
+				t.Fatalf("Search returned unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestSearch_perPageOutOfRange(t *testing.T) {
+	client := NewClient(APIKey)
+
+	for _, perPage := range []int{-1, MaxPerPage + 1} {
+		opts := &SearchOptions{ListOptions: ListOptions{PerPage: perPage}}
+
+		_, _, err := client.Search(context.Background(), "pytest", opts)
+
+		perPageErr, ok := err.(*PerPageError)
+		if !ok {
+			t.Fatalf("expected *PerPageError, got %v", err)
+		}
+		if perPageErr.PerPage != perPage {
+			t.Errorf("PerPageError.PerPage is %d, want %d", perPageErr.PerPage, perPage)
+		}
+	}
+}
+
+func TestListOptionsNormalize(t *testing.T) {
+	opts := ListOptions{}
+
+	got, err := opts.normalize()
+	if err != nil {
+		t.Fatalf("normalize returned unexpected error: %v", err)
+	}
+	if got.PerPage != DefaultPerPage {
+		t.Errorf("PerPage is %d, want %d", got.PerPage, DefaultPerPage)
+	}
+	if opts.PerPage != 0 {
+		t.Errorf("normalize modified the given options")
+	}
+
+	if _, err := (ListOptions{Page: -1}).normalize(); err == nil {
+		t.Error("Expected error for negative page")
+	}
+}
+
+// handleSearchPages serves total results for /search in pages
+// and reports the remaining rate limit of a 2 second window
+func handleSearchPages(mux *http.ServeMux, total int) *[]string {
+	var pages []string
+	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		pages = append(pages, q.Get("page"))
+
+		page, _ := strconv.Atoi(q.Get("page"))
+		perPage, _ := strconv.Atoi(q.Get("per_page"))
+
+		var names []string
+		for i := (page - 1) * perPage; i < total && i < page*perPage; i++ {
+			names = append(names, fmt.Sprintf(`{"name":"p%d"}`, i))
+		}
+
+		w.Header().Set("X-RateLimit-Remaining", "100")
+		w.Header().Set("X-RateLimit-Reset", "2")
+		fmt.Fprintf(w, "[%v]", strings.Join(names, ","))
+	})
+	return &pages
+}
+
+func TestSearchAll(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	pages := handleSearchPages(mux, 5)
+
+	projects, err := client.SearchAll(context.Background(), "pytest", &SearchOptions{ListOptions: ListOptions{PerPage: 2}})
+	if err != nil {
+		t.Fatalf("SearchAll returned unexpected error: %v", err)
+	}
+
+	if len(projects) != 5 {
+		t.Errorf("expected 5 projects, got %d", len(projects))
+	}
+	if want := []string{"1", "2", "3"}; !reflect.DeepEqual(*pages, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, *pages)
+	}
+}
+
+func TestSearchUntil(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	pages := handleSearchPages(mux, 10)
+	opts := &SearchOptions{ListOptions: ListOptions{PerPage: 2}}
+
+	projects, found, err := client.SearchUntil(context.Background(), "pytest", opts, func(p *Project) bool {
+		return stringValue(p.Name) == "p2"
+	})
+	if err != nil {
+		t.Fatalf("SearchUntil returned unexpected error: %v", err)
+	}
+
+	if !found || len(projects) != 3 || stringValue(projects[2].Name) != "p2" {
+		t.Errorf("expected projects up to p2, got %d projects (found %v)", len(projects), found)
+	}
+	if want := []string{"1", "2"}; !reflect.DeepEqual(*pages, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, *pages)
+	}
+
+	projects, found, err = client.SearchUntil(context.Background(), "pytest", opts, func(p *Project) bool {
+		return false
+	})
+	if err != nil {
+		t.Fatalf("SearchUntil returned unexpected error: %v", err)
+	}
+	if found || len(projects) != 10 {
+		t.Errorf("expected all 10 projects without a match, got %d (found %v)", len(projects), found)
+	}
+}
+
+func TestSearchAll_rateSmoothing(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey, WithRateSmoothing())
+	client.BaseURL = url
+	defer server.Close()
+
+	handleSearchPages(mux, 4)
+
+	// 100 requests remaining in 2 seconds result in 20ms between pages
+	start := time.Now()
+	if _, err := client.SearchAll(context.Background(), "pytest", &SearchOptions{ListOptions: ListOptions{PerPage: 2}}); err != nil {
+		t.Fatalf("SearchAll returned unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("expected pages to be spread out, took %v", elapsed)
+	}
+}
+
+// TestMaxPerPage pins the page size used when fetching all pages,
+// lowering it multiplies the requests counted against the rate limit
+func TestMaxPerPage(t *testing.T) {
+	if MaxPerPage != 100 {
+		t.Errorf("MaxPerPage is %d, want the API maximum of 100", MaxPerPage)
+	}
+
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	var perPage []string
+	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
+		perPage = append(perPage, r.URL.Query().Get("per_page"))
+		fmt.Fprint(w, `[]`)
+	})
+	mux.HandleFunc("/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		perPage = append(perPage, r.URL.Query().Get("per_page"))
+		fmt.Fprint(w, `[]`)
+	})
+
+	ctx := context.Background()
+	if _, err := client.SearchAll(ctx, "pytest", nil); err != nil {
+		t.Fatalf("SearchAll returned unexpected error: %v", err)
+	}
+	if _, _, err := client.SearchUntil(ctx, "pytest", nil, func(*Project) bool { return false }); err != nil {
+		t.Fatalf("SearchUntil returned unexpected error: %v", err)
+	}
+	if _, _, err := client.Subscriptions(ctx); err != nil {
+		t.Fatalf("Subscriptions returned unexpected error: %v", err)
+	}
+
+	if want := []string{"100", "100", "100"}; !reflect.DeepEqual(perPage, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, perPage)
+	}
+}
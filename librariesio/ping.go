@@ -0,0 +1,85 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// PingResult describes the health of the connection to libraries.io
+type PingResult struct {
+	// Latency is the time from sending the request until the first byte
+	// of the response, waits for limiters or a Scheduler are left out
+	Latency time.Duration
+
+	// Authenticated is false if the API key was rejected
+	Authenticated bool
+
+	// RateRemaining and RateResetsAt are the rate limit status after
+	// the ping, see Client.RateRemaining and Client.RateResetsAt
+	RateRemaining int
+	RateResetsAt  time.Time
+}
+
+// Ping performs the cheapest authenticated call, requesting a single
+// subscription, to check that libraries.io is reachable and the API key
+// is valid. A rejected API key is reported in the result, an error is
+// only returned if the API could not be reached or failed otherwise.
+// The call is always sent, it is neither answered by the cache nor
+// shared with other requests of a Scheduler.
+//
+// GET https://libraries.io/api/subscriptions?per_page=1
+func (c *Client) Ping(ctx context.Context) (*PingResult, error) {
+	request, err := c.NewRequest("GET", "subscriptions?per_page=1", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Time the first round trip, hedged or retried attempts are ignored
+	var mu sync.Mutex
+	var sent time.Time
+	var latency time.Duration
+	ctx = httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GetConn: func(string) {
+			mu.Lock()
+			defer mu.Unlock()
+			if sent.IsZero() {
+				sent = time.Now()
+			}
+		},
+		GotFirstResponseByte: func() {
+			mu.Lock()
+			defer mu.Unlock()
+			if latency == 0 {
+				latency = time.Since(sent)
+			}
+		},
+	})
+
+	_, err = c.DoLazy(ctx, request, WithNoCache())
+
+	mu.Lock()
+	result := &PingResult{Latency: latency, Authenticated: true}
+	mu.Unlock()
+
+	if err != nil {
+		var errResp *ErrorResponse
+		if !errors.As(err, &errResp) {
+			return nil, err
+		}
+
+		switch errResp.Response.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			result.Authenticated = false
+		default:
+			return nil, err
+		}
+	}
+
+	result.RateRemaining = c.RateRemaining()
+	result.RateResetsAt = c.RateResetsAt()
+	return result, nil
+}
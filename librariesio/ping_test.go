@@ -0,0 +1,110 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPing(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("per_page"); got != "1" {
+			t.Errorf("per_page is %q, want 1", got)
+		}
+		w.Header().Set("X-RateLimit-Remaining", "59")
+		fmt.Fprint(w, `[]`)
+	})
+
+	result, err := client.Ping(context.Background())
+	if err != nil {
+		t.Fatalf("Ping returned unexpected error: %v", err)
+	}
+	if !result.Authenticated || result.RateRemaining != 59 || result.Latency <= 0 {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
+
+func TestPing_unauthorized(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Error 403, you don't have permissions for this operation."}`, http.StatusForbidden)
+	})
+
+	result, err := client.Ping(context.Background())
+	if err != nil {
+		t.Fatalf("Ping returned unexpected error: %v", err)
+	}
+	if result.Authenticated {
+		t.Error("expected API key to be reported as invalid")
+	}
+}
+
+func TestPing_error(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Service Unavailable"}`, http.StatusServiceUnavailable)
+	})
+
+	if _, err := client.Ping(context.Background()); err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestPing_cache(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey, WithCache(NewLRUCache(1<<20, nil)), WithScheduler(NewScheduler(1, nil, nil)))
+	client.BaseURL = url
+	defer server.Close()
+
+	var calls int
+	mux.HandleFunc("/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `[]`)
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Ping(context.Background()); err != nil {
+			t.Fatalf("Ping returned unexpected error: %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected every ping to reach the API, got %d requests", calls)
+	}
+}
+
+func TestPing_latency(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey, WithSharedLimiter(NewSharedLimiter(120, 1)))
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+
+	// The second ping waits for the limiter
+	for i := 0; i < 2; i++ {
+		result, err := client.Ping(context.Background())
+		if err != nil {
+			t.Fatalf("Ping returned unexpected error: %v", err)
+		}
+		if result.Latency <= 0 || result.Latency > 250*time.Millisecond {
+			t.Errorf("expected latency to leave out the limiter, got %v", result.Latency)
+		}
+	}
+}
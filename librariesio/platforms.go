@@ -0,0 +1,87 @@
+package librariesio
+
+import (
+	"context"
+	"strings"
+)
+
+// Platform represents a package manager supported by libraries.io
+type Platform struct {
+	Name            *string `json:"name,omitempty"`
+	ProjectCount    *int    `json:"project_count,omitempty"`
+	Homepage        *string `json:"homepage,omitempty"`
+	Color           *string `json:"color,omitempty"`
+	DefaultLanguage *string `json:"default_language,omitempty"`
+}
+
+// PlatformCapabilities describes which data libraries.io collects for
+// the projects of a platform
+type PlatformCapabilities struct {
+	// Known is false for platforms missing from the built-in table,
+	// all capabilities are reported as supported for them
+	Known bool
+
+	// Versions is set if releases of projects are tracked
+	Versions bool
+
+	// Dependencies is set if dependencies of releases are tracked,
+	// i.e. ProjectDeps and ResolveTree return results
+	Dependencies bool
+}
+
+// platformCapabilities lists the capabilities of known platforms
+var platformCapabilities = map[string]PlatformCapabilities{
+	"alcatraz":  {Known: true},
+	"cargo":     {Known: true, Versions: true, Dependencies: true},
+	"clojars":   {Known: true, Versions: true, Dependencies: true},
+	"conda":     {Known: true, Versions: true, Dependencies: true},
+	"cpan":      {Known: true, Versions: true, Dependencies: true},
+	"cran":      {Known: true, Versions: true, Dependencies: true},
+	"dub":       {Known: true, Versions: true, Dependencies: true},
+	"elm":       {Known: true, Versions: true, Dependencies: true},
+	"go":        {Known: true, Versions: true, Dependencies: true},
+	"hackage":   {Known: true, Versions: true, Dependencies: true},
+	"haxelib":   {Known: true, Versions: true, Dependencies: true},
+	"hex":       {Known: true, Versions: true, Dependencies: true},
+	"inqlude":   {Known: true},
+	"maven":     {Known: true, Versions: true, Dependencies: true},
+	"npm":       {Known: true, Versions: true, Dependencies: true},
+	"nuget":     {Known: true, Versions: true, Dependencies: true},
+	"packagist": {Known: true, Versions: true, Dependencies: true},
+	"pub":       {Known: true, Versions: true, Dependencies: true},
+	"pypi":      {Known: true, Versions: true, Dependencies: true},
+	"rubygems":  {Known: true, Versions: true, Dependencies: true},
+}
+
+// Capabilities returns the capabilities of the given platform,
+// the platform name is case insensitive
+func Capabilities(plat string) PlatformCapabilities {
+	if caps, ok := platformCapabilities[strings.ToLower(plat)]; ok {
+		return caps
+	}
+	return PlatformCapabilities{Versions: true, Dependencies: true}
+}
+
+// Capabilities returns the capabilities of the platform
+func (p *Platform) Capabilities() PlatformCapabilities {
+	return Capabilities(stringValue(p.Name))
+}
+
+// Platforms returns the package managers supported by libraries.io
+//
+// GET https://libraries.io/api/platforms
+func (c *Client) Platforms(ctx context.Context) ([]*Platform, *Response, error) {
+	request, err := c.NewRequest("GET", "platforms", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var platforms []*Platform
+
+	response, err := c.Do(ctx, request, &platforms)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return platforms, response, nil
+}
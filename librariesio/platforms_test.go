@@ -0,0 +1,61 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hackebrot/go-repr/repr"
+)
+
+func TestPlatforms(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/platforms", func(w http.ResponseWriter, r *http.Request) {
+		if method := "GET"; method != r.Method {
+			t.Errorf("expected HTTP %v request, got %v", method, r.Method)
+		}
+		fmt.Fprint(w, `[
+			{"name": "NPM", "project_count": 2000000, "homepage": "https://www.npmjs.com", "color": "#f1e05a", "default_language": "JavaScript"},
+			{"name": "Inqlude", "project_count": 200}
+		]`)
+	})
+
+	platforms, _, err := client.Platforms(context.Background())
+	if err != nil {
+		t.Fatalf("Platforms returned unexpected error: %v", err)
+	}
+
+	want := []*Platform{
+		{
+			Name:            String("NPM"),
+			ProjectCount:    Int(2000000),
+			Homepage:        String("https://www.npmjs.com"),
+			Color:           String("#f1e05a"),
+			DefaultLanguage: String("JavaScript"),
+		},
+		{Name: String("Inqlude"), ProjectCount: Int(200)},
+	}
+	if !reflect.DeepEqual(platforms, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(platforms))
+	}
+
+	if caps := platforms[0].Capabilities(); !caps.Known || !caps.Dependencies {
+		t.Errorf("expected NPM to support dependencies, got %+v", caps)
+	}
+	if caps := platforms[1].Capabilities(); !caps.Known || caps.Versions || caps.Dependencies {
+		t.Errorf("expected Inqlude to support neither versions nor dependencies, got %+v", caps)
+	}
+}
+
+func TestCapabilities_unknown(t *testing.T) {
+	want := PlatformCapabilities{Versions: true, Dependencies: true}
+	if got := Capabilities("SomethingNew"); got != want {
+		t.Errorf("expected unknown platform to be assumed supported, got %+v", got)
+	}
+}
@@ -0,0 +1,47 @@
+package librariesio
+
+import "time"
+
+// Priority classifies requests so background traffic can yield to
+// interactive lookups when the rate limit runs low
+type Priority int
+
+const (
+	// PriorityInteractive is the default priority, for requests
+	// a user is waiting for
+	PriorityInteractive Priority = iota
+
+	// PriorityBackground is for batch traffic such as crawls,
+	// it is held back by WithBackgroundReserve
+	PriorityBackground
+)
+
+// WithPriority sets the priority of the request
+func WithPriority(p Priority) RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.priority = p
+	}
+}
+
+// WithBackgroundReserve reserves the last n requests of every rate limit
+// window for interactive requests. Requests sent with PriorityBackground
+// wait for the rate limit to reset once the remaining requests reported
+// by the API drop to n or below.
+func WithBackgroundReserve(n int) ClientOption {
+	return func(c *Client) {
+		c.backgroundReserve = n
+	}
+}
+
+// backgroundDelay returns how long background requests have to wait for
+// the rate limit to reset, it is zero if more than reserve requests are
+// left or the reset time is unknown or has passed
+func (r *rateStatus) backgroundDelay(at time.Time, reserve int) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.known || r.remaining > reserve || !r.resetsAt.After(at) {
+		return 0
+	}
+	return r.resetsAt.Sub(at)
+}
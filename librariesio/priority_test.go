@@ -0,0 +1,65 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateStatusBackgroundDelay(t *testing.T) {
+	at := time.Date(2017, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name   string
+		status *rateStatus
+		want   time.Duration
+	}{
+		{"unknown", &rateStatus{}, 0},
+		{"above reserve", &rateStatus{known: true, remaining: 11, resetsAt: at.Add(time.Minute)}, 0},
+		{"reserved", &rateStatus{known: true, remaining: 10, resetsAt: at.Add(time.Minute)}, time.Minute},
+		{"reset", &rateStatus{known: true, remaining: 0, resetsAt: at.Add(-time.Second)}, 0},
+		{"unknown reset", &rateStatus{known: true, remaining: 0}, 0},
+	}
+
+	for _, testCase := range testCases {
+		if got := testCase.status.backgroundDelay(at, 10); got != testCase.want {
+			t.Errorf("%v: backgroundDelay is %v, want %v", testCase.name, got, testCase.want)
+		}
+	}
+}
+
+func TestWithBackgroundReserve(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey, WithBackgroundReserve(5))
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "5")
+		w.Header().Set("X-RateLimit-Reset", "60")
+		fmt.Fprint(w, `{"name":"cookiecutter"}`)
+	})
+
+	req, err := client.NewRequest("GET", "pypi/cookiecutter", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned unexpected error: %v", err)
+	}
+
+	// The first response reports that only the reserve is left
+	if _, err := client.Do(context.Background(), req, new(Project), WithPriority(PriorityBackground)); err != nil {
+		t.Fatalf("Do returned unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.Do(ctx, req, new(Project)); err != nil {
+		t.Errorf("expected interactive request to be sent, got %v", err)
+	}
+	if _, err := client.Do(ctx, req, new(Project), WithPriority(PriorityBackground)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected background request to wait for the reset, got %v", err)
+	}
+}
@@ -3,12 +3,14 @@ package librariesio
 import (
 	"context"
 	"fmt"
-	"net/http"
+	"net/url"
 	"time"
 )
 
 // Project represents a project on libraries.io
 type Project struct {
+	DependentReposCount      *int       `json:"dependent_repos_count,omitempty"`
+	DependentsCount          *int       `json:"dependents_count,omitempty"`
 	Description              *string    `json:"description,omitempty"`
 	Forks                    *int       `json:"forks,omitempty"`
 	Homepage                 *string    `json:"homepage,omitempty"`
@@ -28,11 +30,22 @@ type Project struct {
 	Status                   *string    `json:"status,omitempty"`
 	Versions                 []*Release `json:"versions,omitempty"`
 
-	// Dependencies are only populated for ProjectDeps
-	Dependencies []*ProjectDependency `json:"dependencies,omitempty"`
+	// CreatedAt is only populated for Search
+	CreatedAt *time.Time `json:"created_at,omitempty"`
 
-	// RepositoryURL is only populated for UserProjects
+	// Dependencies and DependenciesForVersion are only populated for ProjectDeps
+	Dependencies           []*ProjectDependency `json:"dependencies,omitempty"`
+	DependenciesForVersion *string              `json:"dependencies_for_version,omitempty"`
+
+	// RepositoryURL is only populated for Search and UserProjects
 	RepositoryURL *string `json:"repository_url,omitempty"`
+
+	// CollapsedPlatforms lists the platforms of all search results that
+	// were collapsed into this project, see SearchOptions.Collapse
+	CollapsedPlatforms []string `json:"-"`
+
+	// Enrichment holds the data fetched by Client.EnrichProjects
+	Enrichment *RepositoryInfo `json:"-"`
 }
 
 // Release represents a release of the project
@@ -47,9 +60,11 @@ type Release struct {
 // ProjectDependency represents a dependency of the project
 type ProjectDependency struct {
 	Deprecated   *bool   `json:"deprecated,omitempty"`
+	Kind         *string `json:"kind,omitempty"`
 	Latest       *string `json:"latest,omitempty"`
 	LatestStable *string `json:"latest_stable,omitempty"`
 	Name         *string `json:"name,omitempty"`
+	Optional     *bool   `json:"optional,omitempty"`
 	Outdated     *bool   `json:"outdated,omitempty"`
 	Platform     *string `json:"platform,omitempty"`
 	ProjectName  *string `json:"project_name,omitempty"`
@@ -57,13 +72,25 @@ type ProjectDependency struct {
 }
 
 // Project returns information about a project and it's versions.
+// A *ProjectNotFoundError is returned if the project does not exist.
+// If a provider is set with WithProvider, the project is looked up with
+// it instead and no Response is returned.
 //
 // GET https://libraries.io/api/:platform/:name
 //
 // plat is the platform/package manager of the project
 // name is the name of the project on the platform
-func (c *Client) Project(ctx context.Context, plat, name string) (*Project, *http.Response, error) {
-	urlStr := fmt.Sprintf("%v/%v", plat, name)
+func (c *Client) Project(ctx context.Context, plat, name string) (*Project, *Response, error) {
+	if c.provider != nil {
+		project, err := c.provider.GetProject(ctx, plat, name)
+		return project, nil, err
+	}
+	return c.project(ctx, plat, name)
+}
+
+// project returns the project from the libraries.io API
+func (c *Client) project(ctx context.Context, plat, name string) (*Project, *Response, error) {
+	urlStr := fmt.Sprintf("%v/%v", plat, url.PathEscape(name))
 
 	request, err := c.NewRequest("GET", urlStr, nil)
 
@@ -74,22 +101,37 @@ func (c *Client) Project(ctx context.Context, plat, name string) (*Project, *htt
 	project := new(Project)
 	response, err := c.Do(ctx, request, project)
 	if err != nil {
-		return nil, response, err
+		return nil, response, projectError(err, plat, name)
+	}
+	if response.unchanged() {
+		return nil, response, nil
 	}
 
 	return project, response, nil
 }
 
 // ProjectDeps returns information about a project and it's dependencies.
+// A *ProjectNotFoundError is returned if the project does not exist.
+// If a provider is set with WithProvider, the project is looked up with
+// it instead and no Response is returned.
 //
 // GET https://libraries.io/api/:platform/:name/:version/dependencies
 //
 // plat is the platform/package manager of the project
 // name is the name of the project on the platform
 // ver is the version of the project - pass "latest" for current release
-func (c *Client) ProjectDeps(ctx context.Context, plat, name, ver string) (*Project, *http.Response, error) {
+func (c *Client) ProjectDeps(ctx context.Context, plat, name, ver string) (*Project, *Response, error) {
+	if c.provider != nil {
+		project, err := c.provider.GetDependencies(ctx, plat, name, ver)
+		return project, nil, err
+	}
+	return c.projectDeps(ctx, plat, name, ver)
+}
 
-	urlStr := fmt.Sprintf("%v/%v/%v/dependencies", plat, name, ver)
+// projectDeps returns the project and its dependencies from the
+// libraries.io API
+func (c *Client) projectDeps(ctx context.Context, plat, name, ver string) (*Project, *Response, error) {
+	urlStr := fmt.Sprintf("%v/%v/%v/dependencies", plat, url.PathEscape(name), url.PathEscape(ver))
 
 	request, err := c.NewRequest("GET", urlStr, nil)
 	if err != nil {
@@ -100,25 +142,74 @@ func (c *Client) ProjectDeps(ctx context.Context, plat, name, ver string) (*Proj
 
 	response, err := c.Do(ctx, request, project)
 	if err != nil {
-		return nil, response, err
+		return nil, response, projectError(err, plat, name)
+	}
+	if response.unchanged() {
+		return nil, response, nil
 	}
 
 	return project, response, nil
 }
 
-// Search returns a slice of projects for the given search string
+// SearchOptions specifies the optional parameters of Search
+type SearchOptions struct {
+	// Sort is one of rank, stars, dependents_count, dependent_repos_count,
+	// latest_release_published_at, contributions_count or created_at
+	Sort string `url:"sort,omitempty"`
+
+	// Filters, multiple values of the same filter are combined
+	Platforms []string `url:"platforms,omitempty"`
+	Languages []string `url:"languages,omitempty"`
+	Licenses  []string `url:"licenses,omitempty"`
+	Keywords  []string `url:"keywords,omitempty"`
+
+	// Collapse merges results sharing the same repository URL into the
+	// first of them, listing the platforms of all in CollapsedPlatforms.
+	// Search collapses every page on its own, SearchAll all results.
+	Collapse bool `url:"-"`
+
+	// Scorer re-ranks the results on the client side, e.g. with
+	// DefaultScoreWeights. Search ranks every page on its own,
+	// SearchAll all results.
+	Scorer Scorer `url:"-"`
+
+	ListOptions
+}
+
+// Search returns a slice of projects for the given search string.
+// If a provider is set with WithProvider, the search is sent to it
+// instead and no Response is returned.
 //
 // GET https://libraries.io/api/search?q=amelia
-func (c *Client) Search(ctx context.Context, q string) ([]*Project, *http.Response, error) {
-	request, err := c.NewRequest("GET", "search", nil)
+func (c *Client) Search(ctx context.Context, q string, opts *SearchOptions) ([]*Project, *Response, error) {
+	if c.provider != nil {
+		projects, err := c.provider.Search(ctx, q, opts)
+		return projects, nil, err
+	}
+	return c.search(ctx, q, opts)
+}
+
+// search returns the search results from the libraries.io API
+func (c *Client) search(ctx context.Context, q string, opts *SearchOptions) ([]*Project, *Response, error) {
+	var o SearchOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	var err error
+	if o.ListOptions, err = o.ListOptions.normalize(); err != nil {
+		return nil, nil, err
+	}
+
+	urlStr, err := addOptions("search?q="+url.QueryEscape(q), o)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	// Add query to request
-	query := request.URL.Query()
-	query.Set("q", q)
-	request.URL.RawQuery = query.Encode()
+	request, err := c.NewRequest("GET", urlStr, nil)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	var projects []*Project
 
@@ -127,5 +218,125 @@ func (c *Client) Search(ctx context.Context, q string) ([]*Project, *http.Respon
 		return nil, response, err
 	}
 
+	if o.Collapse {
+		projects = collapseProjects(projects)
+	}
+	if o.Scorer != nil {
+		RankProjects(q, projects, o.Scorer)
+	}
+
 	return projects, response, nil
 }
+
+// SearchAll returns the projects of all result pages for the given search
+// string, starting at the page given in opts. If ctx is cancelled, the
+// projects fetched so far are returned with a PartialResultError.
+func (c *Client) SearchAll(ctx context.Context, q string, opts *SearchOptions) ([]*Project, error) {
+	var o SearchOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	var err error
+	if o.ListOptions, err = o.ListOptions.normalizeAll(); err != nil {
+		return nil, err
+	}
+	if o.Page == 0 {
+		o.Page = 1
+	}
+
+	// Pages are collapsed and ranked together once all are fetched
+	collapse, scorer := o.Collapse, o.Scorer
+	o.Collapse, o.Scorer = false, nil
+
+	var all []*Project
+
+	for {
+		projects, _, err := c.Search(ctx, q, &o)
+		if err != nil {
+			if err, ok := partialResult(ctx, err); ok {
+				return all, err
+			}
+			return nil, err
+		}
+
+		all = append(all, projects...)
+
+		if len(projects) < o.PerPage {
+			if collapse {
+				all = collapseProjects(all)
+			}
+			if scorer != nil {
+				RankProjects(q, all, scorer)
+			}
+			return all, nil
+		}
+		o.Page++
+
+		if err := c.waitForNextPage(ctx); err != nil {
+			return all, &PartialResultError{Err: err}
+		}
+	}
+}
+
+// SearchUntil pages through the results for the given search string,
+// starting at the page given in opts, until stop returns true for a
+// project. It returns the projects up to and including that project and
+// found is true, or all results if no project satisfied stop. Unlike
+// SearchAll, no further pages are requested once a project was found.
+// stop sees the projects as returned by the API, Collapse and Scorer are
+// applied to the returned projects. If ctx is cancelled, the projects fetched so far are returned with a
+// PartialResultError.
+func (c *Client) SearchUntil(ctx context.Context, q string, opts *SearchOptions, stop func(*Project) bool) (projects []*Project, found bool, err error) {
+	var o SearchOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	if o.ListOptions, err = o.ListOptions.normalizeAll(); err != nil {
+		return nil, false, err
+	}
+	if o.Page == 0 {
+		o.Page = 1
+	}
+
+	// Like SearchAll, the results are collapsed and ranked together
+	collapse, scorer := o.Collapse, o.Scorer
+	o.Collapse, o.Scorer = false, nil
+
+	finish := func(found bool) ([]*Project, bool, error) {
+		if collapse {
+			projects = collapseProjects(projects)
+		}
+		if scorer != nil {
+			RankProjects(q, projects, scorer)
+		}
+		return projects, found, nil
+	}
+
+	for {
+		page, _, err := c.Search(ctx, q, &o)
+		if err != nil {
+			if err, ok := partialResult(ctx, err); ok {
+				return projects, false, err
+			}
+			return nil, false, err
+		}
+
+		for _, project := range page {
+			projects = append(projects, project)
+			if stop(project) {
+				return finish(true)
+			}
+		}
+
+		if len(page) < o.PerPage {
+			return finish(false)
+		}
+		o.Page++
+
+		if err := c.waitForNextPage(ctx); err != nil {
+			return projects, false, &PartialResultError{Err: err}
+		}
+	}
+}
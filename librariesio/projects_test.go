@@ -115,7 +115,7 @@ func TestSearch(t *testing.T) {
 		]`)
 	})
 
-	projects, _, err := client.Search(context.Background(), "pytest")
+	projects, _, err := client.Search(context.Background(), "pytest", nil)
 
 	if err != nil {
 		t.Fatalf("Search returned unexpected error: %v", err)
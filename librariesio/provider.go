@@ -0,0 +1,107 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+)
+
+// Provider is a source of package metadata. The Client provides the
+// libraries.io implementation via Client.Provider, alternative backends
+// can implement this interface to be used in its place or as a fallback.
+type Provider interface {
+	// GetProject returns a project and its versions
+	GetProject(ctx context.Context, plat, name string) (*Project, error)
+
+	// GetDependencies returns a project and the dependencies of the
+	// given version, "latest" refers to the current release
+	GetDependencies(ctx context.Context, plat, name, ver string) (*Project, error)
+
+	// Search returns projects matching the given search string
+	Search(ctx context.Context, q string, opts *SearchOptions) ([]*Project, error)
+}
+
+// Provider returns the Provider configured with WithProvider,
+// or a Provider backed by the libraries.io API by default
+func (c *Client) Provider() Provider {
+	if c.provider != nil {
+		return c.provider
+	}
+	return &librariesIOProvider{client: c}
+}
+
+// librariesIOProvider always queries the libraries.io API, even
+// if the client looks up projects with another provider
+type librariesIOProvider struct {
+	client *Client
+}
+
+func (p *librariesIOProvider) GetProject(ctx context.Context, plat, name string) (*Project, error) {
+	project, _, err := p.client.project(ctx, plat, name)
+	return project, err
+}
+
+func (p *librariesIOProvider) GetDependencies(ctx context.Context, plat, name, ver string) (*Project, error) {
+	project, _, err := p.client.projectDeps(ctx, plat, name, ver)
+	return project, err
+}
+
+func (p *librariesIOProvider) Search(ctx context.Context, q string, opts *SearchOptions) ([]*Project, error) {
+	projects, _, err := p.client.search(ctx, q, opts)
+	return projects, err
+}
+
+// FallbackProvider returns a Provider that queries the given providers in
+// order and returns the first successful result. If all providers fail,
+// the errors of all providers are returned. Cancellation of the context
+// is returned right away without trying the remaining providers.
+func FallbackProvider(providers ...Provider) Provider {
+	return fallbackProvider(providers)
+}
+
+type fallbackProvider []Provider
+
+func (f fallbackProvider) GetProject(ctx context.Context, plat, name string) (*Project, error) {
+	var project *Project
+	err := f.try(ctx, func(p Provider) (err error) {
+		project, err = p.GetProject(ctx, plat, name)
+		return err
+	})
+	return project, err
+}
+
+func (f fallbackProvider) GetDependencies(ctx context.Context, plat, name, ver string) (*Project, error) {
+	var project *Project
+	err := f.try(ctx, func(p Provider) (err error) {
+		project, err = p.GetDependencies(ctx, plat, name, ver)
+		return err
+	})
+	return project, err
+}
+
+func (f fallbackProvider) Search(ctx context.Context, q string, opts *SearchOptions) ([]*Project, error) {
+	var projects []*Project
+	err := f.try(ctx, func(p Provider) (err error) {
+		projects, err = p.Search(ctx, q, opts)
+		return err
+	})
+	return projects, err
+}
+
+func (f fallbackProvider) try(ctx context.Context, call func(Provider) error) error {
+	if len(f) == 0 {
+		return errors.New("no providers configured")
+	}
+
+	var errs []error
+	for _, p := range f {
+		err := call(p)
+		if err == nil {
+			return nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return err
+		}
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
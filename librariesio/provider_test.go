@@ -0,0 +1,202 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hackebrot/go-repr/repr"
+)
+
+// stubProvider returns the configured project or error for every call
+type stubProvider struct {
+	project *Project
+	err     error
+	calls   int
+}
+
+func (p *stubProvider) GetProject(ctx context.Context, plat, name string) (*Project, error) {
+	p.calls++
+	return p.project, p.err
+}
+
+func (p *stubProvider) GetDependencies(ctx context.Context, plat, name, ver string) (*Project, error) {
+	p.calls++
+	return p.project, p.err
+}
+
+func (p *stubProvider) Search(ctx context.Context, q string, opts *SearchOptions) ([]*Project, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return []*Project{p.project}, nil
+}
+
+func TestClientProvider(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"cookiecutter"}`)
+	})
+	mux.HandleFunc("/pypi/cookiecutter/latest/dependencies", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"cookiecutter","dependencies":[{"name":"click"}]}`)
+	})
+	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"name":"cookiecutter"}]`)
+	})
+
+	p := client.Provider()
+	ctx := context.Background()
+
+	project, err := p.GetProject(ctx, "pypi", "cookiecutter")
+	if err != nil {
+		t.Fatalf("GetProject returned unexpected error: %v", err)
+	}
+	if want := (&Project{Name: String("cookiecutter")}); !reflect.DeepEqual(project, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(project))
+	}
+
+	project, err = p.GetDependencies(ctx, "pypi", "cookiecutter", "latest")
+	if err != nil {
+		t.Fatalf("GetDependencies returned unexpected error: %v", err)
+	}
+	if len(project.Dependencies) != 1 {
+		t.Errorf("expected 1 dependency, got %v", repr.Repr(project.Dependencies))
+	}
+
+	projects, err := p.Search(ctx, "cookiecutter", nil)
+	if err != nil {
+		t.Fatalf("Search returned unexpected error: %v", err)
+	}
+	if len(projects) != 1 {
+		t.Errorf("expected 1 project, got %v", repr.Repr(projects))
+	}
+}
+
+func TestFallbackProvider(t *testing.T) {
+	down := &stubProvider{err: errors.New("503 Service Unavailable")}
+	up := &stubProvider{project: &Project{Name: String("cookiecutter")}}
+	unused := &stubProvider{err: errors.New("should not be called")}
+
+	p := FallbackProvider(down, up, unused)
+
+	project, err := p.GetProject(context.Background(), "pypi", "cookiecutter")
+	if err != nil {
+		t.Fatalf("GetProject returned unexpected error: %v", err)
+	}
+	if project != up.project {
+		t.Errorf("expected project of second provider, got %v", repr.Repr(project))
+	}
+	if down.calls != 1 || up.calls != 1 || unused.calls != 0 {
+		t.Errorf("unexpected calls %d, %d, %d", down.calls, up.calls, unused.calls)
+	}
+}
+
+func TestFallbackProvider_allFail(t *testing.T) {
+	errA := errors.New("a failed")
+	errB := errors.New("b failed")
+
+	p := FallbackProvider(&stubProvider{err: errA}, &stubProvider{err: errB})
+
+	_, err := p.Search(context.Background(), "cookiecutter", nil)
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("expected errors of all providers, got %v", err)
+	}
+
+	if _, err := FallbackProvider().GetDependencies(context.Background(), "npm", "ava", "latest"); err == nil {
+		t.Error("Expected error without providers")
+	}
+}
+
+func TestFallbackProvider_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	first := &stubProvider{err: context.Canceled}
+	second := &stubProvider{project: &Project{}}
+
+	_, err := FallbackProvider(first, second).GetProject(ctx, "npm", "ava")
+	if err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if second.calls != 0 {
+		t.Error("expected second provider not to be called")
+	}
+}
+
+func TestWithProvider_lookups(t *testing.T) {
+	server, mux, url := startNewServer()
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to libraries.io: %v", r.URL.Path)
+	})
+
+	p := &stubProvider{project: &Project{Name: String("cookiecutter")}}
+	client := NewClient(APIKey, WithProvider(p))
+	client.BaseURL = url
+
+	project, response, err := client.Project(context.Background(), "pypi", "cookiecutter")
+	if err != nil || project != p.project || response != nil {
+		t.Errorf("expected project of provider, got %v (%v)", repr.Repr(project), err)
+	}
+	project, _, err = client.ProjectDeps(context.Background(), "pypi", "cookiecutter", "latest")
+	if err != nil || project != p.project {
+		t.Errorf("expected project of provider, got %v (%v)", repr.Repr(project), err)
+	}
+	projects, response, err := client.Search(context.Background(), "cookiecutter", nil)
+	if err != nil || len(projects) != 1 || projects[0] != p.project || response != nil {
+		t.Errorf("expected search results of provider, got %v (%v)", repr.Repr(projects), err)
+	}
+	if p.calls != 3 {
+		t.Errorf("expected 3 provider calls, got %d", p.calls)
+	}
+}
+
+func TestWithFallbackProvider(t *testing.T) {
+	server, mux, url := startNewServer()
+	defer server.Close()
+	ecosystemsServer, ecosystemsMux, ecosystems := startEcosystemsServer()
+	defer ecosystemsServer.Close()
+
+	var down bool
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		if down {
+			http.Error(w, `{"error":"Service Unavailable"}`, http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"name":"cookiecutter","platform":"Pypi"}`)
+	})
+	var fallbacks int
+	ecosystemsMux.HandleFunc("/registries/pypi.org/packages/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		fallbacks++
+		fmt.Fprint(w, `{"name":"cookiecutter","description":"Project templates"}`)
+	})
+	ecosystemsMux.HandleFunc("/registries/pypi.org/packages/cookiecutter/versions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+
+	client := NewClient(APIKey, WithFallbackProvider(ecosystems))
+	client.BaseURL = url
+
+	project, _, err := client.Project(context.Background(), "pypi", "cookiecutter")
+	if err != nil || project.Description != nil || fallbacks != 0 {
+		t.Errorf("expected project of libraries.io, got %v (%v)", repr.Repr(project), err)
+	}
+
+	down = true
+	project, _, err = client.Project(context.Background(), "pypi", "cookiecutter")
+	if err != nil {
+		t.Fatalf("Project returned unexpected error: %v", err)
+	}
+	if stringValue(project.Description) != "Project templates" || fallbacks != 1 {
+		t.Errorf("expected project of the fallback provider, got %v", repr.Repr(project))
+	}
+}
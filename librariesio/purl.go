@@ -0,0 +1,334 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// PackageURL is a parsed package URL (purl) as specified by
+// https://github.com/package-url/purl-spec
+type PackageURL struct {
+	Type       string
+	Namespace  string
+	Name       string
+	Version    string
+	Qualifiers map[string]string
+	Subpath    string
+}
+
+// purlPlatforms maps purl types to libraries.io platforms
+var purlPlatforms = map[string]string{
+	"bower":     "Bower",
+	"cargo":     "Cargo",
+	"clojars":   "Clojars",
+	"cocoapods": "CocoaPods",
+	"composer":  "Packagist",
+	"conda":     "Conda",
+	"cpan":      "CPAN",
+	"cran":      "CRAN",
+	"gem":       "Rubygems",
+	"golang":    "Go",
+	"hackage":   "Hackage",
+	"hex":       "Hex",
+	"maven":     "Maven",
+	"npm":       "NPM",
+	"nuget":     "NuGet",
+	"pub":       "Pub",
+	"pypi":      "Pypi",
+	"swift":     "SwiftPM",
+}
+
+// ParsePackageURL parses a package URL such as pkg:npm/%40babel/core@7.23.0
+func ParsePackageURL(s string) (*PackageURL, error) {
+	rest := strings.TrimSpace(s)
+	if !strings.HasPrefix(strings.ToLower(rest), "pkg:") {
+		return nil, fmt.Errorf("purl: %q does not start with pkg:", s)
+	}
+	rest = strings.TrimLeft(rest[len("pkg:"):], "/")
+
+	p := new(PackageURL)
+	var err error
+
+	if i := strings.LastIndex(rest, "#"); i >= 0 {
+		if p.Subpath, err = unescapeSegments(strings.Trim(rest[i+1:], "/")); err != nil {
+			return nil, err
+		}
+		rest = rest[:i]
+	}
+
+	if i := strings.LastIndex(rest, "?"); i >= 0 {
+		values, err := url.ParseQuery(rest[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("purl: invalid qualifiers: %v", err)
+		}
+		p.Qualifiers = make(map[string]string)
+		for k, v := range values {
+			p.Qualifiers[strings.ToLower(k)] = v[0]
+		}
+		rest = rest[:i]
+	}
+
+	rest = strings.TrimRight(rest, "/")
+
+	i := strings.Index(rest, "/")
+	if i <= 0 {
+		return nil, fmt.Errorf("purl: %q has no type or name", s)
+	}
+	p.Type, rest = strings.ToLower(rest[:i]), rest[i+1:]
+
+	if i := strings.LastIndex(rest, "@"); i >= 0 && i > strings.LastIndex(rest, "/") {
+		if p.Version, err = url.PathUnescape(rest[i+1:]); err != nil {
+			return nil, fmt.Errorf("purl: invalid version: %v", err)
+		}
+		rest = rest[:i]
+	}
+
+	if i := strings.LastIndex(rest, "/"); i >= 0 {
+		if p.Namespace, err = unescapeSegments(rest[:i]); err != nil {
+			return nil, err
+		}
+		rest = rest[i+1:]
+	}
+
+	if p.Name, err = url.PathUnescape(rest); err != nil {
+		return nil, fmt.Errorf("purl: invalid name: %v", err)
+	}
+	if p.Name == "" {
+		return nil, fmt.Errorf("purl: %q has no name", s)
+	}
+
+	return p, nil
+}
+
+// unescapeSegments percent-decodes every segment of a / separated path
+func unescapeSegments(s string) (string, error) {
+	segments := strings.Split(s, "/")
+	for i, segment := range segments {
+		var err error
+		if segments[i], err = url.PathUnescape(segment); err != nil {
+			return "", fmt.Errorf("purl: invalid segment %q: %v", segment, err)
+		}
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+// String returns the canonical form of the package URL
+func (p *PackageURL) String() string {
+	s := "pkg:" + p.Type + "/"
+	if p.Namespace != "" {
+		s += escapeSegments(p.Namespace) + "/"
+	}
+	s += escapePURL(p.Name)
+	if p.Version != "" {
+		s += "@" + escapePURL(p.Version)
+	}
+
+	if len(p.Qualifiers) > 0 {
+		keys := make([]string, 0, len(p.Qualifiers))
+		for k, v := range p.Qualifiers {
+			if v != "" {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		qualifiers := make([]string, len(keys))
+		for i, k := range keys {
+			qualifiers[i] = strings.ToLower(k) + "=" + escapePURL(p.Qualifiers[k])
+		}
+		if len(qualifiers) > 0 {
+			s += "?" + strings.Join(qualifiers, "&")
+		}
+	}
+
+	if p.Subpath != "" {
+		s += "#" + escapeSegments(p.Subpath)
+	}
+	return s
+}
+
+// escapePURL percent-encodes a single purl component,
+// '@' is encoded as it separates the version
+func escapePURL(s string) string {
+	return strings.Replace(url.PathEscape(s), "@", "%40", -1)
+}
+
+func escapeSegments(s string) string {
+	segments := strings.Split(s, "/")
+	for i, segment := range segments {
+		segments[i] = escapePURL(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// newPackageURL creates a package URL for a project on libraries.io
+func newPackageURL(plat, name, version string) (*PackageURL, error) {
+	var purlType string
+	for t, p := range purlPlatforms {
+		if strings.EqualFold(p, plat) {
+			purlType = t
+			break
+		}
+	}
+	if purlType == "" {
+		return nil, fmt.Errorf("purl: platform %q has no package URL type", plat)
+	}
+
+	p := &PackageURL{Type: purlType, Name: name, Version: version}
+
+	switch purlType {
+	case "maven":
+		if i := strings.Index(name, ":"); i >= 0 {
+			p.Namespace, p.Name = name[:i], name[i+1:]
+		}
+	case "npm", "golang", "composer", "swift":
+		if i := strings.LastIndex(name, "/"); i >= 0 {
+			p.Namespace, p.Name = name[:i], name[i+1:]
+		}
+	}
+
+	// Names are case insensitive on these platforms
+	// and the purl spec requires them to be normalized
+	if purlType == "npm" || purlType == "pypi" {
+		p.Namespace = strings.ToLower(p.Namespace)
+		p.Name = normalizeName(purlType, p.Name)
+	}
+
+	return p, nil
+}
+
+// ProjectRef returns the libraries.io platform, name and version
+// of the package. An error is returned for purl types that are not
+// supported by libraries.io.
+func (p *PackageURL) ProjectRef() (ProjectRef, error) {
+	plat, ok := purlPlatforms[p.Type]
+	if !ok {
+		return ProjectRef{}, fmt.Errorf("purl: type %q is not supported by libraries.io", p.Type)
+	}
+
+	name := p.Name
+	if p.Namespace != "" {
+		sep := "/"
+		if p.Type == "maven" {
+			sep = ":"
+		}
+		name = p.Namespace + sep + p.Name
+	}
+
+	return ProjectRef{Platform: plat, Name: name, Version: p.Version}, nil
+}
+
+// ProjectByPURL returns information about the project identified by the
+// given package URL, the version of the package URL is ignored.
+func (c *Client) ProjectByPURL(ctx context.Context, purl string) (*Project, *Response, error) {
+	ref, err := purlRef(purl)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.ProjectByRef(ctx, ref)
+}
+
+// ProjectDepsByPURL returns the dependencies of the package version
+// identified by the given package URL. The latest version is used
+// if the package URL has no version.
+func (c *Client) ProjectDepsByPURL(ctx context.Context, purl string) (*Project, *Response, error) {
+	ref, err := purlRef(purl)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.ProjectDepsByRef(ctx, ref)
+}
+
+// PURL returns the package URL of the project. The version is only
+// included for projects returned by ProjectDeps.
+func (p *Project) PURL() (string, error) {
+	purl, err := newPackageURL(stringValue(p.Platform), stringValue(p.Name), stringValue(p.DependenciesForVersion))
+	if err != nil {
+		return "", err
+	}
+	return purl.String(), nil
+}
+
+// PURL returns the package URL of the dependency without a version,
+// as dependencies only declare version requirements.
+func (d *ProjectDependency) PURL() (string, error) {
+	name := stringValue(d.ProjectName)
+	if name == "" {
+		name = stringValue(d.Name)
+	}
+
+	purl, err := newPackageURL(stringValue(d.Platform), name, "")
+	if err != nil {
+		return "", err
+	}
+	return purl.String(), nil
+}
+
+func purlRef(purl string) (ProjectRef, error) {
+	p, err := ParsePackageURL(purl)
+	if err != nil {
+		return ProjectRef{}, err
+	}
+	return p.ProjectRef()
+}
+
+// PURLOptions configures DependencyPURLs
+type PURLOptions struct {
+	// Versions includes the resolved version of every dependency
+	Versions bool
+
+	// Ranges adds the version requirement declared by the parent as a
+	// "vers" qualifier, e.g. pkg:npm/chalk?vers=vers:npm%2F%5E1.1.3.
+	// Requirements are passed on verbatim in the package manager's syntax.
+	Ranges bool
+}
+
+// DependencyPURLs flattens the dependencies of the given tree into a
+// sorted list of distinct package URLs, the root itself is not included.
+func DependencyPURLs(tree *DependencyNode, opts *PURLOptions) ([]string, error) {
+	if opts == nil {
+		opts = &PURLOptions{}
+	}
+
+	seen := make(map[string]bool)
+	var purls []string
+	var err error
+
+	tree.Walk(func(node *DependencyNode, path []*DependencyNode) bool {
+		if err != nil {
+			return false
+		}
+		if len(path) == 0 {
+			return true
+		}
+
+		var version string
+		if opts.Versions && node.Version != "latest" {
+			version = node.Version
+		}
+
+		var p *PackageURL
+		if p, err = newPackageURL(node.Platform, node.Name, version); err != nil {
+			return false
+		}
+		if opts.Ranges && node.Requirements != "" {
+			p.Qualifiers = map[string]string{"vers": "vers:" + p.Type + "/" + node.Requirements}
+		}
+
+		if s := p.String(); !seen[s] {
+			seen[s] = true
+			purls = append(purls, s)
+		}
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(purls)
+	return purls, nil
+}
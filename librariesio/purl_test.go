@@ -0,0 +1,303 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hackebrot/go-repr/repr"
+)
+
+func TestParsePackageURL(t *testing.T) {
+	testCases := []struct {
+		purl string
+		want *PackageURL
+	}{
+		{
+			"pkg:npm/%40babel/core@7.23.0",
+			&PackageURL{Type: "npm", Namespace: "@babel", Name: "core", Version: "7.23.0"},
+		},
+		{
+			"pkg:pypi/django@1.11.1",
+			&PackageURL{Type: "pypi", Name: "django", Version: "1.11.1"},
+		},
+		{
+			"pkg:maven/org.apache.commons/io",
+			&PackageURL{Type: "maven", Namespace: "org.apache.commons", Name: "io"},
+		},
+		{
+			"pkg:golang/github.com/gorilla/context@234fd47e07d1004f0aed9c#api",
+			&PackageURL{
+				Type:      "golang",
+				Namespace: "github.com/gorilla",
+				Name:      "context",
+				Version:   "234fd47e07d1004f0aed9c",
+				Subpath:   "api",
+			},
+		},
+		{
+			"pkg:gem/jruby-launcher@1.1.2?Platform=java",
+			&PackageURL{
+				Type:       "gem",
+				Name:       "jruby-launcher",
+				Version:    "1.1.2",
+				Qualifiers: map[string]string{"platform": "java"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		got, err := ParsePackageURL(testCase.purl)
+		if err != nil {
+			t.Errorf("ParsePackageURL(%q) returned unexpected error: %v", testCase.purl, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, testCase.want) {
+			t.Errorf("\nExpected %v\nGot %v", repr.Repr(testCase.want), repr.Repr(got))
+		}
+	}
+}
+
+func TestParsePackageURL_errors(t *testing.T) {
+	for _, purl := range []string{
+		"npm/react",
+		"pkg:react",
+		"pkg:npm/",
+		"pkg:npm/%zz",
+	} {
+		if _, err := ParsePackageURL(purl); err == nil {
+			t.Errorf("ParsePackageURL(%q) did not return an error", purl)
+		}
+	}
+}
+
+func TestPackageURLProjectRef(t *testing.T) {
+	testCases := []struct {
+		purl string
+		want ProjectRef
+	}{
+		{"pkg:npm/%40babel/core@7.23.0", ProjectRef{Platform: "NPM", Name: "@babel/core", Version: "7.23.0"}},
+		{"pkg:maven/org.apache.commons/io@1.3.4", ProjectRef{Platform: "Maven", Name: "org.apache.commons:io", Version: "1.3.4"}},
+		{"pkg:golang/github.com/gorilla/context", ProjectRef{Platform: "Go", Name: "github.com/gorilla/context"}},
+	}
+
+	for _, testCase := range testCases {
+		p, err := ParsePackageURL(testCase.purl)
+		if err != nil {
+			t.Fatalf("ParsePackageURL(%q) returned unexpected error: %v", testCase.purl, err)
+		}
+
+		got, err := p.ProjectRef()
+		if err != nil {
+			t.Fatalf("ProjectRef returned unexpected error: %v", err)
+		}
+		if got != testCase.want {
+			t.Errorf("\nExpected %v\nGot %v", testCase.want, got)
+		}
+	}
+
+	p := &PackageURL{Type: "docker", Name: "nginx"}
+	if _, err := p.ProjectRef(); err == nil {
+		t.Error("Expected error for unsupported purl type")
+	}
+}
+
+func TestProjectByPURL(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.EscapedPath(), "/NPM/@babel%2Fcore"; got != want {
+			t.Errorf("unexpected path %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{"name":"@babel/core"}`)
+	})
+
+	project, _, err := client.ProjectByPURL(context.Background(), "pkg:npm/%40babel/core@7.23.0")
+	if err != nil {
+		t.Fatalf("ProjectByPURL returned unexpected error: %v", err)
+	}
+
+	if want := (&Project{Name: String("@babel/core")}); !reflect.DeepEqual(project, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(project))
+	}
+}
+
+func TestProjectDepsByPURL(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	var paths []string
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		fmt.Fprint(w, `{"name":"django"}`)
+	})
+
+	for _, purl := range []string{"pkg:pypi/django@1.11.1", "pkg:pypi/django"} {
+		if _, _, err := client.ProjectDepsByPURL(context.Background(), purl); err != nil {
+			t.Fatalf("ProjectDepsByPURL returned unexpected error: %v", err)
+		}
+	}
+
+	want := []string{"/Pypi/django/1.11.1/dependencies", "/Pypi/django/latest/dependencies"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, paths)
+	}
+
+	if _, _, err := client.ProjectDepsByPURL(context.Background(), "pkg:docker/nginx"); err == nil {
+		t.Error("Expected error for unsupported purl type")
+	}
+}
+
+func TestPackageURLString(t *testing.T) {
+	testCases := []struct {
+		purl *PackageURL
+		want string
+	}{
+		{
+			&PackageURL{Type: "npm", Namespace: "@babel", Name: "core", Version: "7.23.0"},
+			"pkg:npm/%40babel/core@7.23.0",
+		},
+		{
+			&PackageURL{
+				Type:       "gem",
+				Name:       "jruby-launcher",
+				Version:    "1.1.2",
+				Qualifiers: map[string]string{"platform": "java", "empty": ""},
+				Subpath:    "lib/x",
+			},
+			"pkg:gem/jruby-launcher@1.1.2?platform=java#lib/x",
+		},
+	}
+
+	for _, testCase := range testCases {
+		if got := testCase.purl.String(); got != testCase.want {
+			t.Errorf("\nExpected %v\nGot %v", testCase.want, got)
+		}
+
+		p, err := ParsePackageURL(testCase.want)
+		if err != nil {
+			t.Fatalf("ParsePackageURL(%q) returned unexpected error: %v", testCase.want, err)
+		}
+		if got := p.String(); got != testCase.want {
+			t.Errorf("String() does not round-trip, got %v", got)
+		}
+	}
+}
+
+func TestProjectPURL(t *testing.T) {
+	testCases := []struct {
+		project *Project
+		want    string
+	}{
+		{&Project{Platform: String("NPM"), Name: String("@babel/core")}, "pkg:npm/%40babel/core"},
+		{&Project{Platform: String("Pypi"), Name: String("Flask_SQLAlchemy")}, "pkg:pypi/flask-sqlalchemy"},
+		{&Project{Platform: String("Maven"), Name: String("org.apache.commons:io")}, "pkg:maven/org.apache.commons/io"},
+		{
+			&Project{Platform: String("Go"), Name: String("github.com/gorilla/context"), DependenciesForVersion: String("v1.1.1")},
+			"pkg:golang/github.com/gorilla/context@v1.1.1",
+		},
+	}
+
+	for _, testCase := range testCases {
+		got, err := testCase.project.PURL()
+		if err != nil {
+			t.Fatalf("PURL returned unexpected error: %v", err)
+		}
+		if got != testCase.want {
+			t.Errorf("\nExpected %v\nGot %v", testCase.want, got)
+		}
+	}
+
+	if _, err := (&Project{Platform: String("Nope"), Name: String("x")}).PURL(); err == nil {
+		t.Error("Expected error for unknown platform")
+	}
+}
+
+func TestProjectDependencyPURL(t *testing.T) {
+	dep := &ProjectDependency{
+		Platform:     String("NPM"),
+		Name:         String("chalk"),
+		ProjectName:  String("chalk"),
+		Requirements: String("^1.1.3"),
+	}
+
+	got, err := dep.PURL()
+	if err != nil {
+		t.Fatalf("PURL returned unexpected error: %v", err)
+	}
+	if want := "pkg:npm/chalk"; got != want {
+		t.Errorf("\nExpected %v\nGot %v", want, got)
+	}
+}
+
+func TestDependencyPURLs(t *testing.T) {
+	tree := &DependencyNode{
+		Platform: "NPM",
+		Name:     "app",
+		Version:  "1.0.0",
+		Dependencies: []*DependencyNode{
+			{Platform: "NPM", Name: "chalk", Version: "1.1.3", Requirements: "^1.1.0", Dependencies: []*DependencyNode{
+				{Platform: "NPM", Name: "@babel/core", Version: "latest", Requirements: "*"},
+			}},
+			{Platform: "NPM", Name: "@babel/core", Version: "latest", Requirements: "*"},
+			{Platform: "NPM", Name: "chalk", Version: "1.1.3", Requirements: "~1.1.3"},
+		},
+	}
+
+	testCases := []struct {
+		name string
+		opts *PURLOptions
+		want []string
+	}{
+		{
+			"default",
+			nil,
+			[]string{"pkg:npm/%40babel/core", "pkg:npm/chalk"},
+		},
+		{
+			"versions",
+			&PURLOptions{Versions: true},
+			[]string{"pkg:npm/%40babel/core", "pkg:npm/chalk@1.1.3"},
+		},
+		{
+			"ranges",
+			&PURLOptions{Ranges: true},
+			[]string{
+				"pkg:npm/%40babel/core?vers=vers:npm%2F%2A",
+				"pkg:npm/chalk?vers=vers:npm%2F%5E1.1.0",
+				"pkg:npm/chalk?vers=vers:npm%2F~1.1.3",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := DependencyPURLs(tree, testCase.opts)
+			if err != nil {
+				t.Fatalf("DependencyPURLs returned unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, testCase.want) {
+				t.Errorf("\nExpected %v\nGot %v", testCase.want, got)
+			}
+		})
+	}
+}
+
+func TestDependencyPURLs_unknownPlatform(t *testing.T) {
+	tree := &DependencyNode{
+		Name:         "app",
+		Dependencies: []*DependencyNode{{Platform: "Nope", Name: "x"}},
+	}
+
+	if _, err := DependencyPURLs(tree, nil); err == nil {
+		t.Error("Expected error for unknown platform")
+	}
+}
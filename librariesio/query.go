@@ -0,0 +1,136 @@
+package librariesio
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// addOptions adds the query params encoded from opts to the given URL.
+//
+// Fields of opts are encoded according to their `url` struct tag, which
+// holds the name of the query param and an optional "omitempty" flag.
+// Fields tagged with "-" are skipped and embedded structs are flattened.
+// Slices are encoded as comma separated values, which is what the
+// libraries.io API expects for filters like platforms or licenses.
+func addOptions(urlStr string, opts interface{}) (string, error) {
+	v := reflect.ValueOf(opts)
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return urlStr, nil
+	}
+
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return urlStr, err
+	}
+
+	q := u.Query()
+	if err := encodeQuery(q, v); err != nil {
+		return urlStr, err
+	}
+
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+func encodeQuery(q url.Values, v reflect.Value) error {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("query options must be a struct, got %v", v.Kind())
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		value := v.Field(i)
+
+		tag := field.Tag.Get("url")
+		if tag == "-" {
+			continue
+		}
+
+		if field.Anonymous && tag == "" {
+			if err := encodeQuery(q, value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name, flags := tag, ""
+		if i := strings.Index(tag, ","); i >= 0 {
+			name, flags = tag[:i], tag[i+1:]
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		if flags == "omitempty" && isEmptyValue(value) {
+			continue
+		}
+
+		s, err := formatQueryValue(value)
+		if err != nil {
+			return fmt.Errorf("query param %v: %v", name, err)
+		}
+		q.Set(name, s)
+	}
+	return nil
+}
+
+func formatQueryValue(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Slice, reflect.Array:
+		values := make([]string, v.Len())
+		for i := range values {
+			s, err := formatQueryValue(v.Index(i))
+			if err != nil {
+				return "", err
+			}
+			values[i] = s
+		}
+		return strings.Join(values, ","), nil
+	}
+	return "", fmt.Errorf("unsupported type %v", v.Type())
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
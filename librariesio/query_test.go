@@ -0,0 +1,86 @@
+package librariesio
+
+import (
+	"testing"
+)
+
+func TestAddOptions(t *testing.T) {
+	type embedded struct {
+		Page int `url:"page,omitempty"`
+	}
+
+	type options struct {
+		Name       string   `url:"name"`
+		Sort       string   `url:"sort,omitempty"`
+		Platforms  []string `url:"platforms,omitempty"`
+		Prerelease bool     `url:"prerelease"`
+		Count      *int     `url:"count,omitempty"`
+		Skipped    string   `url:"-"`
+		embedded
+	}
+
+	testCases := []struct {
+		name string
+		opts interface{}
+		want string
+	}{
+		{"nil", (*options)(nil), "search?q=go"},
+		{"empty", options{}, "search?name=&prerelease=false&q=go"},
+		{
+			"all",
+			&options{
+				Name:       "amelia",
+				Sort:       "stars",
+				Platforms:  []string{"npm", "pypi"},
+				Prerelease: true,
+				Count:      Int(3),
+				Skipped:    "nope",
+				embedded:   embedded{Page: 2},
+			},
+			"search?count=3&name=amelia&page=2&platforms=npm%2Cpypi&prerelease=true&q=go&sort=stars",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := addOptions("search?q=go", testCase.opts)
+			if err != nil {
+				t.Fatalf("addOptions returned unexpected error: %v", err)
+			}
+			if got != testCase.want {
+				t.Errorf("\nExpected %v\nGot %v", testCase.want, got)
+			}
+		})
+	}
+}
+
+func TestAddOptions_errors(t *testing.T) {
+	type unsupported struct {
+		Values map[string]string `url:"values"`
+	}
+
+	for _, opts := range []interface{}{"nope", unsupported{}} {
+		if _, err := addOptions("search", opts); err == nil {
+			t.Errorf("addOptions(%#v) did not return an error", opts)
+		}
+	}
+}
+
+func TestSearchOptionsQuery(t *testing.T) {
+	opts := SearchOptions{
+		Sort:        "stars",
+		Platforms:   []string{"Pypi"},
+		Licenses:    []string{"MIT", "BSD-3-Clause"},
+		ListOptions: ListOptions{Page: 2, PerPage: 50},
+	}
+
+	got, err := addOptions("search?q=cookiecutter", opts)
+	if err != nil {
+		t.Fatalf("addOptions returned unexpected error: %v", err)
+	}
+
+	want := "search?licenses=MIT%2CBSD-3-Clause&page=2&per_page=50&platforms=Pypi&q=cookiecutter&sort=stars"
+	if got != want {
+		t.Errorf("\nExpected %v\nGot %v", want, got)
+	}
+}
@@ -0,0 +1,123 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// rateStatus holds the rate limit reported by the most recent response
+type rateStatus struct {
+	mu        sync.Mutex
+	known     bool
+	remaining int
+	resetsAt  time.Time
+}
+
+// update records the rate limit headers of resp, responses
+// without rate limit headers are ignored
+func (r *rateStatus) update(resp *http.Response) {
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+
+	var resetsAt time.Time
+	if reset, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Reset")); err == nil {
+		resetsAt = now().Add(time.Duration(reset) * time.Second)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.known = true
+	r.remaining = remaining
+	r.resetsAt = resetsAt
+}
+
+// RateLimitError is returned instead of retrying a rate limited request
+// when the time until the rate limit resets exceeds the maximum wait set
+// with WithMaxRateLimitWait
+type RateLimitError struct {
+	// Wait is the time until the request could be retried
+	Wait    time.Duration
+	MaxWait time.Duration
+
+	// Err is the ErrorResponse of the rate limited request
+	Err error
+}
+
+// Error returns how long the request would have to wait
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limit exceeded, retry in %v exceeds maximum wait of %v", e.Wait, e.MaxWait)
+}
+
+// Unwrap returns the ErrorResponse of the rate limited request
+func (e *RateLimitError) Unwrap() error {
+	return e.Err
+}
+
+// WithMaxRateLimitWait bounds how long the client sleeps for the rate
+// limit to reset before retrying a request when Retry is enabled. If the
+// reset is further away, a RateLimitError is returned right away.
+func WithMaxRateLimitWait(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.maxRateLimitWait = d
+	}
+}
+
+// RateRemaining returns the number of requests left in the current rate
+// limit window as reported by the most recent response, or -1 if no
+// response reported it yet. It is safe for concurrent use.
+func (c *Client) RateRemaining() int {
+	c.rate.mu.Lock()
+	defer c.rate.mu.Unlock()
+	if !c.rate.known {
+		return -1
+	}
+	return c.rate.remaining
+}
+
+// RateResetsAt returns when the current rate limit window resets as
+// reported by the most recent response, or the zero time if unknown.
+// It is safe for concurrent use.
+func (c *Client) RateResetsAt() time.Time {
+	c.rate.mu.Lock()
+	defer c.rate.mu.Unlock()
+	return c.rate.resetsAt
+}
+
+// pageDelay returns the delay that spreads the remaining requests
+// evenly until the rate limit resets, it is zero if unknown
+func (r *rateStatus) pageDelay(at time.Time) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.known || r.remaining <= 0 || r.resetsAt.IsZero() {
+		return 0
+	}
+	until := r.resetsAt.Sub(at)
+	if until <= 0 {
+		return 0
+	}
+	return until / time.Duration(r.remaining)
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
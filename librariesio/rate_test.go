@@ -0,0 +1,156 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClientRateStatus(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	defer func(f func() time.Time) { now = f }(now)
+	at := time.Date(2017, time.April, 1, 0, 0, 0, 0, time.UTC)
+	now = func() time.Time { return at }
+
+	if got := client.RateRemaining(); got != -1 {
+		t.Errorf("expected -1 before any request, got %d", got)
+	}
+	if got := client.RateResetsAt(); !got.IsZero() {
+		t.Errorf("expected zero time before any request, got %v", got)
+	}
+
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-RateLimit-Reset", "30")
+		fmt.Fprint(w, `{"name":"cookiecutter"}`)
+	})
+	mux.HandleFunc("/pypi/other", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"other"}`)
+	})
+
+	if _, _, err := client.Project(context.Background(), "pypi", "cookiecutter"); err != nil {
+		t.Fatalf("Project returned unexpected error: %v", err)
+	}
+
+	// responses without rate limit headers keep the last known status
+	if _, _, err := client.Project(context.Background(), "pypi", "other"); err != nil {
+		t.Fatalf("Project returned unexpected error: %v", err)
+	}
+
+	if got, want := client.RateRemaining(), 42; got != want {
+		t.Errorf("RateRemaining is %d, want %d", got, want)
+	}
+	if got, want := client.RateResetsAt(), at.Add(30*time.Second); !got.Equal(want) {
+		t.Errorf("RateResetsAt is %v, want %v", got, want)
+	}
+}
+
+func TestClientRateStatus_concurrent(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "1")
+		fmt.Fprint(w, `{}`)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.Project(context.Background(), "npm", "ava")
+			client.RateRemaining()
+			client.RateResetsAt()
+		}()
+	}
+	wg.Wait()
+
+	if got := client.RateRemaining(); got != 1 {
+		t.Errorf("RateRemaining is %d, want 1", got)
+	}
+}
+
+func TestRateStatusPageDelay(t *testing.T) {
+	at := time.Date(2017, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name   string
+		status *rateStatus
+		want   time.Duration
+	}{
+		{"unknown", &rateStatus{}, 0},
+		{"spread", &rateStatus{known: true, remaining: 30, resetsAt: at.Add(time.Minute)}, 2 * time.Second},
+		{"exhausted", &rateStatus{known: true, remaining: 0, resetsAt: at.Add(time.Minute)}, 0},
+		{"reset", &rateStatus{known: true, remaining: 30, resetsAt: at.Add(-time.Second)}, 0},
+	}
+
+	for _, testCase := range testCases {
+		if got := testCase.status.pageDelay(at); got != testCase.want {
+			t.Errorf("%v: pageDelay is %v, want %v", testCase.name, got, testCase.want)
+		}
+	}
+}
+
+func TestWithMaxRateLimitWait(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey, WithMaxRateLimitWait(30*time.Second))
+	client.BaseURL = url
+	client.Retry = true
+	defer server.Close()
+
+	var calls int
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-RateLimit-Reset", "59")
+		http.Error(w, `{"error":"Too Many Requests"}`, http.StatusTooManyRequests)
+	})
+
+	_, _, err := client.Project(context.Background(), "npm", "ava")
+
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) {
+		t.Fatalf("expected *RateLimitError, got %v", err)
+	}
+	if rateErr.Wait != time.Minute || rateErr.MaxWait != 30*time.Second {
+		t.Errorf("unexpected wait %v and max wait %v", rateErr.Wait, rateErr.MaxWait)
+	}
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected RateLimitError to wrap the 429 response")
+	}
+	if calls != 1 {
+		t.Errorf("expected request not to be retried, got %d calls", calls)
+	}
+}
+
+func TestRetry_cancelWait(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	client.Retry = true
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Reset", "59")
+		http.Error(w, `{"error":"Too Many Requests"}`, http.StatusTooManyRequests)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, _, err := client.Project(ctx, "npm", "ava"); err != context.DeadlineExceeded {
+		t.Errorf("expected wait for rate limit reset to be cancelled, got %v", err)
+	}
+}
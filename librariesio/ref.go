@@ -0,0 +1,122 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ProjectRef identifies a project on a given platform and optionally
+// a specific version of it
+type ProjectRef struct {
+	Platform string
+	Name     string
+	Version  string
+}
+
+// String returns the ref as platform/name or platform/name@version
+func (r ProjectRef) String() string {
+	s := r.Platform + "/" + r.Name
+	if r.Version != "" {
+		s += "@" + r.Version
+	}
+	return s
+}
+
+// ParseProjectRef parses a project reference in one of the shorthand
+// formats platform/name, platform:name or a package URL, each optionally
+// followed by @version, e.g. npm:react@18.2.0, pypi/requests or
+// pkg:npm/%40babel/core@7.23.0. Names may contain / and : themselves, as
+// in npm/@babel/core or maven:org.slf4j:slf4j-api, only the first
+// separator ends the platform.
+func ParseProjectRef(s string) (ProjectRef, error) {
+	rest := strings.TrimSpace(s)
+	if strings.HasPrefix(strings.ToLower(rest), "pkg:") {
+		purl, err := ParsePackageURL(rest)
+		if err != nil {
+			return ProjectRef{}, err
+		}
+		return purl.ProjectRef()
+	}
+
+	i := strings.IndexAny(rest, "/:")
+	if i <= 0 {
+		return ProjectRef{}, fmt.Errorf("project ref %q has no platform", s)
+	}
+	ref := ProjectRef{Platform: rest[:i]}
+	rest = rest[i+1:]
+
+	// A leading @ belongs to the name, e.g. of scoped npm packages
+	if i := strings.LastIndex(rest, "@"); i > 0 {
+		ref.Version = rest[i+1:]
+		if ref.Version == "" {
+			return ProjectRef{}, fmt.Errorf("project ref %q has an empty version", s)
+		}
+		rest = rest[:i]
+	}
+
+	ref.Name = rest
+	if ref.Name == "" {
+		return ProjectRef{}, fmt.Errorf("project ref %q has no name", s)
+	}
+	return ref, nil
+}
+
+// versionOrLatest returns the version of the ref, or "latest" if it has none
+func (r ProjectRef) versionOrLatest() string {
+	if r.Version == "" {
+		return "latest"
+	}
+	return r.Version
+}
+
+// ProjectByRef returns information about the referenced project,
+// the version of the ref is ignored, see Project
+func (c *Client) ProjectByRef(ctx context.Context, ref ProjectRef) (*Project, *Response, error) {
+	return c.Project(ctx, ref.Platform, ref.Name)
+}
+
+// ProjectDepsByRef returns the dependencies of the referenced project
+// version, or of the latest version if the ref has none, see ProjectDeps
+func (c *Client) ProjectDepsByRef(ctx context.Context, ref ProjectRef) (*Project, *Response, error) {
+	return c.ProjectDeps(ctx, ref.Platform, ref.Name, ref.versionOrLatest())
+}
+
+// ResolveTreeByRef resolves the dependency tree of the referenced project
+// version, or of the latest version if the ref has none, see ResolveTree
+func (c *Client) ResolveTreeByRef(ctx context.Context, ref ProjectRef, opts *ResolveOptions) (*DependencyNode, error) {
+	return c.ResolveTree(ctx, ref.Platform, ref.Name, ref.versionOrLatest(), opts)
+}
+
+// SubscriptionByRef returns the subscription to the referenced project,
+// see Subscription
+func (c *Client) SubscriptionByRef(ctx context.Context, ref ProjectRef) (*Subscription, *Response, error) {
+	return c.Subscription(ctx, ref.Platform, ref.Name)
+}
+
+// SubscribeByRef subscribes to the referenced project, see Subscribe
+func (c *Client) SubscribeByRef(ctx context.Context, ref ProjectRef, includePrerelease bool) (*Subscription, *Response, error) {
+	return c.Subscribe(ctx, ref.Platform, ref.Name, includePrerelease)
+}
+
+// UpdateSubscriptionByRef updates the subscription to the referenced
+// project, see UpdateSubscription
+func (c *Client) UpdateSubscriptionByRef(ctx context.Context, ref ProjectRef, includePrerelease bool) (*Subscription, *Response, error) {
+	return c.UpdateSubscription(ctx, ref.Platform, ref.Name, includePrerelease)
+}
+
+// UnsubscribeByRef removes the subscription to the referenced project,
+// see Unsubscribe
+func (c *Client) UnsubscribeByRef(ctx context.Context, ref ProjectRef) (*Response, error) {
+	return c.Unsubscribe(ctx, ref.Platform, ref.Name)
+}
+
+// Ref returns the ref of the project, the version is only set for
+// projects returned by ProjectDeps
+func (p *Project) Ref() ProjectRef {
+	return ProjectRef{
+		Platform: stringValue(p.Platform),
+		Name:     stringValue(p.Name),
+		Version:  stringValue(p.DependenciesForVersion),
+	}
+}
@@ -0,0 +1,107 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestProjectRefString(t *testing.T) {
+	testCases := []struct {
+		ref  ProjectRef
+		want string
+	}{
+		{ProjectRef{Platform: "npm", Name: "react"}, "npm/react"},
+		{ProjectRef{Platform: "npm", Name: "react", Version: "15.5.4"}, "npm/react@15.5.4"},
+	}
+
+	for _, testCase := range testCases {
+		if got := testCase.ref.String(); got != testCase.want {
+			t.Errorf("String() returned %q, want %q", got, testCase.want)
+		}
+	}
+}
+
+func TestParseProjectRef(t *testing.T) {
+	testCases := []struct {
+		s    string
+		want ProjectRef
+	}{
+		{"npm:react@18.2.0", ProjectRef{Platform: "npm", Name: "react", Version: "18.2.0"}},
+		{"pypi/requests", ProjectRef{Platform: "pypi", Name: "requests"}},
+		{" npm/@babel/core@7.23.0 ", ProjectRef{Platform: "npm", Name: "@babel/core", Version: "7.23.0"}},
+		{"npm:@babel/core", ProjectRef{Platform: "npm", Name: "@babel/core"}},
+		{"maven:org.slf4j:slf4j-api@2.0.9", ProjectRef{Platform: "maven", Name: "org.slf4j:slf4j-api", Version: "2.0.9"}},
+		{"go/github.com/pkg/errors", ProjectRef{Platform: "go", Name: "github.com/pkg/errors"}},
+		{"pkg:npm/%40babel/core@7.23.0", ProjectRef{Platform: "NPM", Name: "@babel/core", Version: "7.23.0"}},
+	}
+
+	for _, testCase := range testCases {
+		got, err := ParseProjectRef(testCase.s)
+		if err != nil {
+			t.Errorf("ParseProjectRef(%q) returned unexpected error: %v", testCase.s, err)
+			continue
+		}
+		if got != testCase.want {
+			t.Errorf("ParseProjectRef(%q) returned %+v, want %+v", testCase.s, got, testCase.want)
+		}
+	}
+}
+
+func TestParseProjectRef_errors(t *testing.T) {
+	for _, s := range []string{"", "react", "/react", "npm:", "npm/react@", "pkg:unknown/x"} {
+		if ref, err := ParseProjectRef(s); err == nil {
+			t.Errorf("ParseProjectRef(%q) returned %+v, expected an error", s, ref)
+		}
+	}
+}
+
+func TestClientByRef(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	var paths []string
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/npm/react/latest/dependencies":
+			fmt.Fprint(w, `{"name":"react","platform":"NPM","dependencies_for_version":"18.2.0"}`)
+		default:
+			fmt.Fprint(w, `{"name":"react","platform":"NPM"}`)
+		}
+	})
+
+	ctx := context.Background()
+	ref := ProjectRef{Platform: "npm", Name: "react"}
+
+	if _, _, err := client.ProjectByRef(ctx, ProjectRef{Platform: "npm", Name: "react", Version: "18.2.0"}); err != nil {
+		t.Fatalf("ProjectByRef returned unexpected error: %v", err)
+	}
+	project, _, err := client.ProjectDepsByRef(ctx, ref)
+	if err != nil {
+		t.Fatalf("ProjectDepsByRef returned unexpected error: %v", err)
+	}
+	if want := (ProjectRef{Platform: "NPM", Name: "react", Version: "18.2.0"}); project.Ref() != want {
+		t.Errorf("Ref() returned %+v, want %+v", project.Ref(), want)
+	}
+	if _, _, err := client.SubscribeByRef(ctx, ref, true); err != nil {
+		t.Fatalf("SubscribeByRef returned unexpected error: %v", err)
+	}
+	if _, err := client.UnsubscribeByRef(ctx, ref); err != nil {
+		t.Fatalf("UnsubscribeByRef returned unexpected error: %v", err)
+	}
+
+	want := []string{
+		"GET /npm/react",
+		"GET /npm/react/latest/dependencies",
+		"POST /subscriptions/npm/react",
+		"DELETE /subscriptions/npm/react",
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, paths)
+	}
+}
@@ -0,0 +1,95 @@
+package librariesio
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// registryURLs build the package page of a project per platform,
+// version is empty for the page of the project itself
+var registryURLs = map[string]func(name, version string) string{
+	"cargo": func(name, version string) string {
+		return "https://crates.io/crates/" + escapePath(name) + optional("/", version)
+	},
+	"clojars": func(name, version string) string {
+		return "https://clojars.org/" + escapePath(name) + optional("/versions/", version)
+	},
+	"cocoapods": func(name, version string) string {
+		return "https://cocoapods.org/pods/" + escapePath(name)
+	},
+	"cpan": func(name, version string) string {
+		return "https://metacpan.org/dist/" + escapePath(name)
+	},
+	"cran": func(name, version string) string {
+		return "https://cran.r-project.org/package=" + url.QueryEscape(name)
+	},
+	"go": func(name, version string) string {
+		return "https://pkg.go.dev/" + escapePath(name) + optional("@", version)
+	},
+	"hackage": func(name, version string) string {
+		return "https://hackage.haskell.org/package/" + escapePath(name) + optional("-", version)
+	},
+	"hex": func(name, version string) string {
+		return "https://hex.pm/packages/" + escapePath(name) + optional("/", version)
+	},
+	"maven": func(name, version string) string {
+		// Maven projects are named groupId:artifactId
+		return "https://central.sonatype.com/artifact/" + escapePath(strings.Replace(name, ":", "/", 1)) + optional("/", version)
+	},
+	"npm": func(name, version string) string {
+		return "https://www.npmjs.com/package/" + escapePath(name) + optional("/v/", version)
+	},
+	"nuget": func(name, version string) string {
+		return "https://www.nuget.org/packages/" + escapePath(name) + optional("/", version)
+	},
+	"packagist": func(name, version string) string {
+		return "https://packagist.org/packages/" + escapePath(name) + optional("#", version)
+	},
+	"pub": func(name, version string) string {
+		return "https://pub.dev/packages/" + escapePath(name) + optional("/versions/", version)
+	},
+	"pypi": func(name, version string) string {
+		u := "https://pypi.org/project/" + escapePath(name) + "/"
+		if version != "" {
+			u += url.PathEscape(version) + "/"
+		}
+		return u
+	},
+	"rubygems": func(name, version string) string {
+		return "https://rubygems.org/gems/" + escapePath(name) + optional("/versions/", version)
+	},
+}
+
+// RegistryURL returns the page of the project, or of the given version if
+// it is not empty, on the website of its package manager. It is useful as
+// Project.PackageManagerURL is often missing. Platforms whose registries
+// have no pages per version link to the project instead.
+func RegistryURL(plat, name, version string) (string, error) {
+	build, ok := registryURLs[strings.ToLower(plat)]
+	if !ok {
+		return "", fmt.Errorf("no registry URL known for platform %q", plat)
+	}
+	if name == "" {
+		return "", fmt.Errorf("registry URL for platform %q requires a name", plat)
+	}
+	return build(name, version), nil
+}
+
+// escapePath escapes every segment of a / separated path
+func escapePath(s string) string {
+	segments := strings.Split(s, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// optional returns prefix followed by the escaped value,
+// or an empty string if value is empty
+func optional(prefix, value string) string {
+	if value == "" {
+		return ""
+	}
+	return prefix + url.PathEscape(value)
+}
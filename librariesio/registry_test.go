@@ -0,0 +1,44 @@
+package librariesio
+
+import "testing"
+
+func TestRegistryURL(t *testing.T) {
+	testCases := []struct {
+		plat, name, version string
+		want                string
+	}{
+		{"NPM", "@babel/core", "", "https://www.npmjs.com/package/@babel/core"},
+		{"npm", "@babel/core", "7.23.0", "https://www.npmjs.com/package/@babel/core/v/7.23.0"},
+		{"Pypi", "cookiecutter", "", "https://pypi.org/project/cookiecutter/"},
+		{"Pypi", "cookiecutter", "2.5.0", "https://pypi.org/project/cookiecutter/2.5.0/"},
+		{"Go", "github.com/pkg/errors", "v0.9.1", "https://pkg.go.dev/github.com/pkg/errors@v0.9.1"},
+		{"Cargo", "serde", "1.0.0", "https://crates.io/crates/serde/1.0.0"},
+		{"Rubygems", "rails", "7.1.0", "https://rubygems.org/gems/rails/versions/7.1.0"},
+		{"Maven", "org.apache.commons:commons-lang3", "3.14.0", "https://central.sonatype.com/artifact/org.apache.commons/commons-lang3/3.14.0"},
+		{"Packagist", "laravel/framework", "v10.0.0", "https://packagist.org/packages/laravel/framework#v10.0.0"},
+		{"CocoaPods", "Alamofire", "5.8.0", "https://cocoapods.org/pods/Alamofire"},
+		{"CRAN", "ggplot2", "", "https://cran.r-project.org/package=ggplot2"},
+		{"Hackage", "aeson", "2.2.0.0", "https://hackage.haskell.org/package/aeson-2.2.0.0"},
+		{"NuGet", "Newtonsoft.Json", "13.0.3", "https://www.nuget.org/packages/Newtonsoft.Json/13.0.3"},
+	}
+
+	for _, testCase := range testCases {
+		got, err := RegistryURL(testCase.plat, testCase.name, testCase.version)
+		if err != nil {
+			t.Errorf("%v/%v: RegistryURL returned unexpected error: %v", testCase.plat, testCase.name, err)
+			continue
+		}
+		if got != testCase.want {
+			t.Errorf("%v/%v: RegistryURL is %q, want %q", testCase.plat, testCase.name, got, testCase.want)
+		}
+	}
+}
+
+func TestRegistryURL_errors(t *testing.T) {
+	if _, err := RegistryURL("Unknown", "x", ""); err == nil {
+		t.Error("Expected error for unknown platform")
+	}
+	if _, err := RegistryURL("npm", "", ""); err == nil {
+		t.Error("Expected error for empty name")
+	}
+}
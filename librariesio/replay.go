@@ -0,0 +1,232 @@
+package librariesio
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// FailedRequest is a request recorded in a ReplayQueue, the api_key
+// is not stored and added again when the request is replayed
+type FailedRequest struct {
+	Method   string          `json:"method"`
+	URL      string          `json:"url"`
+	Body     json.RawMessage `json:"body,omitempty"`
+	Error    string          `json:"error"`
+	FailedAt time.Time       `json:"failed_at"`
+}
+
+// ReplayQueue stores failed requests so they can be sent again
+// later with Client.ReplayFailed
+type ReplayQueue interface {
+	// Add stores a failed request
+	Add(req *FailedRequest) error
+
+	// Take removes and returns all stored requests
+	Take() ([]*FailedRequest, error)
+}
+
+// WithReplayQueue records requests other than GET that are rate limited or
+// fail with a network or server error to the given queue. Requests sent
+// with WithAPIKey are not recorded, as they would be replayed with the key
+// of the client.
+func WithReplayQueue(q ReplayQueue) ClientOption {
+	return func(c *Client) {
+		c.replayQueue = q
+	}
+}
+
+// FileReplayQueue is a ReplayQueue that stores requests as JSON lines in
+// a file, so they survive restarts of unattended batch jobs. It is safe
+// for concurrent use within a single process.
+type FileReplayQueue struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileReplayQueue returns a queue stored at path,
+// the file is created when the first request is added
+func NewFileReplayQueue(path string) *FileReplayQueue {
+	return &FileReplayQueue{path: path}
+}
+
+// Add appends the request to the file
+func (q *FileReplayQueue) Add(req *FailedRequest) error {
+	line, err := marshalLine(req)
+	if err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	f, err := os.OpenFile(q.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(line); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Take reads all requests from the file and truncates it
+func (q *FileReplayQueue) Take() ([]*FailedRequest, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	f, err := os.OpenFile(q.path, os.O_RDWR, 0600)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var reqs []*FailedRequest
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		req := new(FailedRequest)
+		if err := json.Unmarshal(scanner.Bytes(), req); err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, req)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return reqs, f.Truncate(0)
+}
+
+// recordFailure adds req to the replay queue if it failed in a way that
+// may succeed later, resp is nil if no response was received
+func (c *Client) recordFailure(ctx context.Context, req *http.Request, resp *http.Response, cfg *requestConfig, err error) {
+	if c.replayQueue == nil || cfg.replaying || cfg.apiKey != "" || ctx.Err() != nil {
+		return
+	}
+
+	// A replayed GET has no caller to return its result to
+	switch {
+	case req.Method == http.MethodGet:
+		return
+	case resp == nil || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+	default:
+		return
+	}
+
+	u := *req.URL
+	q := u.Query()
+	q.Del("api_key")
+	u.RawQuery = q.Encode()
+
+	failed := &FailedRequest{
+		Method:   req.Method,
+		URL:      u.String(),
+		Error:    err.Error(),
+		FailedAt: now(),
+	}
+
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			body.Close()
+			if len(data) > 0 {
+				failed.Body = json.RawMessage(bytes.TrimSpace(data))
+			}
+		}
+	}
+
+	if cfg.deferred != nil {
+		*cfg.deferred = failed
+		return
+	}
+
+	// Recording is best effort, the original error is returned to the caller
+	c.replayQueue.Add(failed)
+}
+
+// deferFailure makes recordFailure hand the failed request to *failed
+// instead of adding it to the replay queue, for callers that may find
+// out whether it was applied after all
+func deferFailure(failed **FailedRequest) RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.deferred = failed
+	}
+}
+
+// queueFailure adds a request deferred with deferFailure to the
+// replay queue, failed may be nil
+func (c *Client) queueFailure(failed *FailedRequest) {
+	if failed != nil && c.replayQueue != nil {
+		c.replayQueue.Add(failed)
+	}
+}
+
+// ReplayFailed sends the requests recorded in the replay queue again.
+// Requests that fail again are put back into the queue. It returns the
+// number of requests that succeeded and failed, err is only set if the
+// queue could not be read or ctx was cancelled, in which case the
+// remaining requests are put back into the queue as well.
+func (c *Client) ReplayFailed(ctx context.Context) (succeeded, failed int, err error) {
+	if c.replayQueue == nil {
+		return 0, 0, nil
+	}
+
+	reqs, err := c.replayQueue.Take()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for i, failedReq := range reqs {
+		if err := ctx.Err(); err != nil {
+			for _, r := range reqs[i:] {
+				c.replayQueue.Add(r)
+			}
+			return succeeded, failed, err
+		}
+
+		var data interface{}
+		if len(failedReq.Body) > 0 {
+			data = failedReq.Body
+		}
+
+		request, err := c.NewRequest(failedReq.Method, failedReq.URL, data)
+		if err == nil {
+			_, err = c.DoLazy(ctx, request, replaying())
+		}
+		if err == nil {
+			succeeded++
+			continue
+		}
+
+		failed++
+		failedReq.Error = err.Error()
+		failedReq.FailedAt = now()
+		if err := c.replayQueue.Add(failedReq); err != nil {
+			return succeeded, failed, err
+		}
+	}
+
+	return succeeded, failed, nil
+}
+
+// replaying keeps requests sent by ReplayFailed from being recorded twice
+func replaying() RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.replaying = true
+	}
+}
@@ -0,0 +1,97 @@
+package librariesio
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReplayFailed(t *testing.T) {
+	server, mux, url := startNewServer()
+	defer server.Close()
+
+	queue := NewFileReplayQueue(filepath.Join(t.TempDir(), "failed.jsonl"))
+	client := NewClient(APIKey, WithReplayQueue(queue))
+	client.BaseURL = url
+
+	var down = true
+	var bodies []string
+
+	mux.HandleFunc("/subscriptions/NPM/ava", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, strings.TrimSpace(string(body)))
+
+		if r.URL.Query().Get("api_key") != APIKey {
+			t.Errorf("expected api_key to be set, got %v", r.URL.RawQuery)
+		}
+		if down {
+			http.Error(w, `{"error":"Service Unavailable"}`, http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("/NPM/ava", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Internal Server Error"}`, http.StatusInternalServerError)
+	})
+	mux.HandleFunc("/subscriptions/NPM/mocha", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Too Many Requests"}`, http.StatusTooManyRequests)
+	})
+	mux.HandleFunc("/subscriptions/NPM/chai", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Service Unavailable"}`, http.StatusServiceUnavailable)
+	})
+	mux.HandleFunc("/NPM/mocha", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Too Many Requests"}`, http.StatusTooManyRequests)
+	})
+
+	ctx := context.Background()
+
+	if _, _, err := client.Subscribe(ctx, "NPM", "ava", true); err == nil {
+		t.Fatal("Expected Subscribe to fail")
+	}
+	if _, _, err := client.Subscribe(ctx, "NPM", "mocha", false); err == nil {
+		t.Fatal("Expected Subscribe to fail")
+	}
+	// failed GET requests are not recorded
+	client.Project(ctx, "NPM", "ava")
+	client.Project(ctx, "NPM", "mocha")
+	// nor requests sent with another API key
+	client.Subscribe(NewAPIKeyContext(ctx, "tenant"), "NPM", "chai", true)
+
+	down = false
+
+	succeeded, failed, err := client.ReplayFailed(ctx)
+	if err != nil {
+		t.Fatalf("ReplayFailed returned unexpected error: %v", err)
+	}
+	if succeeded != 1 || failed != 1 {
+		t.Errorf("expected 1 succeeded and 1 failed request, got %d and %d", succeeded, failed)
+	}
+
+	want := `{"include_prerelease":true}`
+	if len(bodies) != 2 || bodies[0] != want || bodies[1] != want {
+		t.Errorf("expected body to be replayed, got %v", bodies)
+	}
+
+	reqs, err := queue.Take()
+	if err != nil {
+		t.Fatalf("Take returned unexpected error: %v", err)
+	}
+	if len(reqs) != 1 || reqs[0].Method != "POST" || !strings.HasSuffix(reqs[0].URL, "/subscriptions/NPM/mocha") {
+		t.Fatalf("expected rate limited request to be queued again, got %+v", reqs)
+	}
+	if strings.Contains(reqs[0].URL, "api_key") {
+		t.Errorf("expected api_key not to be stored, got %v", reqs[0].URL)
+	}
+}
+
+func TestFileReplayQueue_missingFile(t *testing.T) {
+	queue := NewFileReplayQueue(filepath.Join(t.TempDir(), "missing.jsonl"))
+
+	reqs, err := queue.Take()
+	if err != nil || len(reqs) != 0 {
+		t.Errorf("expected empty queue, got %v, %v", reqs, err)
+	}
+}
@@ -0,0 +1,147 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// repoHosts maps repository host domains to libraries.io host types
+var repoHosts = map[string]string{
+	"github.com":    "GitHub",
+	"gitlab.com":    "GitLab",
+	"bitbucket.org": "Bitbucket",
+}
+
+// ParseRepoURL returns the host type, owner and name of a repository URL
+// on GitHub, GitLab or Bitbucket. It accepts web URLs, including links to
+// files or branches, clone URLs with a .git suffix, SSH remotes such as
+// git@github.com:owner/name.git and npm style shorthands such as
+// github:owner/name. GitLab owners may contain subgroups.
+func ParseRepoURL(repoURL string) (host, owner, name string, err error) {
+	s := strings.TrimSpace(repoURL)
+	s = strings.TrimPrefix(s, "git+")
+
+	// npm style shorthands, e.g. github:owner/name
+	for domain, hostType := range repoHosts {
+		prefix := strings.ToLower(hostType) + ":"
+		if strings.HasPrefix(strings.ToLower(s), prefix) {
+			s = domain + "/" + s[len(prefix):]
+			break
+		}
+	}
+
+	if i := strings.Index(s, "://"); i >= 0 {
+		s = s[i+3:]
+	} else if i := strings.Index(s, ":"); i >= 0 && !strings.Contains(s[:i], "/") {
+		// SCP-like SSH remote, e.g. git@github.com:owner/name.git
+		s = s[:i] + "/" + s[i+1:]
+	}
+	if i := strings.Index(s, "@"); i >= 0 && i < strings.Index(s+"/", "/") {
+		s = s[i+1:]
+	}
+	if i := strings.IndexAny(s, "?#"); i >= 0 {
+		s = s[:i]
+	}
+
+	parts := strings.Split(strings.Trim(s, "/"), "/")
+	domain := strings.TrimPrefix(strings.ToLower(parts[0]), "www.")
+	if i := strings.Index(domain, ":"); i >= 0 {
+		domain = domain[:i]
+	}
+
+	host, ok := repoHosts[domain]
+	if !ok {
+		return "", "", "", fmt.Errorf("repository URL %q is not on GitHub, GitLab or Bitbucket", repoURL)
+	}
+
+	path := parts[1:]
+	if host == "GitLab" {
+		// Links to files and branches continue after a "-" segment
+		for i, segment := range path {
+			if segment == "-" {
+				path = path[:i]
+				break
+			}
+		}
+	} else if len(path) > 2 {
+		path = path[:2]
+	}
+
+	if len(path) < 2 || path[0] == "" || path[len(path)-1] == "" {
+		return "", "", "", fmt.Errorf("repository URL %q has no owner and name", repoURL)
+	}
+
+	owner = strings.Join(path[:len(path)-1], "/")
+	name = strings.TrimSuffix(path[len(path)-1], ".git")
+	return host, owner, name, nil
+}
+
+// repositoryProjects returns the projects published from the repository
+//
+// GET https://libraries.io/api/:host/:owner/:name/projects
+func (c *Client) repositoryProjects(ctx context.Context, host, owner, name string) ([]*Project, *Response, error) {
+	urlStr := fmt.Sprintf("%v/%v/%v/projects", strings.ToLower(host), url.PathEscape(owner), url.PathEscape(name))
+
+	request, err := c.NewRequest("GET", urlStr, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var projects []*Project
+
+	response, err := c.Do(ctx, request, &projects)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return projects, response, nil
+}
+
+// FindProjectsByRepoURL returns the projects published from the repository
+// at repoURL, which may be in any format accepted by ParseRepoURL. It
+// combines the projects libraries.io lists for the repository with search
+// results for the repository name whose RepositoryURL points to the same
+// repository, as either lookup may miss projects. Projects listed for the
+// repository come first.
+func (c *Client) FindProjectsByRepoURL(ctx context.Context, repoURL string) ([]*Project, error) {
+	host, owner, name, err := ParseRepoURL(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	repo := strings.ToLower(host + "/" + owner + "/" + name)
+
+	listed, _, err := c.repositoryProjects(ctx, host, owner, name)
+	if err != nil && !isStatus(err, http.StatusNotFound) {
+		return nil, err
+	}
+
+	found, _, err := c.Search(ctx, name, &SearchOptions{ListOptions: ListOptions{PerPage: MaxPerPage}})
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []*Project
+	seen := make(map[string]bool)
+	add := func(p *Project) {
+		key := strings.ToLower(stringValue(p.Platform) + "/" + stringValue(p.Name))
+		if !seen[key] {
+			seen[key] = true
+			projects = append(projects, p)
+		}
+	}
+
+	for _, p := range listed {
+		add(p)
+	}
+	for _, p := range found {
+		h, o, n, err := ParseRepoURL(stringValue(p.RepositoryURL))
+		if err == nil && strings.ToLower(h+"/"+o+"/"+n) == repo {
+			add(p)
+		}
+	}
+
+	return projects, nil
+}
@@ -0,0 +1,117 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestParseRepoURL(t *testing.T) {
+	testCases := []struct {
+		repoURL           string
+		host, owner, name string
+	}{
+		{"https://github.com/gruntjs/grunt", "GitHub", "gruntjs", "grunt"},
+		{"https://github.com/gruntjs/grunt.git", "GitHub", "gruntjs", "grunt"},
+		{"http://www.github.com/gruntjs/grunt/", "GitHub", "gruntjs", "grunt"},
+		{"https://github.com/gruntjs/grunt/tree/main/lib#readme", "GitHub", "gruntjs", "grunt"},
+		{"git+https://github.com/gruntjs/grunt.git", "GitHub", "gruntjs", "grunt"},
+		{"git://github.com/gruntjs/grunt.git", "GitHub", "gruntjs", "grunt"},
+		{"git@github.com:gruntjs/grunt.git", "GitHub", "gruntjs", "grunt"},
+		{"ssh://git@github.com/gruntjs/grunt.git", "GitHub", "gruntjs", "grunt"},
+		{"github:gruntjs/grunt", "GitHub", "gruntjs", "grunt"},
+		{"github.com/gruntjs/grunt", "GitHub", "gruntjs", "grunt"},
+		{"https://gitlab.com/gitlab-org/charts/gitlab-runner", "GitLab", "gitlab-org/charts", "gitlab-runner"},
+		{"https://gitlab.com/gitlab-org/gitlab/-/tree/master/doc", "GitLab", "gitlab-org", "gitlab"},
+		{"git@gitlab.com:gitlab-org/gitlab.git", "GitLab", "gitlab-org", "gitlab"},
+		{"https://bitbucket.org/atlassian/python-bitbucket/src/master/", "Bitbucket", "atlassian", "python-bitbucket"},
+		{"https://user@bitbucket.org/atlassian/python-bitbucket.git", "Bitbucket", "atlassian", "python-bitbucket"},
+	}
+
+	for _, testCase := range testCases {
+		host, owner, name, err := ParseRepoURL(testCase.repoURL)
+		if err != nil {
+			t.Errorf("ParseRepoURL(%q) returned unexpected error: %v", testCase.repoURL, err)
+			continue
+		}
+		if host != testCase.host || owner != testCase.owner || name != testCase.name {
+			t.Errorf("ParseRepoURL(%q) = %v, %v, %v, want %v, %v, %v",
+				testCase.repoURL, host, owner, name, testCase.host, testCase.owner, testCase.name)
+		}
+	}
+}
+
+func TestParseRepoURL_errors(t *testing.T) {
+	for _, repoURL := range []string{
+		"",
+		"https://example.com/owner/name",
+		"https://github.com/gruntjs",
+		"https://github.com/",
+	} {
+		if _, _, _, err := ParseRepoURL(repoURL); err == nil {
+			t.Errorf("ParseRepoURL(%q) did not return an error", repoURL)
+		}
+	}
+}
+
+func TestFindProjectsByRepoURL(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/github/gruntjs/grunt/projects", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"name":"grunt","platform":"NPM"}]`)
+	})
+	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("q"); got != "grunt" {
+			t.Errorf("q is %q, want grunt", got)
+		}
+		fmt.Fprint(w, `[
+			{"name":"grunt","platform":"NPM","repository_url":"https://github.com/gruntjs/grunt"},
+			{"name":"grunt","platform":"Bower","repository_url":"git+https://github.com/GruntJS/grunt.git"},
+			{"name":"grunt-cli","platform":"NPM","repository_url":"https://github.com/gruntjs/grunt-cli"}
+		]`)
+	})
+
+	projects, err := client.FindProjectsByRepoURL(context.Background(), "git@github.com:gruntjs/grunt.git")
+	if err != nil {
+		t.Fatalf("FindProjectsByRepoURL returned unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, p := range projects {
+		got = append(got, stringValue(p.Platform)+"/"+stringValue(p.Name))
+	}
+	if want := []string{"NPM/grunt", "Bower/grunt"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, got)
+	}
+}
+
+func TestFindProjectsByRepoURL_unknownRepository(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/gitlab/acme/tool/projects", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Not Found"}`, http.StatusNotFound)
+	})
+	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"name":"tool","platform":"Pypi","repository_url":"https://gitlab.com/acme/tool"}]`)
+	})
+
+	projects, err := client.FindProjectsByRepoURL(context.Background(), "https://gitlab.com/acme/tool")
+	if err != nil {
+		t.Fatalf("FindProjectsByRepoURL returned unexpected error: %v", err)
+	}
+	if len(projects) != 1 || stringValue(projects[0].Name) != "tool" {
+		t.Errorf("expected the search result, got %d projects", len(projects))
+	}
+
+	if _, err := client.FindProjectsByRepoURL(context.Background(), "https://example.com/a/b"); err == nil {
+		t.Error("expected error for unsupported host")
+	}
+}
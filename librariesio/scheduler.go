@@ -0,0 +1,266 @@
+package librariesio
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Names of the metrics reported by a Scheduler, queued and in flight
+// are gauges that are increased and decreased as requests enter and
+// leave them, deduped counts requests that shared a pending response
+const (
+	MetricSchedulerQueued   = "scheduler_queued"
+	MetricSchedulerInFlight = "scheduler_in_flight"
+	MetricSchedulerDeduped  = "scheduler_deduped"
+)
+
+// Scheduler queues the requests of one or more clients, sending them in
+// order of priority while bounding concurrency and rate. Identical GET
+// requests that are pending at the same time are sent only once and
+// share the response.
+type Scheduler struct {
+	mu       sync.Mutex
+	free     int
+	seq      uint64
+	queue    []*scheduledTask
+	inFlight int
+	pending  map[string]*scheduledCall
+	closed   bool
+	active   activity
+
+	limiter *SharedLimiter
+	metrics Metrics
+}
+
+type scheduledTask struct {
+	priority Priority
+	seq      uint64
+	ready    chan struct{}
+}
+
+type scheduledCall struct {
+	done chan struct{}
+	resp *Response
+	err  error
+
+	// waiters is the number of callers waiting for the call,
+	// it is cancelled when the last one is gone
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// NewScheduler returns a scheduler sending at most concurrency requests
+// at once. Requests additionally wait for limiter and queue depth is
+// reported to metrics if they are not nil.
+func NewScheduler(concurrency int, limiter *SharedLimiter, metrics Metrics) *Scheduler {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &Scheduler{
+		free:    concurrency,
+		pending: make(map[string]*scheduledCall),
+		limiter: limiter,
+		metrics: metrics,
+	}
+}
+
+// WithScheduler sends all requests of the client through s
+func WithScheduler(s *Scheduler) ClientOption {
+	return func(c *Client) {
+		c.scheduler = s
+	}
+}
+
+// QueueDepth returns the number of requests waiting to be sent
+func (s *Scheduler) QueueDepth() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.queue)
+}
+
+// InFlight returns the number of requests being sent
+func (s *Scheduler) InFlight() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inFlight
+}
+
+// do runs fn once a slot is free, calls with the same non-empty key that
+// overlap share the result of the first one. The shared call keeps the
+// values but not the cancellation of the first ctx, it is only cancelled
+// once every caller waiting for it is gone.
+func (s *Scheduler) do(ctx context.Context, key string, priority Priority, fn func(context.Context) (*Response, error)) (*Response, error) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil, ErrShutdown
+	}
+	s.active.add()
+	s.mu.Unlock()
+	defer s.active.done()
+
+	if key == "" {
+		return s.run(ctx, priority, fn)
+	}
+
+	s.mu.Lock()
+	call, ok := s.pending[key]
+	if ok {
+		call.waiters++
+		s.mu.Unlock()
+		s.count(MetricSchedulerDeduped, 1)
+	} else {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &scheduledCall{done: make(chan struct{}), waiters: 1, cancel: cancel}
+		s.pending[key] = call
+		s.active.add()
+		s.mu.Unlock()
+
+		go func() {
+			defer s.active.done()
+			defer cancel()
+
+			call.resp, call.err = s.run(callCtx, priority, fn)
+
+			s.mu.Lock()
+			if s.pending[key] == call {
+				delete(s.pending, key)
+			}
+			s.mu.Unlock()
+			close(call.done)
+		}()
+	}
+
+	select {
+	case <-call.done:
+		return call.resp, call.err
+	case <-ctx.Done():
+	}
+
+	s.mu.Lock()
+	call.waiters--
+	if call.waiters == 0 {
+		if s.pending[key] == call {
+			delete(s.pending, key)
+		}
+		call.cancel()
+	}
+	s.mu.Unlock()
+	return nil, ctx.Err()
+}
+
+func (s *Scheduler) run(ctx context.Context, priority Priority, fn func(context.Context) (*Response, error)) (*Response, error) {
+	if err := s.acquire(ctx, priority); err != nil {
+		return nil, err
+	}
+	defer s.release()
+
+	if s.limiter != nil {
+		if err := s.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return fn(ctx)
+}
+
+// acquire blocks until a slot is handed to the caller
+func (s *Scheduler) acquire(ctx context.Context, priority Priority) error {
+	s.mu.Lock()
+	if s.free > 0 && len(s.queue) == 0 {
+		s.free--
+		s.started()
+		s.mu.Unlock()
+		return nil
+	}
+
+	s.seq++
+	task := &scheduledTask{priority: priority, seq: s.seq, ready: make(chan struct{})}
+	i := sort.Search(len(s.queue), func(i int) bool {
+		return s.queue[i].priority > priority
+	})
+	s.queue = append(s.queue, nil)
+	copy(s.queue[i+1:], s.queue[i:])
+	s.queue[i] = task
+	s.count(MetricSchedulerQueued, 1)
+	s.mu.Unlock()
+
+	select {
+	case <-task.ready:
+		return nil
+	case <-ctx.Done():
+	}
+
+	s.mu.Lock()
+	for i, t := range s.queue {
+		if t == task {
+			s.queue = append(s.queue[:i], s.queue[i+1:]...)
+			s.count(MetricSchedulerQueued, -1)
+			s.mu.Unlock()
+			return ctx.Err()
+		}
+	}
+	s.mu.Unlock()
+
+	// The slot was handed over while ctx was done, pass it on
+	s.release()
+	return ctx.Err()
+}
+
+// release hands the slot of a finished request to the next queued
+// request, or frees it if the queue is empty
+func (s *Scheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.inFlight--
+	s.count(MetricSchedulerInFlight, -1)
+
+	if len(s.queue) == 0 {
+		s.free++
+		return
+	}
+
+	task := s.queue[0]
+	s.queue = s.queue[1:]
+	s.count(MetricSchedulerQueued, -1)
+	s.started()
+	close(task.ready)
+}
+
+// started records a request taking a slot, s.mu must be held
+func (s *Scheduler) started() {
+	s.inFlight++
+	s.count(MetricSchedulerInFlight, 1)
+}
+
+func (s *Scheduler) count(name string, delta int64) {
+	if s.metrics != nil {
+		s.metrics.Add(name, delta)
+	}
+}
+
+// scheduleKey returns the key used to dedupe req, requests that
+// may not be shared return an empty key. Requests are only shared
+// if they have the same API key and headers set by request options.
+func scheduleKey(req *http.Request, cfg *requestConfig) string {
+	if req.Method != http.MethodGet || cfg.conditional() || cfg.noCache {
+		return ""
+	}
+	key := req.URL.String()
+	if len(cfg.header) > 0 {
+		var b strings.Builder
+		cfg.header.Write(&b)
+		key += "\n" + b.String()
+	}
+	return key
+}
+
+// scheduled marks requests that were already queued by the Scheduler
+func scheduled() RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.scheduled = true
+	}
+}
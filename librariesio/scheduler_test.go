@@ -0,0 +1,197 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestScheduler_priority(t *testing.T) {
+	s := NewScheduler(1, nil, nil)
+	ctx := context.Background()
+
+	// Hold the only slot until all requests are queued
+	if err := s.acquire(ctx, PriorityInteractive); err != nil {
+		t.Fatalf("acquire returned unexpected error: %v", err)
+	}
+
+	var mu sync.Mutex
+	var order []string
+	var wg sync.WaitGroup
+
+	for i, p := range []Priority{PriorityBackground, PriorityInteractive, PriorityBackground, PriorityInteractive} {
+		name := fmt.Sprintf("%d-%d", p, i)
+		wg.Add(1)
+		go s.run(ctx, p, func(context.Context) (*Response, error) {
+			defer wg.Done()
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			return nil, nil
+		})
+
+		for s.QueueDepth() != i+1 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	s.release()
+	wg.Wait()
+
+	if want := []string{"0-1", "0-3", "1-0", "1-2"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, order)
+	}
+}
+
+func TestScheduler_cancelled(t *testing.T) {
+	metrics := new(Counters)
+	s := NewScheduler(1, nil, metrics)
+
+	if err := s.acquire(context.Background(), PriorityInteractive); err != nil {
+		t.Fatalf("acquire returned unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := s.acquire(ctx, PriorityInteractive); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	s.release()
+
+	if s.QueueDepth() != 0 || s.InFlight() != 0 {
+		t.Errorf("expected empty scheduler, got %d queued and %d in flight", s.QueueDepth(), s.InFlight())
+	}
+	if got := metrics.Get(MetricSchedulerQueued); got != 0 {
+		t.Errorf("expected queued gauge to be 0, got %d", got)
+	}
+}
+
+func TestWithScheduler_dedupe(t *testing.T) {
+	server, mux, url := startNewServer()
+	defer server.Close()
+
+	metrics := new(Counters)
+	client := NewClient(APIKey, WithScheduler(NewScheduler(2, nil, metrics)))
+	client.BaseURL = url
+
+	var mu sync.Mutex
+	var calls int
+	release := make(chan struct{})
+
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		<-release
+		fmt.Fprint(w, `{"name":"cookiecutter"}`)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			project, _, err := client.Project(context.Background(), "pypi", "cookiecutter")
+			if err != nil {
+				t.Errorf("Project returned unexpected error: %v", err)
+				return
+			}
+			if got := stringValue(project.Name); got != "cookiecutter" {
+				t.Errorf("unexpected project name %q", got)
+			}
+		}()
+	}
+
+	for metrics.Get(MetricSchedulerDeduped) != 2 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("expected 1 request, got %d", calls)
+	}
+	if got := metrics.Get(MetricSchedulerInFlight); got != 0 {
+		t.Errorf("expected in flight gauge to be 0, got %d", got)
+	}
+}
+
+func TestScheduleKey(t *testing.T) {
+	client := NewClient(APIKey)
+	req, err := client.NewRequest("GET", "pypi/cookiecutter", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned unexpected error: %v", err)
+	}
+
+	key := func(opts ...RequestOption) string {
+		cfg := new(requestConfig)
+		for _, opt := range opts {
+			opt(cfg)
+		}
+		return scheduleKey(req, cfg)
+	}
+
+	if key() == "" {
+		t.Error("expected GET request to be shared")
+	}
+	if key(WithIfNoneMatch(`"v1"`)) != "" || key(WithNoCache()) != "" {
+		t.Error("expected conditional and uncached requests not to be shared")
+	}
+	if key(WithHeader("X-Tenant", "a")) == key() || key(WithHeader("X-Tenant", "a")) == key(WithHeader("X-Tenant", "b")) {
+		t.Error("expected requests with different headers not to be shared")
+	}
+}
+
+func TestWithScheduler_dedupeCancelled(t *testing.T) {
+	server, mux, url := startNewServer()
+	defer server.Close()
+
+	metrics := new(Counters)
+	client := NewClient(APIKey, WithScheduler(NewScheduler(2, nil, metrics)))
+	client.BaseURL = url
+
+	release := make(chan struct{})
+	mux.HandleFunc("/pypi/cookiecutter", func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		fmt.Fprint(w, `{"name":"cookiecutter"}`)
+	})
+
+	// The first caller gives up while the second one still waits
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, _, err := client.Project(ctx, "pypi", "cookiecutter")
+		first <- err
+	}()
+
+	second := make(chan *Project)
+	go func() {
+		for metrics.Get(MetricSchedulerInFlight) != 1 {
+			time.Sleep(time.Millisecond)
+		}
+		project, _, err := client.Project(context.Background(), "pypi", "cookiecutter")
+		if err != nil {
+			t.Errorf("Project returned unexpected error: %v", err)
+		}
+		second <- project
+	}()
+
+	for metrics.Get(MetricSchedulerDeduped) != 1 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	close(release)
+	if project := <-second; stringValue(project.Name) != "cookiecutter" {
+		t.Errorf("expected the shared project, got %v", project)
+	}
+}
@@ -0,0 +1,153 @@
+package librariesio
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	durationType      = reflect.TypeOf(time.Duration(0))
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	schemaModels      = []interface{}{
+		Project{}, Release{}, ProjectDependency{}, Repository{}, RepositoryDependency{}, User{}, Subscription{},
+		ProjectComparison{}, ReleaseCadence{}, StaleFinding{}, DependencyConflict{}, DependencyNode{},
+	}
+)
+
+// JSONSchema returns a JSON Schema (draft 2020-12) describing the JSON
+// encoding of the type of v, which must be a struct or a pointer to one.
+// Fields without omitempty are required and named structs are placed
+// in $defs, so recursive types are supported.
+func JSONSchema(v interface{}) ([]byte, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot generate schema for %T, want a struct", v)
+	}
+
+	g := &schemaGenerator{defs: make(map[string]interface{})}
+	root := g.schema(t)
+	root["$schema"] = jsonSchemaDraft
+	root["$defs"] = g.defs
+
+	return MarshalStable(root)
+}
+
+// ModelSchemas returns the JSON Schema of Project, Release, Repository,
+// User and the report types of the package, keyed by type name
+func ModelSchemas() (map[string]json.RawMessage, error) {
+	schemas := make(map[string]json.RawMessage, len(schemaModels))
+	for _, model := range schemaModels {
+		schema, err := JSONSchema(model)
+		if err != nil {
+			return nil, err
+		}
+		schemas[reflect.TypeOf(model).Name()] = schema
+	}
+	return schemas, nil
+}
+
+type schemaGenerator struct {
+	defs map[string]interface{}
+}
+
+// schema returns the schema of values of t
+func (g *schemaGenerator) schema(t reflect.Type) map[string]interface{} {
+	switch {
+	case t.Kind() == reflect.Ptr:
+		return nullable(g.schema(t.Elem()))
+	case t == timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case t == durationType:
+		return map[string]interface{}{"type": "integer", "description": "duration in nanoseconds"}
+	case t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType):
+		return map[string]interface{}{}
+	case t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType):
+		return map[string]interface{}{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "contentEncoding": "base64"}
+		}
+		return nullable(map[string]interface{}{"type": "array", "items": g.schema(t.Elem())})
+	case reflect.Array:
+		return map[string]interface{}{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		return nullable(map[string]interface{}{"type": "object", "additionalProperties": g.schema(t.Elem())})
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		if _, ok := g.defs[t.Name()]; !ok {
+			// Reserve the name before recursing into the fields
+			g.defs[t.Name()] = nil
+			g.defs[t.Name()] = g.structSchema(t)
+		}
+		return map[string]interface{}{"$ref": "#/$defs/" + t.Name()}
+	}
+
+	// Interfaces and other kinds may hold any value
+	return map[string]interface{}{}
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	required := []string{}
+
+	for _, f := range structFields(t) {
+		field := t.FieldByIndex(f.index)
+		opts := strings.Split(field.Tag.Get("json"), ",")[1:]
+
+		properties[f.name] = g.schema(field.Type)
+		if !hasOption(opts, "omitempty") {
+			required = append(required, f.name)
+		}
+	}
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// nullable allows null in addition to the values matched by schema
+func nullable(schema map[string]interface{}) map[string]interface{} {
+	if _, ok := schema["anyOf"]; ok {
+		return schema
+	}
+	return map[string]interface{}{"anyOf": []interface{}{schema, map[string]interface{}{"type": "null"}}}
+}
+
+func hasOption(opts []string, option string) bool {
+	for _, opt := range opts {
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package librariesio
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hackebrot/go-repr/repr"
+)
+
+func TestJSONSchema(t *testing.T) {
+	data, err := JSONSchema(&Project{})
+	if err != nil {
+		t.Fatalf("JSONSchema returned unexpected error: %v", err)
+	}
+
+	var schema struct {
+		Schema string                     `json:"$schema"`
+		Ref    string                     `json:"$ref"`
+		Defs   map[string]json.RawMessage `json:"$defs"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+
+	if schema.Schema != jsonSchemaDraft || schema.Ref != "#/$defs/Project" {
+		t.Errorf("unexpected root schema %s", data)
+	}
+
+	var project struct {
+		Type       string                            `json:"type"`
+		Required   []string                          `json:"required"`
+		Properties map[string]map[string]interface{} `json:"properties"`
+	}
+	if err := json.Unmarshal(schema.Defs["Project"], &project); err != nil {
+		t.Fatalf("Project schema is not valid JSON: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"anyOf": []interface{}{
+			map[string]interface{}{"type": "string", "format": "date-time"},
+			map[string]interface{}{"type": "null"},
+		},
+	}
+	if got := project.Properties["latest_release_published_at"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(got))
+	}
+	if _, ok := project.Properties["CollapsedPlatforms"]; ok {
+		t.Error("expected fields ignored by encoding/json to be left out")
+	}
+	if project.Required != nil {
+		t.Errorf("expected omitempty fields not to be required, got %v", project.Required)
+	}
+	for _, name := range []string{"Release", "ProjectDependency"} {
+		if _, ok := schema.Defs[name]; !ok {
+			t.Errorf("expected %v in $defs", name)
+		}
+	}
+}
+
+func TestJSONSchema_recursive(t *testing.T) {
+	data, err := JSONSchema(DependencyNode{})
+	if err != nil {
+		t.Fatalf("JSONSchema returned unexpected error: %v", err)
+	}
+
+	var schema struct {
+		Defs struct {
+			DependencyNode struct {
+				Required   []string                   `json:"required"`
+				Properties map[string]json.RawMessage `json:"properties"`
+			}
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+
+	node := schema.Defs.DependencyNode
+	if want := []string{"Platform", "Name", "Version", "Requirements", "Dependency", "Dependencies"}; !reflect.DeepEqual(node.Required, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, node.Required)
+	}
+
+	want := `{"anyOf":[{"items":{"anyOf":[{"$ref":"#/$defs/DependencyNode"},{"type":"null"}]},"type":"array"},{"type":"null"}]}`
+	if got := string(compactJSON(t, node.Properties["Dependencies"])); got != want {
+		t.Errorf("\nExpected %v\nGot %v", want, got)
+	}
+}
+
+func TestJSONSchema_invalid(t *testing.T) {
+	if _, err := JSONSchema("project"); err == nil {
+		t.Error("Expected error for non-struct type")
+	}
+}
+
+func TestModelSchemas(t *testing.T) {
+	schemas, err := ModelSchemas()
+	if err != nil {
+		t.Fatalf("ModelSchemas returned unexpected error: %v", err)
+	}
+
+	for _, name := range []string{"Project", "Release", "Repository", "User", "ProjectComparison", "StaleFinding"} {
+		if !json.Valid(schemas[name]) {
+			t.Errorf("expected valid schema for %v, got %s", name, schemas[name])
+		}
+	}
+}
+
+func compactJSON(t *testing.T, data json.RawMessage) []byte {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	out, _ := json.Marshal(v)
+	return out
+}
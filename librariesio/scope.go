@@ -0,0 +1,118 @@
+package librariesio
+
+import (
+	"context"
+	"strings"
+)
+
+// DependencyScope is the kind of a dependency normalized across package
+// managers, which each name their kinds differently
+type DependencyScope string
+
+// Scopes returned by ProjectDependency.Scope
+const (
+	ScopeRuntime     DependencyScope = "runtime"
+	ScopeDevelopment DependencyScope = "development"
+	ScopeTest        DependencyScope = "test"
+	ScopeBuild       DependencyScope = "build"
+	ScopePeer        DependencyScope = "peer"
+	ScopeOptional    DependencyScope = "optional"
+
+	// ScopeOther is used for kinds that are not known
+	ScopeOther DependencyScope = "other"
+)
+
+// Scope returns the normalized kind of the dependency. Dependencies
+// without a kind are runtime dependencies.
+func (d *ProjectDependency) Scope() DependencyScope {
+	switch strings.ToLower(stringValue(d.Kind)) {
+	case "", "runtime", "normal", "compile", "dependencies", "requires", "install", "imports", "depends":
+		return ScopeRuntime
+	case "development", "dev", "devdependencies":
+		return ScopeDevelopment
+	case "test":
+		return ScopeTest
+	case "build", "provided", "build-requires":
+		return ScopeBuild
+	case "peer", "peerdependencies":
+		return ScopePeer
+	case "optional", "optionaldependencies", "suggests":
+		return ScopeOptional
+	}
+	return ScopeOther
+}
+
+// DependencyFilter selects dependencies returned by ProjectDeps on the
+// client side, e.g. to only keep what is installed in production
+type DependencyFilter struct {
+	// RuntimeOnly only keeps runtime dependencies
+	RuntimeOnly bool
+
+	// Scopes only keeps dependencies of the given scopes.
+	// All scopes are kept if empty.
+	Scopes []DependencyScope
+
+	// ExcludeOptional leaves out dependencies that are flagged
+	// as optional or have the optional scope
+	ExcludeOptional bool
+}
+
+// Match reports whether the dependency passes the filter
+func (f *DependencyFilter) Match(d *ProjectDependency) bool {
+	if d == nil {
+		return false
+	}
+
+	scope := d.Scope()
+	if f.RuntimeOnly && scope != ScopeRuntime {
+		return false
+	}
+	if f.ExcludeOptional && (scope == ScopeOptional || d.Optional != nil && *d.Optional) {
+		return false
+	}
+	if len(f.Scopes) == 0 {
+		return true
+	}
+	for _, s := range f.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterDependencies returns the dependencies that pass the filter
+func FilterDependencies(deps []*ProjectDependency, f *DependencyFilter) []*ProjectDependency {
+	var filtered []*ProjectDependency
+	for _, d := range deps {
+		if f.Match(d) {
+			filtered = append(filtered, d)
+		}
+	}
+	return filtered
+}
+
+// DependenciesByScope groups the dependencies of the project by scope,
+// keeping their order within each scope
+func (p *Project) DependenciesByScope() map[DependencyScope][]*ProjectDependency {
+	groups := make(map[DependencyScope][]*ProjectDependency)
+	for _, d := range p.Dependencies {
+		if d != nil {
+			groups[d.Scope()] = append(groups[d.Scope()], d)
+		}
+	}
+	return groups
+}
+
+// ProjectDepsFiltered returns information about a project and the
+// dependencies that pass the filter, see ProjectDeps
+func (c *Client) ProjectDepsFiltered(ctx context.Context, plat, name, ver string, f *DependencyFilter) (*Project, *Response, error) {
+	project, response, err := c.ProjectDeps(ctx, plat, name, ver)
+	if err != nil {
+		return nil, response, err
+	}
+	if f != nil && project != nil {
+		project.Dependencies = FilterDependencies(project.Dependencies, f)
+	}
+	return project, response, nil
+}
@@ -0,0 +1,92 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const testScopedDeps = `{"name":"app","dependencies":[
+	{"name":"a","kind":"runtime"},
+	{"name":"b","kind":"Development"},
+	{"name":"c","kind":"runtime","optional":true},
+	{"name":"d","kind":"optional"},
+	{"name":"e","kind":"test"},
+	{"name":"f"}
+]}`
+
+func dependencyNames(deps []*ProjectDependency) []string {
+	var names []string
+	for _, d := range deps {
+		names = append(names, stringValue(d.Name))
+	}
+	return names
+}
+
+func TestProjectDepsFiltered(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/npm/app/1.0.0/dependencies", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testScopedDeps)
+	})
+
+	testCases := []struct {
+		name   string
+		filter *DependencyFilter
+		want   []string
+	}{
+		{"none", nil, []string{"a", "b", "c", "d", "e", "f"}},
+		{"runtime only", &DependencyFilter{RuntimeOnly: true}, []string{"a", "c", "f"}},
+		{"exclude optional", &DependencyFilter{ExcludeOptional: true}, []string{"a", "b", "e", "f"}},
+		{"runtime without optional", &DependencyFilter{RuntimeOnly: true, ExcludeOptional: true}, []string{"a", "f"}},
+		{"scopes", &DependencyFilter{Scopes: []DependencyScope{ScopeDevelopment, ScopeTest}}, []string{"b", "e"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			project, _, err := client.ProjectDepsFiltered(context.Background(), "npm", "app", "1.0.0", testCase.filter)
+			if err != nil {
+				t.Fatalf("ProjectDepsFiltered returned unexpected error: %v", err)
+			}
+			if got := dependencyNames(project.Dependencies); !reflect.DeepEqual(got, testCase.want) {
+				t.Errorf("\nExpected %v\nGot %v", testCase.want, got)
+			}
+		})
+	}
+}
+
+func TestDependenciesByScope(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/npm/app/1.0.0/dependencies", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testScopedDeps)
+	})
+
+	project, _, err := client.ProjectDeps(context.Background(), "npm", "app", "1.0.0")
+	if err != nil {
+		t.Fatalf("ProjectDeps returned unexpected error: %v", err)
+	}
+
+	got := make(map[DependencyScope][]string)
+	for scope, deps := range project.DependenciesByScope() {
+		got[scope] = dependencyNames(deps)
+	}
+
+	want := map[DependencyScope][]string{
+		ScopeRuntime:     {"a", "c", "f"},
+		ScopeDevelopment: {"b"},
+		ScopeOptional:    {"d"},
+		ScopeTest:        {"e"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, got)
+	}
+}
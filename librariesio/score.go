@@ -0,0 +1,84 @@
+package librariesio
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// Scorer scores a search result for the query q, higher scores rank first
+type Scorer interface {
+	Score(q string, p *Project) float64
+}
+
+// ScorerFunc is a function that implements Scorer
+type ScorerFunc func(q string, p *Project) float64
+
+// Score calls f(q, p)
+func (f ScorerFunc) Score(q string, p *Project) float64 {
+	return f(q, p)
+}
+
+// ScoreWeights is a Scorer that sums weighted signals of a project,
+// each normalized to a value between 0 and 1
+type ScoreWeights struct {
+	// Stars weighs the stars of the repository on a logarithmic scale,
+	// 100k stars score 1
+	Stars float64
+
+	// Rank weighs the SourceRank, a rank of 30 scores 1
+	Rank float64
+
+	// Recency weighs the age of the latest release,
+	// a release today scores 1 and one a year ago 0.5
+	Recency float64
+
+	// ExactName weighs whether the name equals the query, ignoring case
+	// and the name normalization of the platform
+	ExactName float64
+}
+
+// DefaultScoreWeights favors exact name matches over popularity,
+// which the API's own ordering tends to bury for short queries
+var DefaultScoreWeights = ScoreWeights{Stars: 1, Rank: 1, Recency: 0.5, ExactName: 3}
+
+// Score returns the weighted sum of the signals of p
+func (w ScoreWeights) Score(q string, p *Project) float64 {
+	var score float64
+
+	if stars := intValue(p.Stars); stars > 0 {
+		score += w.Stars * math.Min(math.Log10(float64(stars)+1)/5, 1)
+	}
+	if rank := intValue(p.Rank); rank > 0 {
+		score += w.Rank * math.Min(float64(rank)/30, 1)
+	}
+	if p.LatestReleasePublishedAt != nil {
+		age := now().Sub(*p.LatestReleasePublishedAt)
+		if age < 0 {
+			age = 0
+		}
+		score += w.Recency / (1 + float64(age)/float64(year))
+	}
+
+	plat := stringValue(p.Platform)
+	if name := stringValue(p.Name); name != "" && strings.EqualFold(normalizeName(plat, name), normalizeName(plat, q)) {
+		score += w.ExactName
+	}
+
+	return score
+}
+
+// RankProjects sorts projects by descending score for the query q,
+// projects with equal scores keep their order
+func RankProjects(q string, projects []*Project, scorer Scorer) {
+	scores := make(map[*Project]float64, len(projects))
+	for _, p := range projects {
+		if p != nil {
+			scores[p] = scorer.Score(q, p)
+		}
+	}
+
+	sort.SliceStable(projects, func(i, j int) bool {
+		return scores[projects[i]] > scores[projects[j]]
+	})
+}
@@ -0,0 +1,80 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestScoreWeights(t *testing.T) {
+	defer func(f func() time.Time) { now = f }(now)
+	at := time.Date(2017, time.April, 1, 0, 0, 0, 0, time.UTC)
+	now = func() time.Time { return at }
+
+	lastYear := at.Add(-year)
+
+	testCases := []struct {
+		name    string
+		weights ScoreWeights
+		project *Project
+		want    float64
+	}{
+		{"stars", ScoreWeights{Stars: 1}, &Project{Stars: Int(99999)}, 1},
+		{"rank", ScoreWeights{Rank: 2}, &Project{Rank: Int(15)}, 1},
+		{"recency", ScoreWeights{Recency: 1}, &Project{LatestReleasePublishedAt: &lastYear}, 0.5},
+		{"exact name", ScoreWeights{ExactName: 1}, &Project{Name: String("Flask_SQLAlchemy"), Platform: String("Pypi")}, 1},
+		{"other name", ScoreWeights{ExactName: 1}, &Project{Name: String("flask")}, 0},
+		{"nothing", DefaultScoreWeights, &Project{}, 0},
+	}
+
+	for _, testCase := range testCases {
+		if got := testCase.weights.Score("flask-sqlalchemy", testCase.project); fmt.Sprintf("%.3f", got) != fmt.Sprintf("%.3f", testCase.want) {
+			t.Errorf("%v: score is %v, want %v", testCase.name, got, testCase.want)
+		}
+	}
+}
+
+func TestRankProjects(t *testing.T) {
+	projects := []*Project{
+		{Name: String("a"), Stars: Int(10)},
+		{Name: String("b"), Stars: Int(1000)},
+		{Name: String("c"), Stars: Int(10)},
+	}
+
+	byStars := ScoreWeights{Stars: 1}
+	RankProjects("x", projects, byStars)
+
+	var got []string
+	for _, p := range projects {
+		got = append(got, *p.Name)
+	}
+	if want := []string{"b", "a", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, got)
+	}
+}
+
+func TestSearch_scorer(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"name": "grunt-contrib-jshint", "stars": 1200, "rank": 20},
+			{"name": "jshint", "stars": 8000, "rank": 25}
+		]`)
+	})
+
+	projects, _, err := client.Search(context.Background(), "jshint", &SearchOptions{Scorer: DefaultScoreWeights})
+	if err != nil {
+		t.Fatalf("Search returned unexpected error: %v", err)
+	}
+
+	if got := *projects[0].Name; got != "jshint" {
+		t.Errorf("expected exact match to rank first, got %v", got)
+	}
+}
@@ -0,0 +1,63 @@
+package librariesio
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// WithMaxResponseBytes aborts reading response bodies larger than n bytes
+// and returns a ResponseTooLargeError instead, so huge responses such as
+// long dependents lists cannot exhaust the memory of the process. Bodies
+// of error responses are truncated to n bytes.
+func WithMaxResponseBytes(n int64) ClientOption {
+	return func(c *Client) {
+		c.maxResponseBytes = n
+	}
+}
+
+// ResponseTooLargeError is returned for responses with a body
+// exceeding the limit set with WithMaxResponseBytes
+type ResponseTooLargeError struct {
+	Response *http.Response
+	Limit    int64
+}
+
+// Error returns the request and the exceeded limit
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf(
+		"%v %v: response body exceeds %d bytes",
+		e.Response.Request.Method,
+		redactAPIKey(e.Response.Request.URL),
+		e.Limit,
+	)
+}
+
+// limitBody truncates the body of resp to the configured limit
+func (c *Client) limitBody(resp *http.Response) {
+	if c.maxResponseBytes <= 0 {
+		return
+	}
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(resp.Body, c.maxResponseBytes+1), resp.Body}
+}
+
+// readBody reads the full body of resp, failing early if
+// the announced or actual size exceeds the configured limit
+func (c *Client) readBody(resp *http.Response) ([]byte, error) {
+	limit := c.maxResponseBytes
+	if limit > 0 && resp.ContentLength > limit {
+		return nil, &ResponseTooLargeError{Response: resp, Limit: limit}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && int64(len(body)) > limit {
+		return nil, &ResponseTooLargeError{Response: resp, Limit: limit}
+	}
+	return body, nil
+}
@@ -0,0 +1,53 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestWithMaxResponseBytes(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey, WithMaxResponseBytes(64))
+	client.BaseURL = url
+	defer server.Close()
+
+	body := `{"name":"` + strings.Repeat("x", 100) + `"}`
+	mux.HandleFunc("/pypi/sized", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	})
+	mux.HandleFunc("/pypi/chunked", func(w http.ResponseWriter, r *http.Request) {
+		// Flushing before writing the body omits the Content-Length
+		w.(http.Flusher).Flush()
+		fmt.Fprint(w, body)
+	})
+	mux.HandleFunc("/pypi/small", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"small"}`)
+	})
+
+	for _, name := range []string{"sized", "chunked"} {
+		_, _, err := client.Project(context.Background(), "pypi", name)
+
+		var sizeErr *ResponseTooLargeError
+		if !errors.As(err, &sizeErr) {
+			t.Fatalf("%v: expected *ResponseTooLargeError, got %v", name, err)
+		}
+		if sizeErr.Limit != 64 {
+			t.Errorf("%v: expected limit 64, got %d", name, sizeErr.Limit)
+		}
+		if strings.Contains(err.Error(), "api_key="+APIKey) {
+			t.Errorf("%v: expected API key to be redacted from %q", name, err)
+		}
+	}
+
+	project, _, err := client.Project(context.Background(), "pypi", "small")
+	if err != nil {
+		t.Fatalf("Project returned unexpected error: %v", err)
+	}
+	if got := stringValue(project.Name); got != "small" {
+		t.Errorf("unexpected project name %q", got)
+	}
+}
@@ -0,0 +1,169 @@
+package librariesio
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// ProjectOrder compares two projects, returning a negative number if a
+// sorts before b, a positive number if after and 0 if they are equal.
+// Missing values compare like zero values.
+type ProjectOrder func(a, b *Project) int
+
+// Orders of projects, ascending unless reversed
+var (
+	ProjectsByName           ProjectOrder = func(a, b *Project) int { return compareNames(a.Name, b.Name) }
+	ProjectsByPlatform       ProjectOrder = func(a, b *Project) int { return compareNames(a.Platform, b.Platform) }
+	ProjectsByRank           ProjectOrder = func(a, b *Project) int { return compareInts(a.Rank, b.Rank) }
+	ProjectsByStars          ProjectOrder = func(a, b *Project) int { return compareInts(a.Stars, b.Stars) }
+	ProjectsByForks          ProjectOrder = func(a, b *Project) int { return compareInts(a.Forks, b.Forks) }
+	ProjectsByDependents     ProjectOrder = func(a, b *Project) int { return compareInts(a.DependentsCount, b.DependentsCount) }
+	ProjectsByDependentRepos ProjectOrder = func(a, b *Project) int {
+		return compareInts(a.DependentReposCount, b.DependentReposCount)
+	}
+	ProjectsByLatestRelease ProjectOrder = func(a, b *Project) int {
+		return compareTimes(a.LatestReleasePublishedAt, b.LatestReleasePublishedAt)
+	}
+)
+
+// DefaultProjectOrder is used by SortProjects without orders,
+// by rank and stars descending, then by platform and name
+var DefaultProjectOrder = []ProjectOrder{
+	ProjectsByRank.Reverse(),
+	ProjectsByStars.Reverse(),
+	ProjectsByPlatform,
+	ProjectsByName,
+}
+
+// Reverse returns the descending order
+func (o ProjectOrder) Reverse() ProjectOrder {
+	return func(a, b *Project) int { return o(b, a) }
+}
+
+// SortProjects sorts projects by the first of the given orders, ties are
+// broken by the following orders. The sort is stable, so projects equal
+// in all orders keep their relative order. Nil projects sort last.
+func SortProjects(projects []*Project, by ...ProjectOrder) {
+	if len(by) == 0 {
+		by = DefaultProjectOrder
+	}
+	sort.SliceStable(projects, func(i, j int) bool {
+		a, b := projects[i], projects[j]
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		for _, o := range by {
+			if c := o(a, b); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
+
+// ReleaseOrder compares two releases like ProjectOrder compares projects
+type ReleaseOrder func(a, b *Release) int
+
+// Orders of releases, ascending unless reversed
+var (
+	ReleasesByNumber ReleaseOrder = func(a, b *Release) int {
+		return compareVersionNumbers(stringValue(a.Number), stringValue(b.Number))
+	}
+	ReleasesByPublished ReleaseOrder = func(a, b *Release) int { return compareTimes(a.PublishedAt, b.PublishedAt) }
+)
+
+// Reverse returns the descending order
+func (o ReleaseOrder) Reverse() ReleaseOrder {
+	return func(a, b *Release) int { return o(b, a) }
+}
+
+// SortReleases sorts releases like SortProjects, by publish date and
+// version number if no orders are given
+func SortReleases(releases []*Release, by ...ReleaseOrder) {
+	if len(by) == 0 {
+		by = []ReleaseOrder{ReleasesByPublished, ReleasesByNumber}
+	}
+	sort.SliceStable(releases, func(i, j int) bool {
+		a, b := releases[i], releases[j]
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		for _, o := range by {
+			if c := o(a, b); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
+
+// DependencyOrder compares two dependencies like ProjectOrder compares
+// projects
+type DependencyOrder func(a, b *ProjectDependency) int
+
+// Orders of dependencies, ascending unless reversed
+var (
+	DependenciesByName     DependencyOrder = func(a, b *ProjectDependency) int { return compareNames(a.Name, b.Name) }
+	DependenciesByPlatform DependencyOrder = func(a, b *ProjectDependency) int { return compareNames(a.Platform, b.Platform) }
+	DependenciesByScope    DependencyOrder = func(a, b *ProjectDependency) int {
+		return strings.Compare(string(a.Scope()), string(b.Scope()))
+	}
+)
+
+// Reverse returns the descending order
+func (o DependencyOrder) Reverse() DependencyOrder {
+	return func(a, b *ProjectDependency) int { return o(b, a) }
+}
+
+// SortDependencies sorts dependencies like SortProjects, by platform
+// and name if no orders are given
+func SortDependencies(deps []*ProjectDependency, by ...DependencyOrder) {
+	if len(by) == 0 {
+		by = []DependencyOrder{DependenciesByPlatform, DependenciesByName}
+	}
+	sort.SliceStable(deps, func(i, j int) bool {
+		a, b := deps[i], deps[j]
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		for _, o := range by {
+			if c := o(a, b); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
+
+// compareNames compares case insensitively first,
+// so the order does not depend on capitalization
+func compareNames(a, b *string) int {
+	x, y := stringValue(a), stringValue(b)
+	if c := strings.Compare(strings.ToLower(x), strings.ToLower(y)); c != 0 {
+		return c
+	}
+	return strings.Compare(x, y)
+}
+
+func compareInts(a, b *int) int {
+	x, y := intValue(a), intValue(b)
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+func compareTimes(a, b *time.Time) int {
+	var x, y time.Time
+	if a != nil {
+		x = *a
+	}
+	if b != nil {
+		y = *b
+	}
+	return x.Compare(y)
+}
@@ -0,0 +1,99 @@
+package librariesio
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSortProjects(t *testing.T) {
+	projects := []*Project{
+		{Name: String("b"), Platform: String("npm"), Rank: Int(10), Stars: Int(5)},
+		nil,
+		{Name: String("A"), Platform: String("npm"), Rank: Int(10), Stars: Int(5)},
+		{Name: String("c"), Platform: String("npm"), Rank: Int(20)},
+		{Name: String("a"), Platform: String("NPM"), Rank: Int(10), Stars: Int(5)},
+		{Name: String("d"), Platform: String("npm"), Rank: Int(10), Stars: Int(9)},
+	}
+
+	names := func() []string {
+		var names []string
+		for _, p := range projects {
+			if p == nil {
+				names = append(names, "<nil>")
+				continue
+			}
+			names = append(names, *p.Platform+"/"+*p.Name)
+		}
+		return names
+	}
+
+	SortProjects(projects)
+	if want := []string{"npm/c", "npm/d", "NPM/a", "npm/A", "npm/b", "<nil>"}; !reflect.DeepEqual(names(), want) {
+		t.Errorf("\nExpected %v\nGot %v", want, names())
+	}
+
+	SortProjects(projects, ProjectsByName.Reverse())
+	if want := []string{"npm/d", "npm/c", "npm/b", "NPM/a", "npm/A", "<nil>"}; !reflect.DeepEqual(names(), want) {
+		t.Errorf("\nExpected %v\nGot %v", want, names())
+	}
+
+	// Equal projects keep their order
+	SortProjects(projects, ProjectsByRank)
+	if want := []string{"npm/d", "npm/b", "NPM/a", "npm/A", "npm/c", "<nil>"}; !reflect.DeepEqual(names(), want) {
+		t.Errorf("\nExpected %v\nGot %v", want, names())
+	}
+}
+
+func TestSortReleases(t *testing.T) {
+	day := func(d int) *time.Time { return Time(time.Date(2017, time.March, d, 0, 0, 0, 0, time.UTC)) }
+	releases := []*Release{
+		{Number: String("1.10.0"), PublishedAt: day(3)},
+		{Number: String("1.9.0"), PublishedAt: day(1)},
+		{Number: String("1.9.1"), PublishedAt: day(3)},
+	}
+
+	numbers := func() []string {
+		var numbers []string
+		for _, r := range releases {
+			numbers = append(numbers, *r.Number)
+		}
+		return numbers
+	}
+
+	SortReleases(releases)
+	if want := []string{"1.9.0", "1.9.1", "1.10.0"}; !reflect.DeepEqual(numbers(), want) {
+		t.Errorf("\nExpected %v\nGot %v", want, numbers())
+	}
+
+	SortReleases(releases, ReleasesByNumber.Reverse())
+	if want := []string{"1.10.0", "1.9.1", "1.9.0"}; !reflect.DeepEqual(numbers(), want) {
+		t.Errorf("\nExpected %v\nGot %v", want, numbers())
+	}
+}
+
+func TestSortDependencies(t *testing.T) {
+	deps := []*ProjectDependency{
+		{Name: String("pytest"), Platform: String("Pypi"), Kind: String("test")},
+		{Name: String("requests"), Platform: String("Pypi"), Kind: String("runtime")},
+		{Name: String("flask"), Platform: String("Pypi"), Kind: String("runtime")},
+	}
+
+	names := func() []string {
+		var names []string
+		for _, d := range deps {
+			names = append(names, *d.Name)
+		}
+		return names
+	}
+
+	SortDependencies(deps)
+	if want := []string{"flask", "pytest", "requests"}; !reflect.DeepEqual(names(), want) {
+		t.Errorf("\nExpected %v\nGot %v", want, names())
+	}
+
+	SortDependencies(deps, DependenciesByScope, DependenciesByName.Reverse())
+	if want := []string{"requests", "flask", "pytest"}; !reflect.DeepEqual(names(), want) {
+		t.Errorf("\nExpected %v\nGot %v", want, names())
+	}
+}
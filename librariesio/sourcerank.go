@@ -0,0 +1,149 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// SourceRank holds the breakdown of the SourceRank score of a project,
+// see https://docs.libraries.io/overview.html#sourcerank
+type SourceRank struct {
+	BasicInfoPresent        *int `json:"basic_info_present,omitempty"`
+	RepositoryPresent       *int `json:"repository_present,omitempty"`
+	ReadmePresent           *int `json:"readme_present,omitempty"`
+	LicensePresent          *int `json:"license_present,omitempty"`
+	VersionsPresent         *int `json:"versions_present,omitempty"`
+	FollowsSemver           *int `json:"follows_semver,omitempty"`
+	RecentRelease           *int `json:"recent_release,omitempty"`
+	NotBrandNew             *int `json:"not_brand_new,omitempty"`
+	OneOrGreater            *int `json:"is_1_or_greater,omitempty"`
+	DependentProjects       *int `json:"dependent_projects,omitempty"`
+	DependentRepositories   *int `json:"dependent_repositories,omitempty"`
+	Stars                   *int `json:"stars,omitempty"`
+	Contributors            *int `json:"contributors,omitempty"`
+	Subscribers             *int `json:"subscribers,omitempty"`
+	AllPrereleases          *int `json:"all_prereleases,omitempty"`
+	AnyOutdatedDependencies *int `json:"any_outdated_dependencies,omitempty"`
+	IsDeprecated            *int `json:"is_deprecated,omitempty"`
+	IsUnmaintained          *int `json:"is_unmaintained,omitempty"`
+	IsRemoved               *int `json:"is_removed,omitempty"`
+}
+
+// ProjectSourceRank returns the breakdown of the SourceRank score of the
+// given project
+//
+// GET https://libraries.io/api/:platform/:name/sourcerank
+func (c *Client) ProjectSourceRank(ctx context.Context, plat, name string) (*SourceRank, *Response, error) {
+	urlStr := fmt.Sprintf("%v/%v/sourcerank", plat, url.PathEscape(name))
+
+	request, err := c.NewRequest("GET", urlStr, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rank := new(SourceRank)
+
+	response, err := c.Do(ctx, request, rank)
+	if err != nil {
+		return nil, response, projectError(err, plat, name)
+	}
+	if response.unchanged() {
+		return nil, response, nil
+	}
+
+	return rank, response, nil
+}
+
+// Total returns the SourceRank score, the sum of all components
+func (r *SourceRank) Total() int {
+	total := 0
+	for _, c := range r.components() {
+		total += c.value
+	}
+	return total
+}
+
+type sourceRankComponent struct {
+	name  string
+	value int
+}
+
+// components returns the components of the breakdown in declaration order,
+// named like their JSON fields
+func (r *SourceRank) components() []sourceRankComponent {
+	v := reflect.ValueOf(r).Elem()
+	t := v.Type()
+
+	components := make([]sourceRankComponent, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		value, _ := v.Field(i).Interface().(*int)
+		components = append(components, sourceRankComponent{name: name, value: intValue(value)})
+	}
+	return components
+}
+
+// SourceRankChange is a component of the SourceRank that changed
+// between two breakdowns
+type SourceRankChange struct {
+	// Component is the JSON name of the component, e.g. recent_release
+	Component string
+
+	Before int
+	After  int
+}
+
+// Delta returns the points gained, it is negative for lost points
+func (c *SourceRankChange) Delta() int {
+	return c.After - c.Before
+}
+
+// String describes the change, e.g. "recent_release lost 1 point"
+func (c *SourceRankChange) String() string {
+	delta := c.Delta()
+
+	verb := "gained"
+	if delta < 0 {
+		verb, delta = "lost", -delta
+	}
+	unit := "points"
+	if delta == 1 {
+		unit = "point"
+	}
+	return fmt.Sprintf("%v %v %d %v", c.Component, verb, delta, unit)
+}
+
+// DiffSourceRank returns the components that changed from before to after,
+// in the order of the SourceRank fields. Missing components count as 0 and
+// a nil breakdown is treated as all components missing.
+func DiffSourceRank(before, after *SourceRank) []*SourceRankChange {
+	if before == nil {
+		before = new(SourceRank)
+	}
+	if after == nil {
+		after = new(SourceRank)
+	}
+
+	a := after.components()
+	var changes []*SourceRankChange
+	for i, b := range before.components() {
+		if b.value != a[i].value {
+			changes = append(changes, &SourceRankChange{Component: b.name, Before: b.value, After: a[i].value})
+		}
+	}
+	return changes
+}
+
+// SourceRankChanges fetches the current SourceRank breakdown of the project
+// and compares it against snapshot, a previously stored breakdown. The
+// current breakdown is returned as well, to be stored as the next snapshot.
+func (c *Client) SourceRankChanges(ctx context.Context, plat, name string, snapshot *SourceRank) ([]*SourceRankChange, *SourceRank, error) {
+	current, _, err := c.ProjectSourceRank(ctx, plat, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return DiffSourceRank(snapshot, current), current, nil
+}
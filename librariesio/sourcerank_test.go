@@ -0,0 +1,94 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestProjectSourceRank(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/npm/react/sourcerank", func(w http.ResponseWriter, r *http.Request) {
+		if method := "GET"; method != r.Method {
+			t.Errorf("expected HTTP %v request, got %v", method, r.Method)
+		}
+		fmt.Fprint(w, `{"basic_info_present":1,"dependent_projects":5,"stars":6,"is_1_or_greater":1,"is_deprecated":0}`)
+	})
+
+	rank, _, err := client.ProjectSourceRank(context.Background(), "npm", "react")
+	if err != nil {
+		t.Fatalf("ProjectSourceRank returned unexpected error: %v", err)
+	}
+
+	want := &SourceRank{
+		BasicInfoPresent:  Int(1),
+		DependentProjects: Int(5),
+		Stars:             Int(6),
+		OneOrGreater:      Int(1),
+		IsDeprecated:      Int(0),
+	}
+	if !reflect.DeepEqual(rank, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, rank)
+	}
+}
+
+func TestSourceRankTotal(t *testing.T) {
+	rank := &SourceRank{BasicInfoPresent: Int(1), Stars: Int(6), IsDeprecated: Int(-5)}
+	if got := rank.Total(); got != 2 {
+		t.Errorf("expected total of 2, got %d", got)
+	}
+}
+
+func TestDiffSourceRank(t *testing.T) {
+	before := &SourceRank{RecentRelease: Int(1), Stars: Int(4), Contributors: Int(2)}
+	after := &SourceRank{Stars: Int(6), Contributors: Int(2), IsDeprecated: Int(-5)}
+
+	var got []string
+	for _, change := range DiffSourceRank(before, after) {
+		got = append(got, change.String())
+	}
+
+	want := []string{
+		"recent_release lost 1 point",
+		"stars gained 2 points",
+		"is_deprecated lost 5 points",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, got)
+	}
+
+	if changes := DiffSourceRank(nil, nil); changes != nil {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+}
+
+func TestSourceRankChanges(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/npm/react/sourcerank", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"basic_info_present": 1, "recent_release": 0}`)
+	})
+
+	snapshot := &SourceRank{BasicInfoPresent: Int(1), RecentRelease: Int(1)}
+
+	changes, current, err := client.SourceRankChanges(context.Background(), "npm", "react", snapshot)
+	if err != nil {
+		t.Fatalf("SourceRankChanges returned unexpected error: %v", err)
+	}
+
+	if len(changes) != 1 || changes[0].Component != "recent_release" || changes[0].Delta() != -1 {
+		t.Errorf("unexpected changes %v", changes)
+	}
+	if current.Total() != 1 {
+		t.Errorf("expected current total of 1, got %d", current.Total())
+	}
+}
@@ -0,0 +1,214 @@
+package librariesio
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SPDX expression operators
+const (
+	SPDXAnd = "AND"
+	SPDXOr  = "OR"
+)
+
+// SPDXExpression is a parsed SPDX license expression such as
+// "MIT OR (Apache-2.0 AND GPL-2.0-only WITH Classpath-exception-2.0)".
+//
+// Compound expressions have Op set to SPDXAnd or SPDXOr and both Left
+// and Right populated, while simple expressions only hold a License
+// and an optional Exception.
+type SPDXExpression struct {
+	Op          string
+	Left, Right *SPDXExpression
+
+	License   string
+	Exception string
+}
+
+// ParseSPDXExpression parses the given SPDX license expression.
+// Operators are matched case-insensitively, AND binds tighter than OR.
+func ParseSPDXExpression(s string) (*SPDXExpression, error) {
+	p := &spdxParser{tokens: tokenizeSPDX(s)}
+	if len(p.tokens) == 0 {
+		return nil, errors.New("spdx: empty expression")
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok, ok := p.peek(); ok {
+		return nil, fmt.Errorf("spdx: unexpected %q in %q", tok, s)
+	}
+	return expr, nil
+}
+
+// LicenseExpression parses the SPDXExpression of the release
+func (r *Release) LicenseExpression() (*SPDXExpression, error) {
+	if r.SPDXExpression == nil {
+		return nil, errors.New("spdx: release has no SPDX expression")
+	}
+	return ParseSPDXExpression(*r.SPDXExpression)
+}
+
+// Satisfies reports whether the expression can be satisfied using only
+// licenses accepted by the given function. For OR either side needs to be
+// accepted, for AND both sides. License exceptions only ever grant
+// additional permissions and are therefore not passed to accept.
+func (e *SPDXExpression) Satisfies(accept func(license string) bool) bool {
+	switch e.Op {
+	case SPDXAnd:
+		return e.Left.Satisfies(accept) && e.Right.Satisfies(accept)
+	case SPDXOr:
+		return e.Left.Satisfies(accept) || e.Right.Satisfies(accept)
+	}
+	return accept(e.License)
+}
+
+// Licenses returns the distinct license identifiers in the expression
+func (e *SPDXExpression) Licenses() []string {
+	var licenses []string
+	seen := make(map[string]bool)
+
+	var walk func(*SPDXExpression)
+	walk = func(e *SPDXExpression) {
+		if e.Op != "" {
+			walk(e.Left)
+			walk(e.Right)
+			return
+		}
+		if !seen[e.License] {
+			seen[e.License] = true
+			licenses = append(licenses, e.License)
+		}
+	}
+	walk(e)
+
+	return licenses
+}
+
+// String returns the expression in its canonical form
+func (e *SPDXExpression) String() string {
+	if e.Op == "" {
+		if e.Exception != "" {
+			return e.License + " WITH " + e.Exception
+		}
+		return e.License
+	}
+	return e.operand(e.Left) + " " + e.Op + " " + e.operand(e.Right)
+}
+
+// operand wraps an OR expression in parentheses if it is part of an AND
+func (e *SPDXExpression) operand(o *SPDXExpression) string {
+	if e.Op == SPDXAnd && o.Op == SPDXOr {
+		return "(" + o.String() + ")"
+	}
+	return o.String()
+}
+
+func tokenizeSPDX(s string) []string {
+	s = strings.Replace(s, "(", " ( ", -1)
+	s = strings.Replace(s, ")", " ) ", -1)
+	return strings.Fields(s)
+}
+
+type spdxParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *spdxParser) peek() (string, bool) {
+	if p.pos >= len(p.tokens) {
+		return "", false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *spdxParser) next() (string, bool) {
+	tok, ok := p.peek()
+	if ok {
+		p.pos++
+	}
+	return tok, ok
+}
+
+// accept consumes the next token if it matches the given operator
+func (p *spdxParser) accept(op string) bool {
+	if tok, ok := p.peek(); ok && strings.EqualFold(tok, op) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *spdxParser) parseOr() (*SPDXExpression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept(SPDXOr) {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &SPDXExpression{Op: SPDXOr, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *spdxParser) parseAnd() (*SPDXExpression, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept(SPDXAnd) {
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		left = &SPDXExpression{Op: SPDXAnd, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *spdxParser) parsePrimary() (*SPDXExpression, error) {
+	tok, ok := p.next()
+	if !ok {
+		return nil, errors.New("spdx: unexpected end of expression")
+	}
+
+	if tok == "(" {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if tok, ok := p.next(); !ok || tok != ")" {
+			return nil, errors.New("spdx: missing closing parenthesis")
+		}
+		return expr, nil
+	}
+
+	if !isSPDXLicense(tok) {
+		return nil, fmt.Errorf("spdx: expected license, got %q", tok)
+	}
+	expr := &SPDXExpression{License: tok}
+
+	if p.accept("WITH") {
+		exception, ok := p.next()
+		if !ok || !isSPDXLicense(exception) {
+			return nil, errors.New("spdx: expected exception after WITH")
+		}
+		expr.Exception = exception
+	}
+	return expr, nil
+}
+
+// isSPDXLicense reports whether tok can be used as a license or exception id
+func isSPDXLicense(tok string) bool {
+	switch strings.ToUpper(tok) {
+	case "(", ")", SPDXAnd, SPDXOr, "WITH":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,106 @@
+package librariesio
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSPDXExpression(t *testing.T) {
+	testCases := []struct {
+		expr     string
+		want     string
+		licenses []string
+	}{
+		{"MIT", "MIT", []string{"MIT"}},
+		{"MIT OR Apache-2.0", "MIT OR Apache-2.0", []string{"MIT", "Apache-2.0"}},
+		{"mit or apache-2.0", "mit OR apache-2.0", []string{"mit", "apache-2.0"}},
+		{
+			"MIT OR Apache-2.0 AND BSD-3-Clause",
+			"MIT OR Apache-2.0 AND BSD-3-Clause",
+			[]string{"MIT", "Apache-2.0", "BSD-3-Clause"},
+		},
+		{
+			"(MIT OR Apache-2.0) AND BSD-3-Clause",
+			"(MIT OR Apache-2.0) AND BSD-3-Clause",
+			[]string{"MIT", "Apache-2.0", "BSD-3-Clause"},
+		},
+		{
+			"GPL-2.0-only WITH Classpath-exception-2.0 OR MIT",
+			"GPL-2.0-only WITH Classpath-exception-2.0 OR MIT",
+			[]string{"GPL-2.0-only", "MIT"},
+		},
+		{"(MIT)", "MIT", []string{"MIT"}},
+	}
+
+	for _, testCase := range testCases {
+		expr, err := ParseSPDXExpression(testCase.expr)
+		if err != nil {
+			t.Errorf("ParseSPDXExpression(%q) returned unexpected error: %v", testCase.expr, err)
+			continue
+		}
+		if got := expr.String(); got != testCase.want {
+			t.Errorf("ParseSPDXExpression(%q) is %q, want %q", testCase.expr, got, testCase.want)
+		}
+		if got := expr.Licenses(); !reflect.DeepEqual(got, testCase.licenses) {
+			t.Errorf("Licenses() for %q is %v, want %v", testCase.expr, got, testCase.licenses)
+		}
+	}
+}
+
+func TestParseSPDXExpression_errors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"MIT OR",
+		"AND MIT",
+		"(MIT OR Apache-2.0",
+		"MIT Apache-2.0",
+		"GPL-2.0 WITH",
+	} {
+		if _, err := ParseSPDXExpression(expr); err == nil {
+			t.Errorf("ParseSPDXExpression(%q) did not return an error", expr)
+		}
+	}
+}
+
+func TestSPDXExpressionSatisfies(t *testing.T) {
+	allowed := map[string]bool{"MIT": true, "Apache-2.0": true}
+	accept := func(license string) bool { return allowed[license] }
+
+	testCases := []struct {
+		expr string
+		want bool
+	}{
+		{"MIT", true},
+		{"GPL-3.0-only", false},
+		{"GPL-3.0-only OR MIT", true},
+		{"GPL-3.0-only AND MIT", false},
+		{"(GPL-3.0-only OR MIT) AND Apache-2.0", true},
+		{"Apache-2.0 WITH LLVM-exception", true},
+	}
+
+	for _, testCase := range testCases {
+		expr, err := ParseSPDXExpression(testCase.expr)
+		if err != nil {
+			t.Fatalf("ParseSPDXExpression(%q) returned unexpected error: %v", testCase.expr, err)
+		}
+		if got := expr.Satisfies(accept); got != testCase.want {
+			t.Errorf("Satisfies() for %q is %v, want %v", testCase.expr, got, testCase.want)
+		}
+	}
+}
+
+func TestReleaseLicenseExpression(t *testing.T) {
+	release := &Release{SPDXExpression: String("MIT OR Apache-2.0")}
+
+	expr, err := release.LicenseExpression()
+	if err != nil {
+		t.Fatalf("LicenseExpression returned unexpected error: %v", err)
+	}
+	if expr.Op != SPDXOr {
+		t.Errorf("expected OR expression, got %v", expr.Op)
+	}
+
+	if _, err := new(Release).LicenseExpression(); err == nil {
+		t.Error("Expected error for release without SPDX expression")
+	}
+}
@@ -0,0 +1,81 @@
+package librariesio
+
+import (
+	"strings"
+	"time"
+)
+
+// StalePolicy configures which projects DetectStale reports
+type StalePolicy struct {
+	// MaxReleaseAge flags projects without a release within the given
+	// duration, 0 disables the check
+	MaxReleaseAge time.Duration
+
+	// RequireStableRelease flags projects without a stable release
+	RequireStableRelease bool
+
+	// RequireActive flags projects with a status such as
+	// Deprecated, Unmaintained or Removed
+	RequireActive bool
+}
+
+// StaleFinding holds the reasons a project violates a StalePolicy
+type StaleFinding struct {
+	Project *Project
+	Reasons []string
+
+	// Issues holds a coded issue for every reason, in the same order
+	Issues []Issue
+}
+
+// Severity returns the highest severity of the issues of the finding
+func (f *StaleFinding) Severity() Severity {
+	return maxSeverity(f.Issues)
+}
+
+// DetectStale returns a finding for every project that violates the given
+// policy, projects that comply with the policy are not reported.
+func DetectStale(projects []*Project, policy StalePolicy) []*StaleFinding {
+	var findings []*StaleFinding
+	at := now()
+
+	for _, project := range projects {
+		if project == nil {
+			continue
+		}
+
+		var issues []Issue
+
+		if policy.MaxReleaseAge > 0 {
+			if published := project.LatestReleasePublishedAt; published == nil {
+				issues = append(issues, newIssue(CodeNoRelease, "no published release"))
+			} else if age := at.Sub(*published); age > policy.MaxReleaseAge {
+				issues = append(issues, newIssue(CodeReleaseAge, "no release since %v", published.Format("2006-01-02")))
+			}
+		}
+
+		if policy.RequireStableRelease && project.LatestStableRelease == nil {
+			issues = append(issues, newIssue(CodeNoStableRelease, "no stable release"))
+		}
+
+		if policy.RequireActive && !isActive(project) {
+			issues = append(issues, newIssue(statusCode(*project.Status), "status is %v", *project.Status))
+		}
+
+		if len(issues) > 0 {
+			finding := &StaleFinding{Project: project, Issues: issues}
+			for _, issue := range issues {
+				finding.Reasons = append(finding.Reasons, issue.Message)
+			}
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings
+}
+
+// isActive reports whether the project status is empty or Active,
+// which is what libraries.io returns for maintained projects
+func isActive(p *Project) bool {
+	return p.Status == nil || *p.Status == "" || strings.EqualFold(*p.Status, "active")
+}
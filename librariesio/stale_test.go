@@ -0,0 +1,80 @@
+package librariesio
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDetectStale(t *testing.T) {
+	defer func(f func() time.Time) { now = f }(now)
+	now = func() time.Time {
+		return time.Date(2017, time.April, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	projects := []*Project{
+		{
+			Name:                     String("fresh"),
+			LatestReleasePublishedAt: Time(time.Date(2017, time.March, 1, 0, 0, 0, 0, time.UTC)),
+			LatestStableRelease:      &Release{Number: String("1.0.0")},
+		},
+		{
+			Name:                     String("old"),
+			LatestReleasePublishedAt: Time(time.Date(2015, time.March, 1, 0, 0, 0, 0, time.UTC)),
+			LatestStableRelease:      &Release{Number: String("1.0.0")},
+			Status:                   String("Deprecated"),
+		},
+		{
+			Name: String("unreleased"),
+		},
+	}
+
+	policy := StalePolicy{
+		MaxReleaseAge:        365 * 24 * time.Hour,
+		RequireStableRelease: true,
+		RequireActive:        true,
+	}
+
+	findings := DetectStale(projects, policy)
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d", len(findings))
+	}
+
+	testCases := []struct {
+		name     string
+		reasons  []string
+		codes    []FindingCode
+		severity Severity
+	}{
+		{"old", []string{"no release since 2015-03-01", "status is Deprecated"}, []FindingCode{CodeReleaseAge, CodeDeprecated}, SeverityWarning},
+		{"unreleased", []string{"no published release", "no stable release"}, []FindingCode{CodeNoRelease, CodeNoStableRelease}, SeverityWarning},
+	}
+
+	for i, testCase := range testCases {
+		if got := *findings[i].Project.Name; got != testCase.name {
+			t.Errorf("finding %d is for %v, want %v", i, got, testCase.name)
+		}
+		if got := findings[i].Reasons; !reflect.DeepEqual(got, testCase.reasons) {
+			t.Errorf("\nExpected %v\nGot %v", testCase.reasons, got)
+		}
+
+		var codes []FindingCode
+		for _, issue := range findings[i].Issues {
+			codes = append(codes, issue.Code)
+		}
+		if !reflect.DeepEqual(codes, testCase.codes) {
+			t.Errorf("\nExpected %v\nGot %v", testCase.codes, codes)
+		}
+		if got := findings[i].Severity(); got != testCase.severity {
+			t.Errorf("Severity() returned %v, want %v", got, testCase.severity)
+		}
+	}
+}
+
+func TestDetectStale_emptyPolicy(t *testing.T) {
+	projects := []*Project{{Name: String("unreleased"), Status: String("Removed")}}
+
+	if findings := DetectStale(projects, StalePolicy{}); len(findings) != 0 {
+		t.Errorf("expected no findings, got %v", findings)
+	}
+}
@@ -0,0 +1,433 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Subscription represents a subscription of the authenticated
+// user to release notifications of a project
+type Subscription struct {
+	CreatedAt         *time.Time `json:"created_at,omitempty"`
+	IncludePrerelease *bool      `json:"include_prerelease,omitempty"`
+	Project           *Project   `json:"project,omitempty"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+}
+
+// Subscriptions returns all projects the authenticated user is subscribed to.
+// If ctx is cancelled while paging, the subscriptions fetched so far are
+// returned with a PartialResultError.
+//
+// GET https://libraries.io/api/subscriptions
+func (c *Client) Subscriptions(ctx context.Context) ([]*Subscription, *Response, error) {
+	var subscriptions []*Subscription
+
+	for page := 1; ; page++ {
+		if page > 1 {
+			if err := c.waitForNextPage(ctx); err != nil {
+				return subscriptions, nil, &PartialResultError{Err: err}
+			}
+		}
+
+		urlStr, err := addOptions("subscriptions", ListOptions{Page: page, PerPage: MaxPerPage})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		request, err := c.NewRequest("GET", urlStr, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var s []*Subscription
+
+		response, err := c.Do(ctx, request, &s, WithNoCache())
+		if err != nil {
+			if err, ok := partialResult(ctx, err); ok {
+				return subscriptions, response, err
+			}
+			return nil, response, err
+		}
+
+		subscriptions = append(subscriptions, s...)
+
+		if len(s) < MaxPerPage {
+			return subscriptions, response, nil
+		}
+	}
+}
+
+// Subscription returns the subscription to the given project
+//
+// GET https://libraries.io/api/subscriptions/:platform/:name
+//
+// plat is the platform/package manager of the project
+// name is the name of the project on the platform
+func (c *Client) Subscription(ctx context.Context, plat, name string) (*Subscription, *Response, error) {
+	urlStr := fmt.Sprintf("subscriptions/%v/%v", plat, url.PathEscape(name))
+
+	request, err := c.NewRequest("GET", urlStr, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	subscription := new(Subscription)
+
+	response, err := c.Do(ctx, request, subscription, WithNoCache())
+	if err != nil {
+		return nil, response, err
+	}
+	if response.unchanged() {
+		return nil, response, nil
+	}
+
+	return subscription, response, nil
+}
+
+// subscriptionRequest is the payload for creating or updating a subscription
+type subscriptionRequest struct {
+	IncludePrerelease bool `json:"include_prerelease"`
+}
+
+// Subscribe subscribes the authenticated user to the given project.
+// If Retry is enabled and the request fails without a response or with
+// a server error, the subscription is checked and the request is only
+// sent again if it was not applied.
+//
+// POST https://libraries.io/api/subscriptions/:platform/:name
+//
+// plat is the platform/package manager of the project
+// name is the name of the project on the platform
+// includePrerelease enables notifications for prereleases
+func (c *Client) Subscribe(ctx context.Context, plat, name string, includePrerelease bool) (*Subscription, *Response, error) {
+	return c.writeSubscription(ctx, "POST", plat, name, includePrerelease)
+}
+
+// UpdateSubscription updates the subscription to the given project,
+// it is retried like Subscribe
+//
+// PUT https://libraries.io/api/subscriptions/:platform/:name
+//
+// plat is the platform/package manager of the project
+// name is the name of the project on the platform
+// includePrerelease enables notifications for prereleases
+func (c *Client) UpdateSubscription(ctx context.Context, plat, name string, includePrerelease bool) (*Subscription, *Response, error) {
+	return c.writeSubscription(ctx, "PUT", plat, name, includePrerelease)
+}
+
+// writeSubscription sends the subscription change, the failure of an
+// attempt that is settled by checking the subscription is not added to
+// the replay queue, so ReplayFailed cannot apply it a second time
+func (c *Client) writeSubscription(ctx context.Context, method, plat, name string, includePrerelease bool) (*Subscription, *Response, error) {
+	var failed *FailedRequest
+	subscription, response, err := c.sendSubscription(ctx, method, plat, name, includePrerelease, deferFailure(&failed))
+	if err == nil || !c.Retry || !ambiguousFailure(ctx, err) {
+		c.queueFailure(failed)
+		return subscription, response, err
+	}
+
+	// The request may have been applied, check before sending it again.
+	// Subscription is never answered by the cache or a pending request.
+	current, checkResponse, checkErr := c.Subscription(ctx, plat, name)
+	switch {
+	case checkErr == nil && current != nil && current.IncludePrerelease != nil && *current.IncludePrerelease == includePrerelease:
+		return current, checkResponse, nil
+	case checkErr == nil && current != nil && method == "PUT", isStatus(checkErr, http.StatusNotFound) && method == "POST":
+		return c.sendSubscription(ctx, method, plat, name, includePrerelease)
+	}
+	c.queueFailure(failed)
+	return nil, response, err
+}
+
+func (c *Client) sendSubscription(ctx context.Context, method, plat, name string, includePrerelease bool, opts ...RequestOption) (*Subscription, *Response, error) {
+	urlStr := fmt.Sprintf("subscriptions/%v/%v", plat, url.PathEscape(name))
+
+	request, err := c.NewRequest(method, urlStr, &subscriptionRequest{IncludePrerelease: includePrerelease})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	subscription := new(Subscription)
+
+	response, err := c.Do(ctx, request, subscription, opts...)
+	if err != nil {
+		return nil, response, err
+	}
+
+	if response.DryRun {
+		subscription.Project = &Project{Name: String(name), Platform: String(plat)}
+	}
+
+	return subscription, response, nil
+}
+
+// Unsubscribe removes the subscription to the given project,
+// it is retried like Subscribe
+//
+// DELETE https://libraries.io/api/subscriptions/:platform/:name
+//
+// plat is the platform/package manager of the project
+// name is the name of the project on the platform
+func (c *Client) Unsubscribe(ctx context.Context, plat, name string) (*Response, error) {
+	urlStr := fmt.Sprintf("subscriptions/%v/%v", plat, url.PathEscape(name))
+
+	request, err := c.NewRequest("DELETE", urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var failed *FailedRequest
+	response, err := c.Do(ctx, request, nil, deferFailure(&failed))
+	if err == nil || !c.Retry || !ambiguousFailure(ctx, err) {
+		c.queueFailure(failed)
+		return response, err
+	}
+
+	// The request may have been applied, check before sending it again.
+	// Subscription is never answered by the cache or a pending request.
+	_, checkResponse, checkErr := c.Subscription(ctx, plat, name)
+	switch {
+	case isStatus(checkErr, http.StatusNotFound):
+		return checkResponse, nil
+	case checkErr == nil:
+		if request, err = c.NewRequest("DELETE", urlStr, nil); err != nil {
+			return nil, err
+		}
+		return c.Do(ctx, request, nil)
+	}
+	c.queueFailure(failed)
+	return response, err
+}
+
+// ambiguousFailure reports whether a mutating request failed without
+// telling whether it was applied, because no response was received or
+// the server failed while handling it
+func ambiguousFailure(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) {
+		return errResp.Response != nil && errResp.Response.StatusCode >= 500
+	}
+
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// isStatus reports whether err is an ErrorResponse with the given status
+func isStatus(err error, status int) bool {
+	var errResp *ErrorResponse
+	return errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == status
+}
+
+// SyncOptions configures SyncSubscriptions and PlanSubscriptions
+type SyncOptions struct {
+	// IncludePrerelease is used for new subscriptions, existing
+	// subscriptions with a different setting are updated
+	IncludePrerelease bool
+
+	// Unsubscribe removes subscriptions to projects that are
+	// not part of the given refs
+	Unsubscribe bool
+}
+
+// SyncActionType is the kind of change a SyncAction makes
+type SyncActionType string
+
+// Actions taken when syncing subscriptions
+const (
+	SyncSubscribe   SyncActionType = "subscribe"
+	SyncUpdate      SyncActionType = "update"
+	SyncUnsubscribe SyncActionType = "unsubscribe"
+)
+
+// SyncAction is a single change to the user's subscriptions
+type SyncAction struct {
+	Type              SyncActionType
+	Ref               ProjectRef
+	IncludePrerelease bool
+}
+
+// String returns the action in a format suitable for review, e.g.
+// "+ npm/ava (include_prerelease=true)" or "- npm/chalk"
+func (a *SyncAction) String() string {
+	switch a.Type {
+	case SyncSubscribe:
+		return fmt.Sprintf("+ %v (include_prerelease=%v)", a.Ref, a.IncludePrerelease)
+	case SyncUpdate:
+		return fmt.Sprintf("~ %v (include_prerelease=%v)", a.Ref, a.IncludePrerelease)
+	}
+	return fmt.Sprintf("- %v", a.Ref)
+}
+
+// SyncPlan lists the changes needed to sync the user's subscriptions
+type SyncPlan struct {
+	Actions   []*SyncAction
+	Unchanged []ProjectRef
+}
+
+// String returns one line per action
+func (p *SyncPlan) String() string {
+	var lines []string
+	for _, action := range p.Actions {
+		lines = append(lines, action.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SyncSummary reports the changes made by SyncSubscriptions
+type SyncSummary struct {
+	Subscribed   []ProjectRef
+	Updated      []ProjectRef
+	Unsubscribed []ProjectRef
+	Unchanged    []ProjectRef
+	Failed       []*SyncFailure
+}
+
+// Err returns a BatchError for the projects that failed to sync,
+// or nil if all of them succeeded
+func (s *SyncSummary) Err() error {
+	var refs []ProjectRef
+	for _, group := range [][]ProjectRef{s.Subscribed, s.Updated, s.Unsubscribed, s.Unchanged} {
+		refs = append(refs, group...)
+	}
+
+	errs := make(map[ProjectRef]error)
+	for _, failure := range s.Failed {
+		refs = append(refs, failure.Ref)
+		errs[failure.Ref] = failure.Err
+	}
+	return batchError(refs, errs)
+}
+
+// SyncFailure holds the error for a project that could not be synced
+type SyncFailure struct {
+	Ref ProjectRef
+	Err error
+}
+
+// PlanSubscriptions compares the given projects against the existing
+// subscriptions of the authenticated user and returns the changes that
+// SyncSubscriptions would make, without making any of them.
+func (c *Client) PlanSubscriptions(ctx context.Context, refs []ProjectRef, opts *SyncOptions) (*SyncPlan, error) {
+	if opts == nil {
+		opts = &SyncOptions{}
+	}
+
+	subscriptions, _, err := c.Subscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]*Subscription)
+	for _, s := range subscriptions {
+		if s.Project != nil {
+			existing[subscriptionKey(stringValue(s.Project.Platform), stringValue(s.Project.Name))] = s
+		}
+	}
+
+	plan := new(SyncPlan)
+	wanted := make(map[string]bool)
+
+	for _, ref := range refs {
+		key := subscriptionKey(ref.Platform, ref.Name)
+		wanted[key] = true
+
+		s, ok := existing[key]
+		switch {
+		case !ok:
+			plan.Actions = append(plan.Actions, &SyncAction{
+				Type:              SyncSubscribe,
+				Ref:               ref,
+				IncludePrerelease: opts.IncludePrerelease,
+			})
+		case s.IncludePrerelease == nil || *s.IncludePrerelease != opts.IncludePrerelease:
+			plan.Actions = append(plan.Actions, &SyncAction{
+				Type:              SyncUpdate,
+				Ref:               ref,
+				IncludePrerelease: opts.IncludePrerelease,
+			})
+		default:
+			plan.Unchanged = append(plan.Unchanged, ref)
+		}
+	}
+
+	if !opts.Unsubscribe {
+		return plan, nil
+	}
+
+	for _, s := range subscriptions {
+		if s.Project == nil {
+			continue
+		}
+
+		ref := ProjectRef{Platform: stringValue(s.Project.Platform), Name: stringValue(s.Project.Name)}
+		if !wanted[subscriptionKey(ref.Platform, ref.Name)] {
+			plan.Actions = append(plan.Actions, &SyncAction{Type: SyncUnsubscribe, Ref: ref})
+		}
+	}
+
+	return plan, nil
+}
+
+// ApplySubscriptionPlan executes the actions of the given plan.
+//
+// Failing to change a single subscription does not abort the sync,
+// the failure is reported in the summary instead.
+func (c *Client) ApplySubscriptionPlan(ctx context.Context, plan *SyncPlan) *SyncSummary {
+	summary := &SyncSummary{Unchanged: plan.Unchanged}
+
+	for _, action := range plan.Actions {
+		var err error
+
+		switch action.Type {
+		case SyncSubscribe:
+			if _, _, err = c.Subscribe(ctx, action.Ref.Platform, action.Ref.Name, action.IncludePrerelease); err == nil {
+				summary.Subscribed = append(summary.Subscribed, action.Ref)
+			}
+		case SyncUpdate:
+			if _, _, err = c.UpdateSubscription(ctx, action.Ref.Platform, action.Ref.Name, action.IncludePrerelease); err == nil {
+				summary.Updated = append(summary.Updated, action.Ref)
+			}
+		case SyncUnsubscribe:
+			if _, err = c.Unsubscribe(ctx, action.Ref.Platform, action.Ref.Name); err == nil {
+				summary.Unsubscribed = append(summary.Unsubscribed, action.Ref)
+			}
+		default:
+			err = fmt.Errorf("unknown sync action %q", action.Type)
+		}
+
+		if err != nil {
+			summary.Failed = append(summary.Failed, &SyncFailure{Ref: action.Ref, Err: err})
+		}
+	}
+
+	return summary
+}
+
+// SyncSubscriptions subscribes to every given project that the
+// authenticated user is not subscribed to yet. It is equivalent to
+// applying the plan returned by PlanSubscriptions.
+//
+// An error is only returned if the existing subscriptions cannot be
+// listed, failures for single projects are reported in the summary.
+func (c *Client) SyncSubscriptions(ctx context.Context, refs []ProjectRef, opts *SyncOptions) (*SyncSummary, error) {
+	plan, err := c.PlanSubscriptions(ctx, refs, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.ApplySubscriptionPlan(ctx, plan), nil
+}
+
+// subscriptionKey matches platforms case-insensitively,
+// as the API returns e.g. "NPM" for the npm platform
+func subscriptionKey(plat, name string) string {
+	return strings.ToLower(plat) + "/" + name
+}
@@ -0,0 +1,481 @@
+package librariesio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hackebrot/go-repr/repr"
+)
+
+func TestSubscriptions(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		if method := "GET"; method != r.Method {
+			t.Errorf("expected HTTP %v request, got %v", method, r.Method)
+		}
+
+		if page := r.URL.Query().Get("page"); page != "1" {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+
+		// Return a full page to make the client request the next one
+		var s []string
+		for i := 0; i < MaxPerPage; i++ {
+			s = append(s, fmt.Sprintf(`{"project": {"name": "p%d", "platform": "NPM"}}`, i))
+		}
+		fmt.Fprintf(w, "[%v]", strings.Join(s, ","))
+	})
+
+	subscriptions, _, err := client.Subscriptions(context.Background())
+	if err != nil {
+		t.Fatalf("Subscriptions returned unexpected error: %v", err)
+	}
+
+	if got, want := len(subscriptions), MaxPerPage; got != want {
+		t.Errorf("got %d subscriptions, want %d", got, want)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/subscriptions/npm/ava", func(w http.ResponseWriter, r *http.Request) {
+		if method := "POST"; method != r.Method {
+			t.Errorf("expected HTTP %v request, got %v", method, r.Method)
+		}
+
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		if got, want := body["include_prerelease"], true; got != want {
+			t.Errorf("include_prerelease is %v, want %v", got, want)
+		}
+
+		fmt.Fprint(w, `{"include_prerelease": true, "project": {"name": "ava"}}`)
+	})
+
+	subscription, _, err := client.Subscribe(context.Background(), "npm", "ava", true)
+	if err != nil {
+		t.Fatalf("Subscribe returned unexpected error: %v", err)
+	}
+
+	want := &Subscription{
+		IncludePrerelease: Bool(true),
+		Project:           &Project{Name: String("ava")},
+	}
+	if !reflect.DeepEqual(subscription, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(subscription))
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/subscriptions/npm/ava", func(w http.ResponseWriter, r *http.Request) {
+		if method := "DELETE"; method != r.Method {
+			t.Errorf("expected HTTP %v request, got %v", method, r.Method)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := client.Unsubscribe(context.Background(), "npm", "ava"); err != nil {
+		t.Fatalf("Unsubscribe returned unexpected error: %v", err)
+	}
+}
+
+// handleSubscriptions serves a list of existing subscriptions and
+// records all subscription changes as "METHOD platform/name"
+func handleSubscriptions(mux *http.ServeMux, existing string) func() []string {
+	var mu sync.Mutex
+	var calls []string
+
+	mux.HandleFunc("/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, existing)
+	})
+	mux.HandleFunc("/subscriptions/", func(w http.ResponseWriter, r *http.Request) {
+		ref := strings.TrimPrefix(r.URL.Path, "/subscriptions/")
+		if ref == "npm/broken" {
+			http.Error(w, `{"error":"nope"}`, http.StatusInternalServerError)
+			return
+		}
+
+		mu.Lock()
+		calls = append(calls, r.Method+" "+ref)
+		mu.Unlock()
+		fmt.Fprint(w, `{}`)
+	})
+
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		sort.Strings(calls)
+		return calls
+	}
+}
+
+const testExistingSubscriptions = `[
+	{"include_prerelease": false, "project": {"name": "ava", "platform": "NPM"}},
+	{"include_prerelease": true, "project": {"name": "mocha", "platform": "NPM"}},
+	{"include_prerelease": false, "project": {"name": "left-pad", "platform": "NPM"}}
+]`
+
+func TestSubscriptions_escaped(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.EscapedPath(), "/subscriptions/npm/@babel%2Fcore"; got != want {
+			t.Errorf("%v requested %v, want %v", r.Method, got, want)
+		}
+		fmt.Fprint(w, `{}`)
+	})
+
+	ctx := context.Background()
+	if _, _, err := client.Subscription(ctx, "npm", "@babel/core"); err != nil {
+		t.Fatalf("Subscription returned unexpected error: %v", err)
+	}
+	if _, _, err := client.Subscribe(ctx, "npm", "@babel/core", true); err != nil {
+		t.Fatalf("Subscribe returned unexpected error: %v", err)
+	}
+	if _, err := client.Unsubscribe(ctx, "npm", "@babel/core"); err != nil {
+		t.Fatalf("Unsubscribe returned unexpected error: %v", err)
+	}
+}
+
+func TestSyncSubscriptions(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	calls := handleSubscriptions(mux, testExistingSubscriptions)
+
+	refs := []ProjectRef{
+		{Platform: "npm", Name: "ava"},
+		{Platform: "npm", Name: "mocha"},
+		{Platform: "npm", Name: "chalk"},
+		{Platform: "npm", Name: "broken"},
+	}
+
+	summary, err := client.SyncSubscriptions(context.Background(), refs, &SyncOptions{Unsubscribe: true})
+	if err != nil {
+		t.Fatalf("SyncSubscriptions returned unexpected error: %v", err)
+	}
+
+	wantCalls := []string{"DELETE NPM/left-pad", "POST npm/chalk", "PUT npm/mocha"}
+	if got := calls(); !reflect.DeepEqual(got, wantCalls) {
+		t.Errorf("\nExpected %v\nGot %v", wantCalls, got)
+	}
+
+	if len(summary.Failed) != 1 || summary.Failed[0].Ref != refs[3] {
+		t.Errorf("expected broken to fail, got %v", repr.Repr(summary.Failed))
+	}
+
+	var batchErr *BatchError
+	if err := summary.Err(); !errors.As(err, &batchErr) {
+		t.Fatalf("expected *BatchError, got %v", err)
+	}
+	if failed := batchErr.Failed(); len(failed) != 1 || failed[0] != refs[3] || len(batchErr.Succeeded()) != 4 {
+		t.Errorf("unexpected batch error %v", batchErr)
+	}
+	summary.Failed = nil
+	if err := summary.Err(); err != nil {
+		t.Errorf("expected no error without failures, got %v", err)
+	}
+
+	want := &SyncSummary{
+		Subscribed:   []ProjectRef{refs[2]},
+		Updated:      []ProjectRef{refs[1]},
+		Unsubscribed: []ProjectRef{{Platform: "NPM", Name: "left-pad"}},
+		Unchanged:    []ProjectRef{refs[0]},
+	}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("\nExpected %v\nGot %v", repr.Repr(want), repr.Repr(summary))
+	}
+}
+
+func TestSyncSubscriptions_keepsOthers(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	calls := handleSubscriptions(mux, testExistingSubscriptions)
+
+	refs := []ProjectRef{{Platform: "npm", Name: "ava"}}
+
+	if _, err := client.SyncSubscriptions(context.Background(), refs, nil); err != nil {
+		t.Fatalf("SyncSubscriptions returned unexpected error: %v", err)
+	}
+
+	if got := calls(); len(got) != 0 {
+		t.Errorf("expected no changes, got %v", got)
+	}
+}
+
+func TestPlanSubscriptions(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	calls := handleSubscriptions(mux, testExistingSubscriptions)
+
+	refs := []ProjectRef{
+		{Platform: "npm", Name: "ava"},
+		{Platform: "npm", Name: "mocha"},
+		{Platform: "npm", Name: "chalk"},
+	}
+
+	plan, err := client.PlanSubscriptions(context.Background(), refs, &SyncOptions{Unsubscribe: true})
+	if err != nil {
+		t.Fatalf("PlanSubscriptions returned unexpected error: %v", err)
+	}
+
+	if got := calls(); len(got) != 0 {
+		t.Errorf("expected no changes, got %v", got)
+	}
+
+	want := strings.Join([]string{
+		"~ npm/mocha (include_prerelease=false)",
+		"+ npm/chalk (include_prerelease=false)",
+		"- NPM/left-pad",
+	}, "\n")
+	if got := plan.String(); got != want {
+		t.Errorf("\nExpected %v\nGot %v", want, got)
+	}
+
+	if !reflect.DeepEqual(plan.Unchanged, refs[:1]) {
+		t.Errorf("unexpected unchanged refs %v", plan.Unchanged)
+	}
+}
+
+func TestSubscribe_retryApplied(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	client.Retry = true
+	defer server.Close()
+
+	var posts int
+	mux.HandleFunc("/subscriptions/npm/ava", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			// The subscription is created but the response fails
+			posts++
+			http.Error(w, `{"error":"Bad Gateway"}`, http.StatusBadGateway)
+		case "GET":
+			fmt.Fprint(w, `{"include_prerelease": true, "project": {"name": "ava"}}`)
+		}
+	})
+
+	subscription, _, err := client.Subscribe(context.Background(), "npm", "ava", true)
+	if err != nil {
+		t.Fatalf("Subscribe returned unexpected error: %v", err)
+	}
+	if posts != 1 {
+		t.Errorf("expected applied subscription not to be sent again, got %d requests", posts)
+	}
+	if want := Bool(true); !reflect.DeepEqual(subscription.IncludePrerelease, want) {
+		t.Errorf("unexpected subscription %v", repr.Repr(subscription))
+	}
+}
+
+func TestSubscribe_retryNotApplied(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	client.Retry = true
+	defer server.Close()
+
+	var posts int
+	mux.HandleFunc("/subscriptions/npm/ava", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET":
+			http.Error(w, `{"error":"Not Found"}`, http.StatusNotFound)
+		case posts == 0:
+			posts++
+			http.Error(w, `{"error":"Service Unavailable"}`, http.StatusServiceUnavailable)
+		default:
+			posts++
+			fmt.Fprint(w, `{"include_prerelease": false, "project": {"name": "ava"}}`)
+		}
+	})
+
+	if _, _, err := client.Subscribe(context.Background(), "npm", "ava", false); err != nil {
+		t.Fatalf("Subscribe returned unexpected error: %v", err)
+	}
+	if posts != 2 {
+		t.Errorf("expected subscription to be sent again, got %d requests", posts)
+	}
+}
+
+func TestUnsubscribe_retryApplied(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	client.Retry = true
+	defer server.Close()
+
+	var deletes int
+	mux.HandleFunc("/subscriptions/npm/ava", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "DELETE":
+			deletes++
+			http.Error(w, `{"error":"Internal Server Error"}`, http.StatusInternalServerError)
+		case "GET":
+			http.Error(w, `{"error":"Not Found"}`, http.StatusNotFound)
+		}
+	})
+
+	if _, err := client.Unsubscribe(context.Background(), "npm", "ava"); err != nil {
+		t.Fatalf("Unsubscribe returned unexpected error: %v", err)
+	}
+	if deletes != 1 {
+		t.Errorf("expected removed subscription not to be deleted again, got %d requests", deletes)
+	}
+}
+
+func TestSubscribe_retryCheckUncached(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey, WithCache(NewLRUCache(1<<20, nil)), WithScheduler(NewScheduler(2, nil, nil)))
+	client.BaseURL = url
+	client.Retry = true
+	defer server.Close()
+
+	var posts int
+	var subscribed bool
+	mux.HandleFunc("/subscriptions/npm/ava", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST":
+			// The subscription is created but the response fails
+			posts++
+			subscribed = true
+			http.Error(w, `{"error":"Bad Gateway"}`, http.StatusBadGateway)
+		case subscribed:
+			fmt.Fprint(w, `{"include_prerelease": true, "project": {"name": "ava"}}`)
+		default:
+			fmt.Fprint(w, `{"include_prerelease": false, "project": {"name": "ava"}}`)
+		}
+	})
+
+	// A response of the previous state must not answer the check
+	if _, _, err := client.Subscription(context.Background(), "npm", "ava"); err != nil {
+		t.Fatalf("Subscription returned unexpected error: %v", err)
+	}
+
+	subscription, _, err := client.Subscribe(context.Background(), "npm", "ava", true)
+	if err != nil {
+		t.Fatalf("Subscribe returned unexpected error: %v", err)
+	}
+	if posts != 1 {
+		t.Errorf("expected applied subscription not to be sent again, got %d requests", posts)
+	}
+	if want := Bool(true); !reflect.DeepEqual(subscription.IncludePrerelease, want) {
+		t.Errorf("unexpected subscription %v", repr.Repr(subscription))
+	}
+}
+
+func TestSubscribe_retryNotQueued(t *testing.T) {
+	server, mux, url := startNewServer()
+	queue := NewFileReplayQueue(filepath.Join(t.TempDir(), "failed.jsonl"))
+	client := NewClient(APIKey, WithReplayQueue(queue))
+	client.BaseURL = url
+	client.Retry = true
+	defer server.Close()
+
+	var subscribed bool
+	mux.HandleFunc("/subscriptions/npm/ava", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST":
+			subscribed = true
+			http.Error(w, `{"error":"Bad Gateway"}`, http.StatusBadGateway)
+		case subscribed:
+			fmt.Fprint(w, `{"include_prerelease": true, "project": {"name": "ava"}}`)
+		default:
+			http.Error(w, `{"error":"Not Found"}`, http.StatusNotFound)
+		}
+	})
+
+	if _, _, err := client.Subscribe(context.Background(), "npm", "ava", true); err != nil {
+		t.Fatalf("Subscribe returned unexpected error: %v", err)
+	}
+
+	reqs, err := queue.Take()
+	if err != nil {
+		t.Fatalf("Take returned unexpected error: %v", err)
+	}
+	if len(reqs) != 0 {
+		t.Errorf("expected settled subscription not to be queued, got %+v", reqs)
+	}
+}
+
+func TestSubscribe_retryNotModified(t *testing.T) {
+	server, mux, url := startNewServer()
+	queue := NewFileReplayQueue(filepath.Join(t.TempDir(), "failed.jsonl"))
+	client := NewClient(APIKey, WithReplayQueue(queue))
+	client.BaseURL = url
+	client.Retry = true
+	defer server.Close()
+
+	mux.HandleFunc("/subscriptions/npm/ava", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		http.Error(w, `{"error":"Bad Gateway"}`, http.StatusBadGateway)
+	})
+
+	ctx := NewContext(context.Background(), WithIfNoneMatch(`"abc"`))
+	if _, _, err := client.Subscribe(ctx, "npm", "ava", true); err == nil {
+		t.Fatal("expected Subscribe to fail when the check is not modified")
+	}
+
+	reqs, err := queue.Take()
+	if err != nil {
+		t.Fatalf("Take returned unexpected error: %v", err)
+	}
+	if len(reqs) != 1 || reqs[0].Method != "POST" {
+		t.Errorf("expected unsettled subscription to be queued, got %+v", reqs)
+	}
+}
+
+func TestSubscribe_noRetry(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/subscriptions/npm/ava", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			t.Error("expected subscription not to be checked without Retry")
+		}
+		http.Error(w, `{"error":"Bad Gateway"}`, http.StatusBadGateway)
+	})
+
+	if _, _, err := client.Subscribe(context.Background(), "npm", "ava", true); err == nil {
+		t.Error("Expected error for failed subscription")
+	}
+}
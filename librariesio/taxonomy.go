@@ -0,0 +1,113 @@
+package librariesio
+
+import "strings"
+
+// keywordStopWords are dropped by NormalizeKeywords as they
+// say nothing about what a project does
+var keywordStopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "for": true, "in": true, "of": true,
+	"on": true, "or": true, "the": true, "to": true, "with": true,
+}
+
+// NormalizeKeywords returns the keywords trimmed, lowercased, with spaces
+// and underscores replaced by dashes and leading # stripped. Empty
+// keywords, stop words and duplicates are dropped, the first occurrence
+// of every keyword keeps its position.
+func NormalizeKeywords(keywords []*string) []string {
+	var normalized []string
+	seen := make(map[string]bool)
+
+	for _, k := range keywords {
+		keyword := strings.ToLower(strings.TrimSpace(stringValue(k)))
+		keyword = strings.TrimLeft(keyword, "#")
+		keyword = strings.Join(strings.FieldsFunc(keyword, func(r rune) bool {
+			return r == ' ' || r == '_' || r == '-' || r == '\t'
+		}), "-")
+
+		if keyword == "" || keywordStopWords[keyword] || seen[keyword] {
+			continue
+		}
+		seen[keyword] = true
+		normalized = append(normalized, keyword)
+	}
+	return normalized
+}
+
+// NormalizedKeywords returns the keywords of the project normalized
+// with NormalizeKeywords
+func (p *Project) NormalizedKeywords() []string {
+	return NormalizeKeywords(p.Keywords)
+}
+
+// canonicalLanguages maps lowercased language names and their common
+// aliases to the names used by GitHub linguist
+var canonicalLanguages = map[string]string{
+	"bash":             "Shell",
+	"c":                "C",
+	"c#":               "C#",
+	"c++":              "C++",
+	"clojure":          "Clojure",
+	"coffeescript":     "CoffeeScript",
+	"cpp":              "C++",
+	"csharp":           "C#",
+	"css":              "CSS",
+	"dart":             "Dart",
+	"elisp":            "Emacs Lisp",
+	"elixir":           "Elixir",
+	"elm":              "Elm",
+	"emacs lisp":       "Emacs Lisp",
+	"erlang":           "Erlang",
+	"f#":               "F#",
+	"fsharp":           "F#",
+	"go":               "Go",
+	"golang":           "Go",
+	"haskell":          "Haskell",
+	"html":             "HTML",
+	"java":             "Java",
+	"javascript":       "JavaScript",
+	"js":               "JavaScript",
+	"jupyter":          "Jupyter Notebook",
+	"jupyter notebook": "Jupyter Notebook",
+	"kotlin":           "Kotlin",
+	"lua":              "Lua",
+	"objc":             "Objective-C",
+	"objective-c":      "Objective-C",
+	"objective-c++":    "Objective-C++",
+	"ocaml":            "OCaml",
+	"perl":             "Perl",
+	"php":              "PHP",
+	"py":               "Python",
+	"python":           "Python",
+	"r":                "R",
+	"rb":               "Ruby",
+	"ruby":             "Ruby",
+	"rust":             "Rust",
+	"scala":            "Scala",
+	"sh":               "Shell",
+	"shell":            "Shell",
+	"swift":            "Swift",
+	"ts":               "TypeScript",
+	"typescript":       "TypeScript",
+	"vb.net":           "Visual Basic",
+	"vim script":       "Vim Script",
+	"viml":             "Vim Script",
+	"vimscript":        "Vim Script",
+	"visual basic":     "Visual Basic",
+}
+
+// CanonicalLanguage returns the canonical name of a programming language,
+// e.g. JavaScript for js or Go for golang. Unknown languages are returned
+// with surrounding whitespace trimmed.
+func CanonicalLanguage(lang string) string {
+	lang = strings.TrimSpace(lang)
+	if canonical, ok := canonicalLanguages[strings.ToLower(lang)]; ok {
+		return canonical
+	}
+	return lang
+}
+
+// CanonicalLanguage returns the canonical name of the language of
+// the project, see CanonicalLanguage
+func (p *Project) CanonicalLanguage() string {
+	return CanonicalLanguage(stringValue(p.Language))
+}
@@ -0,0 +1,44 @@
+package librariesio
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeKeywords(t *testing.T) {
+	project := &Project{Keywords: []*string{
+		String("React"), String(" #react "), String("UI_Components"), String("ui components"),
+		String("the"), String(""), nil, String("state--management"),
+	}}
+
+	want := []string{"react", "ui-components", "state-management"}
+	if got := project.NormalizedKeywords(); !reflect.DeepEqual(got, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, got)
+	}
+}
+
+func TestCanonicalLanguage(t *testing.T) {
+	testCases := []struct {
+		lang string
+		want string
+	}{
+		{"javascript", "JavaScript"},
+		{"JS", "JavaScript"},
+		{" golang ", "Go"},
+		{"C++", "C++"},
+		{"csharp", "C#"},
+		{"VimL", "Vim Script"},
+		{"Zig", "Zig"},
+		{"", ""},
+	}
+
+	for _, testCase := range testCases {
+		if got := CanonicalLanguage(testCase.lang); got != testCase.want {
+			t.Errorf("CanonicalLanguage(%q) returned %q, want %q", testCase.lang, got, testCase.want)
+		}
+	}
+
+	if got := (&Project{Language: String("python")}).CanonicalLanguage(); got != "Python" {
+		t.Errorf("unexpected project language %q", got)
+	}
+}
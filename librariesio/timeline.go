@@ -0,0 +1,165 @@
+package librariesio
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ReleaseEventType is the kind of a ReleaseEvent
+type ReleaseEventType string
+
+// Events of a release timeline
+const (
+	EventFirstRelease  ReleaseEventType = "first_release"
+	EventFirstStable   ReleaseEventType = "first_stable"
+	EventRelease       ReleaseEventType = "release"
+	EventLatestStable  ReleaseEventType = "latest_stable"
+	EventLatestRelease ReleaseEventType = "latest_release"
+
+	// EventGap marks version numbers that were skipped, which often
+	// means a release was yanked or removed from the registry
+	EventGap ReleaseEventType = "gap"
+)
+
+// ReleaseEvent is an entry of the timeline returned by ReleaseTimeline
+type ReleaseEvent struct {
+	Type    ReleaseEventType
+	At      time.Time
+	Release *Release
+
+	// Missing lists the skipped versions of an EventGap
+	Missing []string
+}
+
+// String describes the event, e.g. "2017-04-01 first_stable 1.0.0"
+func (e *ReleaseEvent) String() string {
+	s := fmt.Sprintf("%v %v %v", e.At.Format("2006-01-02"), e.Type, stringValue(e.Release.Number))
+	if len(e.Missing) > 0 {
+		s += fmt.Sprintf(" (missing %v)", e.Missing)
+	}
+	return s
+}
+
+var (
+	prereleasePattern = regexp.MustCompile(`(?i)-|alpha|beta|rc|dev|pre|snapshot|[0-9][ab][0-9]`)
+	semverPattern     = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)$`)
+)
+
+// isPrerelease reports whether the version number looks like a
+// prerelease in semver, PEP 440 or Maven notation
+func isPrerelease(number string) bool {
+	return prereleasePattern.MatchString(number)
+}
+
+// ReleaseTimeline returns the releases of the project in the order they
+// were published, as typed events for rendering the history of a project.
+// Every release is a release event, the first release, first stable,
+// latest stable and latest release additionally get their own event. A gap
+// event is added before a stable release that skipped patch or minor
+// versions compared to the previous one. Releases without a publish date
+// are not part of the timeline.
+func ReleaseTimeline(project *Project) []*ReleaseEvent {
+	var releases []*Release
+	for _, r := range project.Versions {
+		if r != nil && r.Number != nil && r.PublishedAt != nil {
+			releases = append(releases, r)
+		}
+	}
+	if len(releases) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(releases, func(i, j int) bool {
+		return releases[i].PublishedAt.Before(*releases[j].PublishedAt)
+	})
+
+	latestStable := -1
+	for i, r := range releases {
+		if !isPrerelease(*r.Number) {
+			latestStable = i
+		}
+	}
+
+	var events []*ReleaseEvent
+	add := func(t ReleaseEventType, r *Release, missing []string) {
+		events = append(events, &ReleaseEvent{Type: t, At: *r.PublishedAt, Release: r, Missing: missing})
+	}
+
+	var previous *Release
+	seenStable := false
+	for i, r := range releases {
+		stable := !isPrerelease(*r.Number)
+
+		if stable && previous != nil {
+			if missing := skippedVersions(*previous.Number, *r.Number); len(missing) > 0 {
+				add(EventGap, r, missing)
+			}
+		}
+
+		add(EventRelease, r, nil)
+		if i == 0 {
+			add(EventFirstRelease, r, nil)
+		}
+		if stable && !seenStable {
+			seenStable = true
+			add(EventFirstStable, r, nil)
+		}
+		if i == latestStable {
+			add(EventLatestStable, r, nil)
+		}
+		if i == len(releases)-1 {
+			add(EventLatestRelease, r, nil)
+		}
+
+		if stable {
+			previous = r
+		}
+	}
+
+	return events
+}
+
+// skippedVersions returns the versions between two consecutive stable
+// releases that were skipped, it only handles major.minor.patch numbers
+func skippedVersions(from, to string) []string {
+	a, ok := parseSemver(from)
+	if !ok {
+		return nil
+	}
+	b, ok := parseSemver(to)
+	if !ok || a[0] != b[0] {
+		return nil
+	}
+
+	var missing []string
+	switch {
+	case a[1] == b[1]:
+		for patch := a[2] + 1; patch < b[2]; patch++ {
+			missing = append(missing, fmt.Sprintf("%d.%d.%d", a[0], a[1], patch))
+		}
+	case b[2] == 0:
+		for minor := a[1] + 1; minor < b[1]; minor++ {
+			missing = append(missing, fmt.Sprintf("%d.%d.0", a[0], minor))
+		}
+	}
+	return missing
+}
+
+func parseSemver(number string) ([3]int, bool) {
+	var v [3]int
+	m := semverPattern.FindStringSubmatch(number)
+	if m == nil {
+		return v, false
+	}
+	for i := range v {
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return v, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
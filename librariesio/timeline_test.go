@@ -0,0 +1,82 @@
+package librariesio
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReleaseTimeline(t *testing.T) {
+	at := func(day int) *time.Time {
+		return Time(time.Date(2017, time.April, day, 0, 0, 0, 0, time.UTC))
+	}
+
+	project := &Project{Versions: []*Release{
+		{Number: String("1.0.0"), PublishedAt: at(3)},
+		{Number: String("1.0.0-beta.1"), PublishedAt: at(1)},
+		{Number: String("1.0.3"), PublishedAt: at(5)},
+		{Number: String("1.1.0-rc1"), PublishedAt: at(6)},
+		{Number: String("0.9.0")},
+	}}
+
+	var got []string
+	for _, event := range ReleaseTimeline(project) {
+		got = append(got, event.String())
+	}
+
+	want := []string{
+		"2017-04-01 release 1.0.0-beta.1",
+		"2017-04-01 first_release 1.0.0-beta.1",
+		"2017-04-03 release 1.0.0",
+		"2017-04-03 first_stable 1.0.0",
+		"2017-04-05 gap 1.0.3 (missing [1.0.1 1.0.2])",
+		"2017-04-05 release 1.0.3",
+		"2017-04-05 latest_stable 1.0.3",
+		"2017-04-06 release 1.1.0-rc1",
+		"2017-04-06 latest_release 1.1.0-rc1",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, got)
+	}
+
+	if events := ReleaseTimeline(&Project{}); events != nil {
+		t.Errorf("expected no events without versions, got %v", events)
+	}
+}
+
+func TestSkippedVersions(t *testing.T) {
+	testCases := []struct {
+		from, to string
+		want     []string
+	}{
+		{"1.2.0", "1.2.1", nil},
+		{"1.2.0", "1.2.3", []string{"1.2.1", "1.2.2"}},
+		{"v1.2.5", "v1.5.0", []string{"1.3.0", "1.4.0"}},
+		{"1.2.5", "1.3.2", nil},
+		{"1.9.0", "2.0.0", nil},
+		{"1.0", "1.3", nil},
+	}
+
+	for _, testCase := range testCases {
+		if got := skippedVersions(testCase.from, testCase.to); !reflect.DeepEqual(got, testCase.want) {
+			t.Errorf("%v -> %v: got %v, want %v", testCase.from, testCase.to, got, testCase.want)
+		}
+	}
+}
+
+func TestIsPrerelease(t *testing.T) {
+	for number, want := range map[string]bool{
+		"1.0.0":        false,
+		"2.5":          false,
+		"1.0.0-beta.1": true,
+		"2.0.0rc1":     true,
+		"1.0a1":        true,
+		"3.1.0.dev2":   true,
+		"1.0-SNAPSHOT": true,
+		"4.0.0.Final":  false,
+	} {
+		if got := isPrerelease(number); got != want {
+			t.Errorf("isPrerelease(%q) is %v, want %v", number, got, want)
+		}
+	}
+}
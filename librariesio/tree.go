@@ -0,0 +1,245 @@
+package librariesio
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// DependencyNode is a node of a resolved dependency tree
+type DependencyNode struct {
+	Platform string
+	Name     string
+	Version  string
+
+	// Requirements is the version requirement declared by the parent,
+	// it is empty for the root of the tree
+	Requirements string
+
+	// Dependency is the dependency as returned by the API,
+	// it is nil for the root of the tree
+	Dependency *ProjectDependency
+
+	Dependencies []*DependencyNode
+}
+
+// Ref returns the ProjectRef of the node
+func (n *DependencyNode) Ref() ProjectRef {
+	return ProjectRef{Platform: n.Platform, Name: n.Name, Version: n.Version}
+}
+
+// Walk calls fn for every node in the tree in depth-first order together
+// with the path of nodes from the root to the parent of the node.
+// Children of a node are skipped if fn returns false.
+func (n *DependencyNode) Walk(fn func(node *DependencyNode, path []*DependencyNode) bool) {
+	var walk func(*DependencyNode, []*DependencyNode)
+	walk = func(node *DependencyNode, path []*DependencyNode) {
+		if !fn(node, path) {
+			return
+		}
+		path = append(path, node)
+		for _, child := range node.Dependencies {
+			walk(child, path[:len(path):len(path)])
+		}
+	}
+	walk(n, nil)
+}
+
+// ResolveOptions configures ResolveTree
+type ResolveOptions struct {
+	// MaxDepth limits how deep the tree is resolved, 0 means no limit
+	MaxDepth int
+
+	// MaxNodes limits the number of nodes in the tree including the root,
+	// dependencies beyond the limit are left out. 0 means no limit.
+	MaxNodes int
+
+	// ExcludeDev leaves out development and test dependencies
+	ExcludeDev bool
+
+	// ExcludeOptional leaves out optional dependencies
+	ExcludeOptional bool
+
+	// Platforms only includes dependencies on the given platforms,
+	// compared case insensitively. All platforms are included if empty.
+	Platforms []string
+
+	// Stop is called for every dependency added to the tree,
+	// its dependencies are not resolved if it returns true
+	Stop func(node *DependencyNode) bool
+
+	// Cache is used to look up and store the dependencies of every
+	// project version, share it between calls to avoid fetching common
+	// dependencies again. A new cache is used for every call if nil.
+	Cache *ResolutionCache
+}
+
+// ResolutionCache memoizes the dependencies of project versions for
+// ResolveTree. It is safe for concurrent use.
+type ResolutionCache struct {
+	mu       sync.Mutex
+	projects map[string]*Project
+}
+
+// NewResolutionCache returns an empty ResolutionCache
+func NewResolutionCache() *ResolutionCache {
+	return &ResolutionCache{projects: make(map[string]*Project)}
+}
+
+// Len returns the number of cached project versions
+func (c *ResolutionCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.projects)
+}
+
+func (c *ResolutionCache) get(key string) (*Project, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	project, ok := c.projects[key]
+	return project, ok
+}
+
+func (c *ResolutionCache) set(key string, project *Project) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.projects[key] = project
+}
+
+// include reports whether the dependency passes the filters
+func (o *ResolveOptions) include(dep *ProjectDependency, node *DependencyNode) bool {
+	if o.ExcludeDev && isDevDependency(dep) {
+		return false
+	}
+	if o.ExcludeOptional && dep.Optional != nil && *dep.Optional {
+		return false
+	}
+	if len(o.Platforms) == 0 {
+		return true
+	}
+	for _, plat := range o.Platforms {
+		if strings.EqualFold(plat, node.Platform) {
+			return true
+		}
+	}
+	return false
+}
+
+// isDevDependency reports whether the dependency is only needed
+// for development, the kinds differ between package managers
+func isDevDependency(dep *ProjectDependency) bool {
+	scope := dep.Scope()
+	return scope == ScopeDevelopment || scope == ScopeTest
+}
+
+// ResolveTree resolves the dependency tree of the given project version
+// by recursively fetching the dependencies of every dependency.
+//
+// Dependencies are resolved to their latest stable release as reported
+// by the API. Cyclic dependencies are included in the tree but are not
+// resolved any further. Dependencies can be filtered with opts to keep
+// large trees tractable.
+//
+// If ctx is cancelled, the tree resolved so far is returned together
+// with a PartialResultError.
+func (c *Client) ResolveTree(ctx context.Context, plat, name, ver string, opts *ResolveOptions) (*DependencyNode, error) {
+	if opts == nil {
+		opts = &ResolveOptions{}
+	}
+
+	cache := opts.Cache
+	if cache == nil {
+		cache = NewResolutionCache()
+	}
+
+	r := &resolver{client: c, opts: opts, cache: cache, nodes: 1}
+	root := &DependencyNode{Platform: plat, Name: name, Version: ver}
+
+	if err := r.resolve(ctx, root, make(map[string]bool), 0); err != nil {
+		if err, ok := partialResult(ctx, err); ok {
+			return root, err
+		}
+		return nil, err
+	}
+	return root, nil
+}
+
+type resolver struct {
+	client *Client
+	opts   *ResolveOptions
+	cache  *ResolutionCache
+	nodes  int
+}
+
+func (r *resolver) resolve(ctx context.Context, node *DependencyNode, ancestors map[string]bool, depth int) error {
+	key := strings.ToLower(node.Ref().String())
+	if ancestors[key] {
+		return nil
+	}
+	if r.opts.MaxDepth > 0 && depth >= r.opts.MaxDepth {
+		return nil
+	}
+
+	project, ok := r.cache.get(key)
+	if !ok {
+		var err error
+		project, _, err = r.client.ProjectDeps(ctx, node.Platform, node.Name, node.Version)
+		if err != nil {
+			return err
+		}
+		r.cache.set(key, project)
+	}
+
+	ancestors[key] = true
+	defer delete(ancestors, key)
+
+	for _, dep := range project.Dependencies {
+		if dep == nil {
+			continue
+		}
+
+		child := dependencyNode(dep, node.Platform)
+		if !r.opts.include(dep, child) {
+			continue
+		}
+		if r.opts.MaxNodes > 0 && r.nodes >= r.opts.MaxNodes {
+			return nil
+		}
+
+		node.Dependencies = append(node.Dependencies, child)
+		r.nodes++
+
+		if r.opts.Stop != nil && r.opts.Stop(child) {
+			continue
+		}
+		if err := r.resolve(ctx, child, ancestors, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dependencyNode creates an unresolved node for the given dependency
+func dependencyNode(dep *ProjectDependency, plat string) *DependencyNode {
+	node := &DependencyNode{
+		Platform:     plat,
+		Name:         stringValue(dep.ProjectName),
+		Version:      stringValue(dep.LatestStable),
+		Requirements: stringValue(dep.Requirements),
+		Dependency:   dep,
+	}
+
+	if dep.Platform != nil && *dep.Platform != "" {
+		node.Platform = *dep.Platform
+	}
+	if node.Name == "" {
+		node.Name = stringValue(dep.Name)
+	}
+	if node.Version == "" {
+		node.Version = stringValue(dep.Latest)
+	}
+	if node.Version == "" {
+		node.Version = "latest"
+	}
+	return node
+}
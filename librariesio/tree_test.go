@@ -0,0 +1,283 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// handleDeps serves the given dependencies JSON per project version,
+// deps maps "name@version" to the JSON array of dependencies
+func handleDeps(mux *http.ServeMux, deps map[string]string) map[string]int {
+	calls := make(map[string]int)
+
+	for key, body := range deps {
+		parts := strings.SplitN(key, "@", 2)
+		name, ver := parts[0], parts[1]
+
+		body := body
+		key := key
+		mux.HandleFunc(fmt.Sprintf("/npm/%v/%v/dependencies", name, ver), func(w http.ResponseWriter, r *http.Request) {
+			calls[key]++
+			fmt.Fprintf(w, `{"name": %q, "dependencies": %v}`, name, body)
+		})
+	}
+	return calls
+}
+
+var testTreeDeps = map[string]string{
+	"app@1.0.0": `[
+		{"project_name": "a", "platform": "npm", "requirements": "^1.0.0", "latest_stable": "1.2.0"},
+		{"project_name": "b", "platform": "npm", "requirements": "^2.0.0", "latest_stable": "2.0.0"}
+	]`,
+	"a@1.2.0": `[
+		{"project_name": "c", "platform": "npm", "requirements": "~1.0.0", "latest_stable": "1.0.5"}
+	]`,
+	"b@2.0.0": `[
+		{"project_name": "c", "platform": "npm", "requirements": "^1.0.0", "latest_stable": "1.0.5"},
+		{"project_name": "a", "platform": "npm", "requirements": "^1.0.0", "latest_stable": "1.2.0"}
+	]`,
+	"c@1.0.5": `[
+		{"project_name": "app", "platform": "npm", "requirements": "*", "latest_stable": "1.0.0"}
+	]`,
+}
+
+// treeString renders the tree as nested name@version strings
+func treeString(n *DependencyNode) string {
+	s := n.Name + "@" + n.Version
+	if len(n.Dependencies) > 0 {
+		s += "("
+		for i, child := range n.Dependencies {
+			if i > 0 {
+				s += " "
+			}
+			s += treeString(child)
+		}
+		s += ")"
+	}
+	return s
+}
+
+func TestResolveTree(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	calls := handleDeps(mux, testTreeDeps)
+
+	tree, err := client.ResolveTree(context.Background(), "npm", "app", "1.0.0", nil)
+	if err != nil {
+		t.Fatalf("ResolveTree returned unexpected error: %v", err)
+	}
+
+	want := "app@1.0.0(a@1.2.0(c@1.0.5(app@1.0.0)) b@2.0.0(c@1.0.5(app@1.0.0) a@1.2.0(c@1.0.5(app@1.0.0))))"
+	if got := treeString(tree); got != want {
+		t.Errorf("\nExpected %v\nGot %v", want, got)
+	}
+
+	for key, n := range calls {
+		if n != 1 {
+			t.Errorf("dependencies of %v fetched %d times, want 1", key, n)
+		}
+	}
+}
+
+func TestResolveTree_maxDepth(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	handleDeps(mux, testTreeDeps)
+
+	tree, err := client.ResolveTree(context.Background(), "npm", "app", "1.0.0", &ResolveOptions{MaxDepth: 1})
+	if err != nil {
+		t.Fatalf("ResolveTree returned unexpected error: %v", err)
+	}
+
+	if got, want := treeString(tree), "app@1.0.0(a@1.2.0 b@2.0.0)"; got != want {
+		t.Errorf("\nExpected %v\nGot %v", want, got)
+	}
+}
+
+func TestResolveTree_filters(t *testing.T) {
+	deps := map[string]string{
+		"app@1.0.0": `[
+			{"project_name": "a", "platform": "npm", "kind": "runtime", "latest_stable": "1.2.0"},
+			{"project_name": "d", "platform": "npm", "kind": "Development", "latest_stable": "1.0.0"},
+			{"project_name": "e", "platform": "npm", "optional": true, "latest_stable": "1.0.0"},
+			{"project_name": "f", "platform": "Bower", "latest_stable": "1.0.0"}
+		]`,
+		"a@1.2.0": `[
+			{"project_name": "c", "platform": "npm", "latest_stable": "1.0.5"}
+		]`,
+		"c@1.0.5": `[]`,
+		"d@1.0.0": `[]`,
+		"e@1.0.0": `[]`,
+	}
+
+	testCases := []struct {
+		name string
+		opts *ResolveOptions
+		want string
+	}{
+		{
+			"exclude dev",
+			&ResolveOptions{ExcludeDev: true, Platforms: []string{"NPM"}},
+			"app@1.0.0(a@1.2.0(c@1.0.5) e@1.0.0)",
+		},
+		{
+			"exclude optional",
+			&ResolveOptions{ExcludeOptional: true, Platforms: []string{"npm"}},
+			"app@1.0.0(a@1.2.0(c@1.0.5) d@1.0.0)",
+		},
+		{
+			"stop",
+			&ResolveOptions{
+				ExcludeDev:      true,
+				ExcludeOptional: true,
+				Stop:            func(node *DependencyNode) bool { return node.Name == "a" || node.Platform == "Bower" },
+			},
+			"app@1.0.0(a@1.2.0 f@1.0.0)",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server, mux, url := startNewServer()
+			client := NewClient(APIKey)
+			client.BaseURL = url
+			defer server.Close()
+
+			handleDeps(mux, deps)
+			mux.HandleFunc("/Bower/f/1.0.0/dependencies", func(w http.ResponseWriter, r *http.Request) {
+				t.Error("stopped dependency should not be resolved")
+			})
+
+			tree, err := client.ResolveTree(context.Background(), "npm", "app", "1.0.0", testCase.opts)
+			if err != nil {
+				t.Fatalf("ResolveTree returned unexpected error: %v", err)
+			}
+			if got := treeString(tree); got != testCase.want {
+				t.Errorf("\nExpected %v\nGot %v", testCase.want, got)
+			}
+		})
+	}
+}
+
+func TestResolveTree_maxNodes(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	handleDeps(mux, testTreeDeps)
+
+	tree, err := client.ResolveTree(context.Background(), "npm", "app", "1.0.0", &ResolveOptions{MaxNodes: 3})
+	if err != nil {
+		t.Fatalf("ResolveTree returned unexpected error: %v", err)
+	}
+
+	if got, want := treeString(tree), "app@1.0.0(a@1.2.0(c@1.0.5))"; got != want {
+		t.Errorf("\nExpected %v\nGot %v", want, got)
+	}
+}
+
+func TestResolveTree_sharedCache(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	calls := handleDeps(mux, testTreeDeps)
+	cache := NewResolutionCache()
+	opts := &ResolveOptions{Cache: cache}
+
+	for _, name := range []string{"a@1.2.0", "b@2.0.0"} {
+		parts := strings.SplitN(name, "@", 2)
+		if _, err := client.ResolveTree(context.Background(), "npm", parts[0], parts[1], opts); err != nil {
+			t.Fatalf("ResolveTree returned unexpected error: %v", err)
+		}
+	}
+
+	for key, n := range calls {
+		if n != 1 {
+			t.Errorf("dependencies of %v fetched %d times, want 1", key, n)
+		}
+	}
+	if got, want := cache.Len(), len(testTreeDeps); got != want {
+		t.Errorf("cache has %d entries, want %d", got, want)
+	}
+}
+
+func TestResolveTree_cancelled(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	deps := make(map[string]string)
+	for key, body := range testTreeDeps {
+		if key != "b@2.0.0" {
+			deps[key] = body
+		}
+	}
+	handleDeps(mux, deps)
+	mux.HandleFunc("/npm/b/2.0.0/dependencies", func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		<-r.Context().Done()
+	})
+
+	tree, err := client.ResolveTree(ctx, "npm", "app", "1.0.0", nil)
+	if !errors.Is(err, ErrPartialResult) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected partial result error, got %v", err)
+	}
+
+	if got, want := treeString(tree), "app@1.0.0(a@1.2.0(c@1.0.5(app@1.0.0)) b@2.0.0)"; got != want {
+		t.Errorf("\nExpected %v\nGot %v", want, got)
+	}
+}
+
+func TestResolveTree_error(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Not Found"}`, http.StatusNotFound)
+	})
+
+	if _, err := client.ResolveTree(context.Background(), "npm", "app", "1.0.0", nil); err == nil {
+		t.Fatal("Expected error to be returned")
+	}
+}
+
+func TestDependencyNodeWalk(t *testing.T) {
+	tree := &DependencyNode{
+		Name: "app",
+		Dependencies: []*DependencyNode{
+			{Name: "a", Dependencies: []*DependencyNode{{Name: "c"}}},
+			{Name: "b"},
+		},
+	}
+
+	var got []string
+	tree.Walk(func(node *DependencyNode, path []*DependencyNode) bool {
+		got = append(got, fmt.Sprintf("%v:%d", node.Name, len(path)))
+		return node.Name != "a"
+	})
+
+	want := []string{"app:0", "a:1", "b:1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, got)
+	}
+}
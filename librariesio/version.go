@@ -0,0 +1,114 @@
+package librariesio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Version returns the version of the library,
+// it is also sent as part of the User-Agent header
+func Version() string {
+	return libraryVersion
+}
+
+// compatibilityProbes are well-known endpoints used by CheckCompatibility,
+// new returns the model the response is decoded into
+var compatibilityProbes = []struct {
+	endpoint string
+	new      func() interface{}
+}{
+	{"NPM/base62", func() interface{} { return new(Project) }},
+	{"NPM/base62/latest/dependencies", func() interface{} { return new(Project) }},
+	{"search?q=base62&per_page=1", func() interface{} { return new([]*Project) }},
+	{"github/gruntjs/grunt", func() interface{} { return new(Repository) }},
+	{"github/andrew", func() interface{} { return new(User) }},
+}
+
+// CompatibilityReport is the result of CheckCompatibility
+type CompatibilityReport struct {
+	// Version is the version of the library that was checked
+	Version string
+
+	Checks []*CompatibilityCheck
+}
+
+// CompatibilityCheck holds the result of decoding a single endpoint
+type CompatibilityCheck struct {
+	Endpoint string
+
+	// Mismatches lists the values that could not be decoded into
+	// the model, e.g. because the API changed the type of a field
+	Mismatches []DecodeDiagnostic
+
+	// Unknown lists the paths of fields returned by the API that
+	// are not part of the model, they are ignored when decoding
+	Unknown []string
+
+	// Err is set if the endpoint could not be fetched
+	Err error
+}
+
+// Compatible reports whether all endpoints were fetched and decoded
+// without mismatches, unknown fields do not affect compatibility
+func (r *CompatibilityReport) Compatible() bool {
+	for _, check := range r.Checks {
+		if check.Err != nil || len(check.Mismatches) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckCompatibility fetches a handful of well-known endpoints and strictly
+// decodes them into the models of the library, reporting values that do
+// not match the models. It helps to detect API drift before rolling the
+// library out to a new environment. Failures of single endpoints are
+// reported in the checks. The endpoints are always fetched, responses
+// are neither taken from the cache nor shared with other requests. If
+// ctx is cancelled, the checks so far are returned with a
+// PartialResultError.
+func (c *Client) CheckCompatibility(ctx context.Context) (*CompatibilityReport, error) {
+	report := &CompatibilityReport{Version: Version()}
+
+	for _, probe := range compatibilityProbes {
+		check := &CompatibilityCheck{Endpoint: probe.endpoint}
+		report.Checks = append(report.Checks, check)
+
+		request, err := c.NewRequest(http.MethodGet, probe.endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := c.DoLazy(ctx, request, WithNoCache())
+		if err != nil {
+			if err, ok := partialResult(ctx, err); ok {
+				report.Checks = report.Checks[:len(report.Checks)-1]
+				return report, err
+			}
+			check.Err = err
+			continue
+		}
+
+		// Conditional requests set on ctx and dry runs leave nothing to decode
+		if response.NotModified || response.body == nil {
+			check.Err = fmt.Errorf("%v returned no body to check", probe.endpoint)
+			continue
+		}
+
+		diagnostics, err := decodeTolerant(response.body, probe.new(), false)
+		if err != nil {
+			check.Err = err
+			continue
+		}
+		for _, d := range diagnostics {
+			if d.Err == nil {
+				check.Unknown = append(check.Unknown, d.Path)
+			} else {
+				check.Mismatches = append(check.Mismatches, d)
+			}
+		}
+	}
+
+	return report, nil
+}
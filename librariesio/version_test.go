@@ -0,0 +1,128 @@
+package librariesio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestVersion(t *testing.T) {
+	if !strings.HasSuffix(userAgent, "/"+Version()) {
+		t.Errorf("expected User-Agent %q to contain version %q", userAgent, Version())
+	}
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/NPM/base62", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "base62", "stars": "many", "funding_urls": []}`)
+	})
+	mux.HandleFunc("/NPM/base62/latest/dependencies", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "base62", "dependencies": []}`)
+	})
+	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"name": "base62"}]`)
+	})
+	mux.HandleFunc("/github/gruntjs/grunt", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"full_name": "gruntjs/grunt"}`)
+	})
+	mux.HandleFunc("/github/andrew", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Internal Server Error"}`, http.StatusInternalServerError)
+	})
+
+	report, err := client.CheckCompatibility(context.Background())
+	if err != nil {
+		t.Fatalf("CheckCompatibility returned unexpected error: %v", err)
+	}
+
+	if report.Compatible() {
+		t.Error("expected report to be incompatible")
+	}
+	if len(report.Checks) != len(compatibilityProbes) {
+		t.Fatalf("expected %d checks, got %d", len(compatibilityProbes), len(report.Checks))
+	}
+
+	project := report.Checks[0]
+	if len(project.Mismatches) != 1 || project.Mismatches[0].Path != "stars" {
+		t.Errorf("expected mismatch of stars, got %v", project.Mismatches)
+	}
+	if want := []string{"funding_urls"}; !reflect.DeepEqual(project.Unknown, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, project.Unknown)
+	}
+	for _, check := range report.Checks[1:4] {
+		if check.Err != nil || len(check.Mismatches) > 0 {
+			t.Errorf("expected %v to be compatible, got %v", check.Endpoint, check.Mismatches)
+		}
+	}
+	if report.Checks[4].Err == nil {
+		t.Error("expected error for failed endpoint")
+	}
+}
+
+func TestCheckCompatibility_cancelled(t *testing.T) {
+	client := NewClient(APIKey)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report, err := client.CheckCompatibility(ctx)
+	if !errors.Is(err, ErrPartialResult) {
+		t.Errorf("expected PartialResultError, got %v", err)
+	}
+	if report == nil || len(report.Checks) != 0 {
+		t.Errorf("expected empty partial report, got %v", report)
+	}
+}
+
+func TestCheckCompatibility_uncached(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey, WithCache(NewLRUCache(1<<20, nil)))
+	client.BaseURL = url
+	defer server.Close()
+
+	var calls int
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{}`)
+	})
+
+	if _, _, err := client.Project(context.Background(), "NPM", "base62"); err != nil {
+		t.Fatalf("Project returned unexpected error: %v", err)
+	}
+	if _, err := client.CheckCompatibility(context.Background()); err != nil {
+		t.Fatalf("CheckCompatibility returned unexpected error: %v", err)
+	}
+	if want := 1 + len(compatibilityProbes); calls != want {
+		t.Errorf("expected every endpoint to be fetched, got %d requests, want %d", calls, want)
+	}
+}
+
+func TestCheckCompatibility_notModified(t *testing.T) {
+	server, mux, url := startNewServer()
+	client := NewClient(APIKey)
+	client.BaseURL = url
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	})
+
+	ctx := NewContext(context.Background(), WithIfNoneMatch(`"abc"`))
+	report, err := client.CheckCompatibility(ctx)
+	if err != nil {
+		t.Fatalf("CheckCompatibility returned unexpected error: %v", err)
+	}
+	for _, check := range report.Checks {
+		if check.Err == nil {
+			t.Errorf("expected an error for %v without a body", check.Endpoint)
+		}
+	}
+}
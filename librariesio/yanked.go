@@ -0,0 +1,64 @@
+package librariesio
+
+import (
+	"strings"
+)
+
+// RemovedReleases compares two fetches of the same project and returns
+// the releases of previous that are missing from current, which usually
+// means they were yanked or removed upstream. If current lists no
+// versions at all, nothing is returned, as that is more likely an
+// incomplete response than every release being removed.
+func RemovedReleases(previous, current *Project) []*Release {
+	if previous == nil || current == nil || len(current.Versions) == 0 {
+		return nil
+	}
+
+	listed := make(map[string]bool, len(current.Versions))
+	for _, r := range current.Versions {
+		if r != nil && r.Number != nil {
+			listed[releaseKey(*r.Number)] = true
+		}
+	}
+
+	var removed []*Release
+	seen := make(map[string]bool)
+	for _, r := range previous.Versions {
+		if r == nil || r.Number == nil {
+			continue
+		}
+		key := releaseKey(*r.Number)
+		if !listed[key] && !seen[key] {
+			seen[key] = true
+			removed = append(removed, r)
+		}
+	}
+	return removed
+}
+
+// DetectRemovedReleases returns an EventReleaseRemoved event for every
+// release returned by RemovedReleases, to be passed to a Notifier
+func DetectRemovedReleases(previous, current *Project) []Event {
+	var events []Event
+	for _, r := range RemovedReleases(previous, current) {
+		event := Event{
+			Type:     EventReleaseRemoved,
+			Platform: stringValue(current.Platform),
+			Name:     stringValue(current.Name),
+			Version:  *r.Number,
+			Time:     now(),
+		}
+		if r.PublishedAt != nil {
+			event.Message = "published " + r.PublishedAt.Format("2006-01-02")
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// releaseKey ignores a leading v, which registries and the API do
+// not always report consistently
+func releaseKey(number string) string {
+	number = strings.TrimSpace(number)
+	return strings.TrimPrefix(strings.TrimPrefix(number, "v"), "V")
+}
@@ -0,0 +1,52 @@
+package librariesio
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDetectRemovedReleases(t *testing.T) {
+	defer func(f func() time.Time) { now = f }(now)
+	at := time.Date(2017, time.April, 1, 0, 0, 0, 0, time.UTC)
+	now = func() time.Time { return at }
+
+	previous := &Project{
+		Platform: String("NPM"),
+		Name:     String("left-pad"),
+		Versions: []*Release{
+			{Number: String("1.0.0"), PublishedAt: Time(time.Date(2014, time.March, 1, 0, 0, 0, 0, time.UTC))},
+			{Number: String("v1.1.0")},
+			{Number: String("1.2.0")},
+			{Number: String("1.3.0")},
+		},
+	}
+	current := &Project{
+		Platform: String("NPM"),
+		Name:     String("left-pad"),
+		Versions: []*Release{
+			{Number: String("1.1.0")},
+			{Number: String("1.3.0")},
+			{Number: String("1.4.0")},
+		},
+	}
+
+	want := []Event{
+		{Type: EventReleaseRemoved, Platform: "NPM", Name: "left-pad", Version: "1.0.0", Time: at, Message: "published 2014-03-01"},
+		{Type: EventReleaseRemoved, Platform: "NPM", Name: "left-pad", Version: "1.2.0", Time: at},
+	}
+	if got := DetectRemovedReleases(previous, current); !reflect.DeepEqual(got, want) {
+		t.Errorf("\nExpected %v\nGot %v", want, got)
+	}
+	if got, want := want[0].String(), "NPM/left-pad: release removed 1.0.0 (published 2014-03-01)"; got != want {
+		t.Errorf("String() returned %q, want %q", got, want)
+	}
+
+	// A response without versions is not taken as every release being removed
+	if got := DetectRemovedReleases(previous, &Project{Name: String("left-pad")}); len(got) != 0 {
+		t.Errorf("expected no events, got %v", got)
+	}
+	if got := RemovedReleases(nil, current); got != nil {
+		t.Errorf("expected no releases without a previous fetch, got %v", got)
+	}
+}